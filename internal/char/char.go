@@ -48,6 +48,28 @@ func (l RangeList) Exclude(x RangeList) RangeList {
 	return res
 }
 
+// FoldCase returns a new RangeList that also includes the case-folded equivalents of all characters in l.
+// The equivalents of a character are found by following its Unicode simple case folding orbit (e.g., k, K, and K).
+func (l RangeList) FoldCase() RangeList {
+	list := disc.NewRangeList[rune](nil)
+	for _, r := range l {
+		list.Add(disc.Range[rune]{Lo: r[0], Hi: r[1]})
+
+		for c := r[0]; c <= r[1]; c++ {
+			for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+				list.Add(disc.Range[rune]{Lo: f, Hi: f})
+			}
+		}
+	}
+
+	var res RangeList
+	for r := range list.All() {
+		res = append(res, Range{r.Lo, r.Hi})
+	}
+
+	return res
+}
+
 // unicodeCategoryToRanges converts a Go unicode.RangeTable into a flat list of Range.
 func unicodeCategoryToRanges(c *unicode.RangeTable) RangeList {
 	var ranges RangeList
@@ -40,6 +40,58 @@ func TestRangeList_Dedup(t *testing.T) {
 	}
 }
 
+func TestRangeList_FoldCase(t *testing.T) {
+	tests := []struct {
+		name           string
+		l              RangeList
+		expectedResult RangeList
+	}{
+		{
+			name: "NoCase",
+			l: RangeList{
+				{'0', '9'},
+				{'_', '_'},
+			},
+			expectedResult: RangeList{
+				{'0', '9'},
+				{'_', '_'},
+			},
+		},
+		{
+			name: "Letters",
+			l: RangeList{
+				{'a', 'c'},
+				{'X', 'X'},
+			},
+			expectedResult: RangeList{
+				{'A', 'C'},
+				{'X', 'X'},
+				{'a', 'c'},
+				{'x', 'x'},
+			},
+		},
+		{
+			name: "Orbit",
+			l: RangeList{
+				{'k', 'k'},
+			},
+			expectedResult: RangeList{
+				{'K', 'K'},
+				{'k', 'k'},
+				{0x212A, 0x212A}, // Kelvin Sign
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.l.FoldCase()
+
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
+
 func TestRangeList_Exclude(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -2,7 +2,7 @@
 package command
 
 import (
-	"errors"
+	"fmt"
 	"io"
 	"math/rand"
 	"os"
@@ -12,6 +12,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/gardenbed/charm/ui"
+	"github.com/moorara/algo/errors"
 	"github.com/moorara/algo/generic"
 
 	"github.com/gardenbed/emerge/internal/ebnf/parser/spec"
@@ -19,7 +20,7 @@ import (
 )
 
 const helpTemplate = `
-  {{green "Emerge"}} is a parser generator that produces an {{magenta "LALR(1)"}} parser for Go from an {{magenta "EBNF"}} specification of a context-free grammar.
+  {{green "Emerge"}} is a parser generator that produces an {{magenta "LR"}} parser for Go from an {{magenta "EBNF"}} specification of a context-free grammar.
   It also generates a lexical analyzer and all necessary auxiliary types.
   The generated code is {{magenta "self-contained"}} and does not rely on any external third-party modules.
 
@@ -48,9 +49,10 @@ const helpTemplate = `
     1. {{blue "Implicitly"}}—by specifying string values.
     2. {{blue "Explicitly"}}—by defining a token name and a token definition using strings or regular expressions.
 
-  {{yellow "LALR(1) Parsing"}}
+  {{yellow "LR Parsing"}}
 
-  {{green "Emerge"}} generates an {{blue "LALR(1)"}} parser, a type of {{blue "bottom-up"}} parser designed for {{blue "LR(1)"}} languages.
+  {{green "Emerge"}} generates an {{blue "LALR(1)"}} parser by default, a type of {{blue "bottom-up"}} parser designed for {{blue "LR(1)"}} languages.
+  Alternatively, the parsing table can be constructed using the {{blue "SLR(1)"}} or the {{blue "canonical LR(1)"}} algorithm.
   LR parsing methods can handle a larger class of grammars than LL (predictive top-down) parsing methods.
   An LR parser is expressive enough to recognize almost all programming language constructs described by context-free grammars.
   The generated parser uses a precomputed parsing table and operates in linear time {{blue "O(n)"}}.
@@ -75,6 +77,11 @@ const helpTemplate = `
     {{cyan "🔗 https://gardenbed.github.io/emerge"}}
 
   {{yellow "Usage:"}}  {{ green "emerge [flags] FILE_PATH"}}
+          {{ green "emerge [flags] check FILE_PATH"}}
+
+  {{yellow "Commands:"}}
+
+    check        Validate the grammar specification, the lexer, and the parsing table without generating any code.
 
   {{yellow "Flags:"}}
 
@@ -85,6 +92,8 @@ const helpTemplate = `
     -out=path    Generate the parser in the specified directory.
     -name=foo    Generate the parser with the specified name and ignore the name in the grammar specification.
     -debug       Generate the parser with extra types and methods for debugging and troubleshooting purposes.
+    -force       Regenerate the parser in an existing package directory and replace only the files generated by emerge.
+    -table=lalr  Construct the parsing table using one of the slr, lalr, or lr1 algorithms (default: {{.Table}}).
 
   {{yellow "Examples:"}}
 
@@ -92,6 +101,9 @@ const helpTemplate = `
     emerge -out="~/src/project/internal" grammar.ebnf
     emerge -name="parser" grammar.ebnf
     emerge -debug grammar.ebnf
+    emerge -force grammar.ebnf
+    emerge -table=lr1 grammar.ebnf
+    emerge check grammar.ebnf
 
 `
 
@@ -125,12 +137,14 @@ type Command struct {
 	Out   string `flag:"out"`
 	Name  string `flag:"name"`
 	Debug bool   `flag:"debug"`
+	Force bool   `flag:"force"`
+	Table string `flag:"table"`
 }
 
 // funcs defines the function types required by the command.
 // This abstraction allows these functions to be mocked for testing purposes.
 type funcs struct {
-	Parse    func(string, io.Reader) (*spec.Spec, error)
+	Parse    func(string, string, io.Reader) (*spec.Spec, error)
 	Generate func(ui.UI, *golang.Params) error
 }
 
@@ -142,11 +156,12 @@ func New(u ui.UI) (*Command, error) {
 	}
 
 	c := &Command{
-		UI:  u,
-		Out: path,
+		UI:    u,
+		Out:   path,
+		Table: string(spec.LALR),
 	}
 
-	c.funcs.Parse = spec.Parse
+	c.funcs.Parse = spec.ParseDir
 	c.funcs.Generate = golang.Generate
 
 	return c, nil
@@ -167,43 +182,115 @@ func (c *Command) PrintHelp() error {
 // Run runs the actual command with the given command-line arguments.
 // This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
 func (c *Command) Run(args []string) error {
-	path, ok := generic.FirstMatch(args, func(a string) bool {
+	args = generic.SelectMatch(args, func(a string) bool {
 		return !strings.HasPrefix(a, "-")
 	})
 
-	if !ok {
-		return errors.New("no input file specified, please provide a file path")
+	if len(args) > 0 && args[0] == "check" {
+		return c.check(args[1:])
+	}
+
+	return c.generate(args)
+}
+
+// parse reads and parses the EBNF specification from the file path given as the first argument.
+func (c *Command) parse(args []string) (*spec.Spec, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no input file specified, please provide a file path")
 	}
 
-	filename := filepath.Base(path)
+	path := args[0]
+	filename := displayName(path)
 
 	c.Infof(plum, "%c Parsing %q ...", getPlant(), filename)
 
 	f, err := os.Open(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer func() {
 		_ = f.Close()
 	}()
 
-	spec, err := c.funcs.Parse(filename, f)
+	// The input is named after its path relative to the working directory,
+	// while the files imported by the grammar are resolved relative to the directory of the input.
+	return c.funcs.Parse(filepath.Dir(path), filename, f)
+}
+
+// displayName returns the name of a file relative to the working directory,
+// so the file is named the same regardless of how its path is given.
+// It returns the path itself if it cannot be made relative to the working directory.
+func displayName(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+// check validates the EBNF specification without generating any code or writing anything to disk.
+// It reports all issues found in the lexer and parsing table construction rather than stopping at the first one.
+func (c *Command) check(args []string) error {
+	s, err := c.parse(args)
+	if err != nil {
+		return err
+	}
+
+	c.Infof(gold, "%c Checking lexer and parser ...", getAnimal())
+
+	errs := &errors.MultiError{
+		Format: errors.BulletErrorFormat,
+	}
+
+	if _, err := s.BuildLexerModes(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if _, err := s.ParsingTable(spec.TableKind(c.Table)); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	c.Infof(chartreuse, "%c No issues found!", getFruit())
+
+	return nil
+}
+
+// generate parses the EBNF specification and generates the parser code.
+func (c *Command) generate(args []string) error {
+	s, err := c.parse(args)
 	if err != nil {
 		return err
 	}
 
 	// Override the grammar name if specified via command-line flag.
 	if c.Name != "" {
-		spec.Name = c.Name
+		s.Name = c.Name
 	}
 
 	c.Infof(gold, "%c Generating parser ...", getAnimal())
 
 	err = c.funcs.Generate(c.UI, &golang.Params{
 		Debug: c.Debug,
+		Force: c.Force,
+		Table: spec.TableKind(c.Table),
 		Path:  c.Out,
-		Spec:  spec,
+		Spec:  s,
 	})
 
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gardenbed/charm/ui"
@@ -102,7 +103,7 @@ func TestCommand_Run(t *testing.T) {
 			c: &Command{
 				UI: ui.NewNop(),
 				funcs: funcs{
-					Parse: func(string, io.Reader) (*spec.Spec, error) {
+					Parse: func(string, string, io.Reader) (*spec.Spec, error) {
 						return nil, errors.New("error on parsing the input")
 					},
 				},
@@ -119,7 +120,7 @@ func TestCommand_Run(t *testing.T) {
 			c: &Command{
 				UI: ui.NewNop(),
 				funcs: funcs{
-					Parse: func(string, io.Reader) (*spec.Spec, error) {
+					Parse: func(string, string, io.Reader) (*spec.Spec, error) {
 						return &spec.Spec{}, nil
 					},
 					Generate: func(ui.UI, *golang.Params) error {
@@ -139,7 +140,7 @@ func TestCommand_Run(t *testing.T) {
 			c: &Command{
 				UI: ui.NewNop(),
 				funcs: funcs{
-					Parse: func(string, io.Reader) (*spec.Spec, error) {
+					Parse: func(string, string, io.Reader) (*spec.Spec, error) {
 						return &spec.Spec{}, nil
 					},
 					Generate: func(ui.UI, *golang.Params) error {
@@ -150,12 +151,139 @@ func TestCommand_Run(t *testing.T) {
 				Out:   "/path/to/destination",
 				Name:  "override",
 				Debug: false,
+				Force: true,
 			},
 			args: []string{
 				"../ebnf/fixture/test.success.grammar",
 			},
 			expectedErrorStrings: nil,
 		},
+		{
+			name: "Check_Error_NoFile",
+			c: &Command{
+				UI:    ui.NewNop(),
+				funcs: funcs{},
+			},
+			args: []string{"check"},
+			expectedErrorStrings: []string{
+				`no input file specified, please provide a file path`,
+			},
+		},
+		{
+			name: "Check_Error_ParseFails",
+			c: &Command{
+				UI: ui.NewNop(),
+				funcs: funcs{
+					Parse: func(string, string, io.Reader) (*spec.Spec, error) {
+						return nil, errors.New("error on parsing the input")
+					},
+				},
+			},
+			args: []string{
+				"check",
+				"../ebnf/fixture/test.success.grammar",
+			},
+			expectedErrorStrings: []string{
+				`error on parsing the input`,
+			},
+		},
+		{
+			name: "Check_Error_Conflicts",
+			c: &Command{
+				UI: ui.NewNop(),
+				funcs: funcs{
+					Parse: spec.ParseDir,
+				},
+				Table: "lalr",
+			},
+			args: []string{
+				"check",
+				"../ebnf/fixture/test.conflict.grammar",
+			},
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`conflicting definitions capture the same string:`,
+				`test.conflict.grammar:4:1: "ID"`,
+				`test.conflict.grammar:5:1: "KEYWORD"`,
+				`error on building LALR(1) parsing table:`,
+				`Shift/Reduce conflict on "+":`,
+				`Example: expr "+" expr • "+" expr`,
+				`expr → expr "+" expr •  <test.conflict.grammar:8:`,
+				`expr → expr • "+" expr  <test.conflict.grammar:8:`,
+			},
+		},
+		{
+			name: "Check_Error_InvalidTable",
+			c: &Command{
+				UI: ui.NewNop(),
+				funcs: funcs{
+					Parse: spec.ParseDir,
+				},
+				Table: "glr",
+			},
+			args: []string{
+				"check",
+				"../ebnf/fixture/test.success.grammar",
+			},
+			expectedErrorStrings: []string{
+				`invalid parsing table "glr", expected one of slr, lalr, or lr1`,
+			},
+		},
+		{
+			name: "Check_Success",
+			c: &Command{
+				UI: ui.NewNop(),
+				funcs: funcs{
+					Parse: spec.ParseDir,
+					Generate: func(ui.UI, *golang.Params) error {
+						return errors.New("check must not generate the parser")
+					},
+				},
+				Table: "lr1",
+			},
+			args: []string{
+				"check",
+				"../ebnf/fixture/json.grammar",
+			},
+			expectedErrorStrings: nil,
+		},
+		{
+			name: "Check_Success_Imports",
+			c: &Command{
+				UI: ui.NewNop(),
+				funcs: funcs{
+					Parse: spec.ParseDir,
+					Generate: func(ui.UI, *golang.Params) error {
+						return errors.New("check must not generate the parser")
+					},
+				},
+				Table: "lalr",
+			},
+			args: []string{
+				"check",
+				"../ebnf/fixture/test.imports.grammar",
+			},
+			expectedErrorStrings: nil,
+		},
+		{
+			name: "Check_Error_Imports",
+			c: &Command{
+				UI: ui.NewNop(),
+				funcs: funcs{
+					Parse: spec.ParseDir,
+				},
+				Table: "lalr",
+			},
+			args: []string{
+				"check",
+				"../ebnf/fixture/../fixture/test.imports.error.grammar",
+			},
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`import cycle ../ebnf/fixture/imports/cycle.a.grammar → ../ebnf/fixture/imports/cycle.b.grammar → ../ebnf/fixture/imports/cycle.a.grammar: ../ebnf/fixture/imports/cycle.b.grammar:4:8`,
+				`cannot import ../ebnf/fixture/imports/missing.grammar: no such file or directory: ../ebnf/fixture/test.imports.error.grammar:5:8`,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -174,3 +302,36 @@ func TestCommand_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayName(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedName string
+	}{
+		{
+			name:         "Relative",
+			path:         "../ebnf/fixture/json.grammar",
+			expectedName: "../ebnf/fixture/json.grammar",
+		},
+		{
+			name:         "Unclean",
+			path:         "./../ebnf/../ebnf/fixture/json.grammar",
+			expectedName: "../ebnf/fixture/json.grammar",
+		},
+		{
+			name:         "Absolute",
+			path:         filepath.Join(wd, "test.grammar"),
+			expectedName: "test.grammar",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, displayName(tc.path))
+		})
+	}
+}
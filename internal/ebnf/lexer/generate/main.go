@@ -51,14 +51,36 @@ func BuildDFA() *automata.DFA {
 			13,                         // RRBRACE
 			14,                         // LANGLE
 			15,                         // RANGLE
+			128,                        // SEP
+			129,                        // TSEP
+			130,                        // COMMA
 			17,                         // PREDEF
 			22,                         // LASSOC
 			27,                         // RASSOC
 			31,                         // NOASSOC
+			73,                         // MODE
+			76,                         // POP
+			79,                         // PUSH
+			85,                         // SWITCH
+			88,                         // SKIP
+			98,                         // WHITESPACE
+			104,                        // INDENT
+			112,                        // PRIORITY
+			120,                        // FRAGMENT
+			127,                        // START
+			147,                        // OVERRIDE
+			152,                        // REMOVE
+			160,                        // EXTERNAL
+			122,                        // LABEL
+			123,                        // FIELD
+			133,                        // CODE
 			38,                         // GRAMMER
+			139,                        // IMPORT
 			32, 33, 34, 35, 36, 37, 39, // IDENT
+			134, 135, 136, 137, 138, // IDENT
 			40,     // TOKEN
 			61,     // STRING
+			105,    // ISTRING
 			65,     // REGEX
 			66, 69, // COMMENT
 		})
@@ -82,7 +104,9 @@ func BuildDFA() *automata.DFA {
 		AddTransition(0, '{', '{', 10).AddTransition(10, '{', '{', 12).
 		AddTransition(0, '}', '}', 11).AddTransition(11, '}', '}', 13).
 		AddTransition(0, '<', '<', 14).
-		AddTransition(0, '>', '>', 15)
+		AddTransition(0, '>', '>', 15).
+		AddTransition(0, '%', '%', 128).AddTransition(128, '%', '%', 129).
+		AddTransition(0, ',', ',', 130)
 
 	// PREDEFINED TOKENS
 	b.AddTransition(0, '$', '$', 16).
@@ -97,7 +121,7 @@ func BuildDFA() *automata.DFA {
 
 	// GRAMMER & IDENT
 	b.AddTransition(0, 'g', 'g', 32).AddTransition(32, 'r', 'r', 33).AddTransition(33, 'a', 'a', 34).AddTransition(34, 'm', 'm', 35).AddTransition(35, 'm', 'm', 36).AddTransition(36, 'a', 'a', 37).AddTransition(37, 'r', 'r', 38).
-		AddTransition(0, 'a', 'f', 39).AddTransition(0, 'h', 'z', 39).
+		AddTransition(0, 'a', 'f', 39).AddTransition(0, 'h', 'h', 39).AddTransition(0, 'j', 'z', 39).
 		AddTransition(32, '0', '9', 39).AddTransition(32, '_', '_', 39).AddTransition(32, 'a', 'q', 39).AddTransition(32, 's', 'z', 39).
 		AddTransition(33, '0', '9', 39).AddTransition(33, '_', '_', 39).AddTransition(33, 'b', 'z', 39).
 		AddTransition(34, '0', '9', 39).AddTransition(34, '_', '_', 39).AddTransition(34, 'a', 'l', 39).AddTransition(34, 'n', 'z', 39).
@@ -107,6 +131,15 @@ func BuildDFA() *automata.DFA {
 		AddTransition(38, '0', '9', 39).AddTransition(38, '_', '_', 39).AddTransition(38, 'a', 'z', 39).
 		AddTransition(39, '0', '9', 39).AddTransition(39, '_', '_', 39).AddTransition(39, 'a', 'z', 39)
 
+	// IMPORT & IDENT
+	b.AddTransition(0, 'i', 'i', 134).AddTransition(134, 'm', 'm', 135).AddTransition(135, 'p', 'p', 136).AddTransition(136, 'o', 'o', 137).AddTransition(137, 'r', 'r', 138).AddTransition(138, 't', 't', 139).
+		AddTransition(134, '0', '9', 39).AddTransition(134, '_', '_', 39).AddTransition(134, 'a', 'l', 39).AddTransition(134, 'n', 'z', 39).
+		AddTransition(135, '0', '9', 39).AddTransition(135, '_', '_', 39).AddTransition(135, 'a', 'o', 39).AddTransition(135, 'q', 'z', 39).
+		AddTransition(136, '0', '9', 39).AddTransition(136, '_', '_', 39).AddTransition(136, 'a', 'n', 39).AddTransition(136, 'p', 'z', 39).
+		AddTransition(137, '0', '9', 39).AddTransition(137, '_', '_', 39).AddTransition(137, 'a', 'q', 39).AddTransition(137, 's', 'z', 39).
+		AddTransition(138, '0', '9', 39).AddTransition(138, '_', '_', 39).AddTransition(138, 'a', 's', 39).AddTransition(138, 'u', 'z', 39).
+		AddTransition(139, '0', '9', 39).AddTransition(139, '_', '_', 39).AddTransition(139, 'a', 'z', 39)
+
 	// TOKEN
 	b.AddTransition(0, 'A', 'Z', 40).
 		AddTransition(40, '0', '9', 40).AddTransition(40, 'A', 'Z', 40).AddTransition(40, '_', '_', 40)
@@ -124,7 +157,8 @@ func BuildDFA() *automata.DFA {
 		AddTransition(42, 'n', 'n', 43).
 		AddTransition(42, 'r', 'r', 43).
 		AddTransition(43, '\\', '\\', 42).
-		AddTransition(43, '"', '"', 61)
+		AddTransition(43, '"', '"', 61).
+		AddTransition(61, 'i', 'i', 105)
 
 	// ASCII Escapes: \xhh
 	b.AddTransition(42, 'x', 'x', 44).
@@ -217,6 +251,50 @@ func BuildDFA() *automata.DFA {
 		b.AddTransition(68, lo, hi, 67)
 	}
 
+	// MODE TOKENS
+	b.AddTransition(18, 'm', 'm', 70).AddTransition(70, 'o', 'o', 71).AddTransition(71, 'd', 'd', 72).AddTransition(72, 'e', 'e', 73).
+		AddTransition(18, 'p', 'p', 74).AddTransition(74, 'o', 'o', 75).AddTransition(75, 'p', 'p', 76).
+		AddTransition(74, 'u', 'u', 77).AddTransition(77, 's', 's', 78).AddTransition(78, 'h', 'h', 79).
+		AddTransition(18, 's', 's', 80).AddTransition(80, 'w', 'w', 81).AddTransition(81, 'i', 'i', 82).AddTransition(82, 't', 't', 83).AddTransition(83, 'c', 'c', 84).AddTransition(84, 'h', 'h', 85).
+		AddTransition(80, 'k', 'k', 86).AddTransition(86, 'i', 'i', 87).AddTransition(87, 'p', 'p', 88).
+		AddTransition(18, 'w', 'w', 89).AddTransition(89, 'h', 'h', 90).AddTransition(90, 'i', 'i', 91).AddTransition(91, 't', 't', 92).AddTransition(92, 'e', 'e', 93).AddTransition(93, 's', 's', 94).AddTransition(94, 'p', 'p', 95).AddTransition(95, 'a', 'a', 96).AddTransition(96, 'c', 'c', 97).AddTransition(97, 'e', 'e', 98).
+		AddTransition(18, 'i', 'i', 99).AddTransition(99, 'n', 'n', 100).AddTransition(100, 'd', 'd', 101).AddTransition(101, 'e', 'e', 102).AddTransition(102, 'n', 'n', 103).AddTransition(103, 't', 't', 104).
+		AddTransition(74, 'r', 'r', 106).AddTransition(106, 'i', 'i', 107).AddTransition(107, 'o', 'o', 108).AddTransition(108, 'r', 'r', 109).AddTransition(109, 'i', 'i', 110).AddTransition(110, 't', 't', 111).AddTransition(111, 'y', 'y', 112).
+		AddTransition(18, 'f', 'f', 113).AddTransition(113, 'r', 'r', 114).AddTransition(114, 'a', 'a', 115).AddTransition(115, 'g', 'g', 116).AddTransition(116, 'm', 'm', 117).AddTransition(117, 'e', 'e', 118).AddTransition(118, 'n', 'n', 119).AddTransition(119, 't', 't', 120).
+		AddTransition(80, 't', 't', 124).AddTransition(124, 'a', 'a', 125).AddTransition(125, 'r', 'r', 126).AddTransition(126, 't', 't', 127).
+		AddTransition(18, 'o', 'o', 140).AddTransition(140, 'v', 'v', 141).AddTransition(141, 'e', 'e', 142).AddTransition(142, 'r', 'r', 143).AddTransition(143, 'r', 'r', 144).AddTransition(144, 'i', 'i', 145).AddTransition(145, 'd', 'd', 146).AddTransition(146, 'e', 'e', 147).
+		AddTransition(23, 'e', 'e', 148).AddTransition(148, 'm', 'm', 149).AddTransition(149, 'o', 'o', 150).AddTransition(150, 'v', 'v', 151).AddTransition(151, 'e', 'e', 152).
+		AddTransition(18, 'e', 'e', 153).AddTransition(153, 'x', 'x', 154).AddTransition(154, 't', 't', 155).AddTransition(155, 'e', 'e', 156).AddTransition(156, 'r', 'r', 157).AddTransition(157, 'n', 'n', 158).AddTransition(158, 'a', 'a', 159).AddTransition(159, 'l', 'l', 160)
+
+	// LABEL
+	b.AddTransition(0, '#', '#', 121).
+		AddTransition(121, 'A', 'Z', 122).
+		AddTransition(122, '0', '9', 122).AddTransition(122, 'A', 'Z', 122).AddTransition(122, '_', '_', 122).AddTransition(122, 'a', 'z', 122)
+
+	// FIELD
+	b.AddTransition(32, ':', ':', 123).AddTransition(33, ':', ':', 123).AddTransition(34, ':', ':', 123).AddTransition(35, ':', ':', 123).
+		AddTransition(36, ':', ':', 123).AddTransition(37, ':', ':', 123).AddTransition(38, ':', ':', 123).AddTransition(39, ':', ':', 123).
+		AddTransition(134, ':', ':', 123).AddTransition(135, ':', ':', 123).AddTransition(136, ':', ':', 123).AddTransition(137, ':', ':', 123).
+		AddTransition(138, ':', ':', 123).AddTransition(139, ':', ':', 123)
+
+	// CODE
+	b.AddTransition(10, '%', '%', 131).
+		AddTransition(131, '%', '%', 132).
+		AddTransition(132, '%', '%', 132).
+		AddTransition(132, '}', '}', 133)
+
+	// All Unicode characters except %
+	for _, r := range char.Classes["UNICODE"].Exclude(char.RangeList{{'%', '%'}}) {
+		lo, hi := automata.Symbol(r[0]), automata.Symbol(r[1])
+		b.AddTransition(131, lo, hi, 131)
+	}
+
+	// All Unicode characters except % }
+	for _, r := range char.Classes["UNICODE"].Exclude(char.RangeList{{'%', '%'}, {'}', '}'}}) {
+		lo, hi := automata.Symbol(r[0]), automata.Symbol(r[1])
+		b.AddTransition(132, lo, hi, 131)
+	}
+
 	return b.Build()
 }
 
@@ -298,6 +376,21 @@ func BuildTokenSpecs() tokenSpecs {
 		LexemeValue:  stringPtr(">"),
 	})
 
+	specs.Put(automata.NewStates(128), tokenSpec{
+		TerminalName: "SEP",
+		LexemeValue:  stringPtr("%"),
+	})
+
+	specs.Put(automata.NewStates(129), tokenSpec{
+		TerminalName: "TSEP",
+		LexemeValue:  stringPtr("%%"),
+	})
+
+	specs.Put(automata.NewStates(130), tokenSpec{
+		TerminalName: "COMMA",
+		LexemeValue:  stringPtr(","),
+	})
+
 	specs.Put(automata.NewStates(17), tokenSpec{
 		TerminalName: "PREDEF",
 	})
@@ -317,12 +410,82 @@ func BuildTokenSpecs() tokenSpecs {
 		LexemeValue:  stringPtr("@none"),
 	})
 
+	specs.Put(automata.NewStates(73), tokenSpec{
+		TerminalName: "MODE",
+		LexemeValue:  stringPtr("@mode"),
+	})
+
+	specs.Put(automata.NewStates(76), tokenSpec{
+		TerminalName: "POP",
+		LexemeValue:  stringPtr("@pop"),
+	})
+
+	specs.Put(automata.NewStates(79), tokenSpec{
+		TerminalName: "PUSH",
+		LexemeValue:  stringPtr("@push"),
+	})
+
+	specs.Put(automata.NewStates(85), tokenSpec{
+		TerminalName: "SWITCH",
+		LexemeValue:  stringPtr("@switch"),
+	})
+
+	specs.Put(automata.NewStates(88), tokenSpec{
+		TerminalName: "SKIP",
+		LexemeValue:  stringPtr("@skip"),
+	})
+
+	specs.Put(automata.NewStates(98), tokenSpec{
+		TerminalName: "WHITESPACE",
+		LexemeValue:  stringPtr("@whitespace"),
+	})
+
+	specs.Put(automata.NewStates(104), tokenSpec{
+		TerminalName: "INDENT",
+		LexemeValue:  stringPtr("@indent"),
+	})
+
+	specs.Put(automata.NewStates(112), tokenSpec{
+		TerminalName: "PRIORITY",
+		LexemeValue:  stringPtr("@priority"),
+	})
+
+	specs.Put(automata.NewStates(120), tokenSpec{
+		TerminalName: "FRAGMENT",
+		LexemeValue:  stringPtr("@fragment"),
+	})
+
+	specs.Put(automata.NewStates(127), tokenSpec{
+		TerminalName: "START",
+		LexemeValue:  stringPtr("@start"),
+	})
+
+	specs.Put(automata.NewStates(147), tokenSpec{
+		TerminalName: "OVERRIDE",
+		LexemeValue:  stringPtr("@override"),
+	})
+
+	specs.Put(automata.NewStates(152), tokenSpec{
+		TerminalName: "REMOVE",
+		LexemeValue:  stringPtr("@remove"),
+	})
+
+	specs.Put(automata.NewStates(160), tokenSpec{
+		TerminalName: "EXTERNAL",
+		LexemeValue:  stringPtr("@external"),
+	})
+
 	specs.Put(automata.NewStates(38), tokenSpec{
 		TerminalName: "GRAMMER",
 		LexemeValue:  stringPtr("grammar"),
 	})
 
-	specs.Put(automata.NewStates(32, 33, 34, 35, 36, 37, 39), tokenSpec{
+	specs.Put(automata.NewStates(139), tokenSpec{
+		TerminalName: "IMPORT",
+		LexemeValue:  stringPtr("import"),
+	})
+
+	specs.Put(automata.NewStates(32, 33, 34, 35, 36, 37, 39, 134, 135, 136, 137, 138), tokenSpec{
 		TerminalName: "IDENT",
 	})
 
@@ -335,6 +498,12 @@ func BuildTokenSpecs() tokenSpecs {
 		TrimLexeme:   true,
 	})
 
+	specs.Put(automata.NewStates(105), tokenSpec{
+		TerminalName: "ISTRING",
+		TrimLexeme:   true,
+		Suffix:       "i",
+	})
+
 	specs.Put(automata.NewStates(65), tokenSpec{
 		TerminalName: "REGEX",
 		TrimLexeme:   true,
@@ -342,12 +511,25 @@ func BuildTokenSpecs() tokenSpecs {
 
 	specs.Put(automata.NewStates(66), tokenSpec{
 		TerminalName: "COMMENT",
-		LexemeValue:  stringPtr(""),
 	})
 
 	specs.Put(automata.NewStates(69), tokenSpec{
 		TerminalName: "COMMENT",
-		LexemeValue:  stringPtr(""),
+	})
+
+	specs.Put(automata.NewStates(122), tokenSpec{
+		TerminalName: "LABEL",
+	})
+
+	specs.Put(automata.NewStates(123), tokenSpec{
+		TerminalName: "FIELD",
+	})
+
+	specs.Put(automata.NewStates(133), tokenSpec{
+		TerminalName: "CODE",
+		TrimLexeme:   true,
+		Prefix:       "%",
+		Suffix:       "%",
 	})
 
 	return specs
@@ -360,6 +542,8 @@ type (
 		TerminalName string
 		LexemeValue  *string
 		TrimLexeme   bool
+		Prefix       string
+		Suffix       string
 	}
 )
 
@@ -379,6 +563,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/lexer"
@@ -391,32 +576,53 @@ const (
 )
 
 const (
-	ERR     = grammar.Terminal("ERR")     // ERR is the error token.
-	WS      = grammar.Terminal("WS")      // WS is the token for whitespace characters.
-	EOL     = grammar.Terminal("EOL")     // WS is the token for newline characters.
-	DEF     = grammar.Terminal("=")       // DEF is the token for "=".
-	SEMI    = grammar.Terminal(";")       // SEMI is the token for ";".
-	ALT     = grammar.Terminal("|")       // ALT is the token for "|".
-	LPAREN  = grammar.Terminal("(")       // LPAREN is the token for "(".
-	RPAREN  = grammar.Terminal(")")       // RPAREN is the token for ")".
-	LBRACK  = grammar.Terminal("[")       // LBRACK is the token for "[".
-	RBRACK  = grammar.Terminal("]")       // RBRACK is the token for "]".
-	LBRACE  = grammar.Terminal("{")       // LBRACE is the token for "{".
-	RBRACE  = grammar.Terminal("}")       // RBRACE is the token for "}".
-	LLBRACE = grammar.Terminal("{{")      // LLBRACE is the token for "{{".
-	RRBRACE = grammar.Terminal("}}")      // RRBRACE is the token for "}}".
-	LANGLE  = grammar.Terminal("<")       // LANGLE  is the token for "<".
-	RANGLE  = grammar.Terminal(">")       // RANGLE  is the token for ">".
-	PREDEF  = grammar.Terminal("PREDEF")  // PREDEF is the token for /\$[A-Z][0-9A-Z_]*/.
-	LASSOC  = grammar.Terminal("@left")   // LASSOC  is the token for "@left".
-	RASSOC  = grammar.Terminal("@right")  // RASSOC  is the token for "@right".
-	NOASSOC = grammar.Terminal("@none")   // NOASSOC is the token for "@none".
-	GRAMMER = grammar.Terminal("grammar") // GRAMMER is the token for "grammar".
-	IDENT   = grammar.Terminal("IDENT")   // IDENT is the token for /[a-z][0-9a-z_]*/.
-	TOKEN   = grammar.Terminal("TOKEN")   // TOKEN is the token for /[A-Z][0-9A-Z_]*/.
-	STRING  = grammar.Terminal("STRING")  // STRING is the token for /"([^\\"]\|\\[\\"'tnr]\|\\x[0-9A-Fa-f]{2}\|\\u[0-9A-Fa-f]{4}\|\\U[0-9A-Fa-f]{8})*"/.
-	REGEX   = grammar.Terminal("REGEX")   // REGEX is the token for /\/([^\/\\*]\|\\.)([^\/\\]\|\\.)*\//.
-	COMMENT = grammar.Terminal("COMMENT") // COMMENT is the token for single-line and multi-line comments.
+	ERR        = grammar.Terminal("ERR")         // ERR is the error token.
+	WS         = grammar.Terminal("WS")          // WS is the token for whitespace characters.
+	EOL        = grammar.Terminal("EOL")         // WS is the token for newline characters.
+	DEF        = grammar.Terminal("=")           // DEF is the token for "=".
+	SEMI       = grammar.Terminal(";")           // SEMI is the token for ";".
+	ALT        = grammar.Terminal("|")           // ALT is the token for "|".
+	LPAREN     = grammar.Terminal("(")           // LPAREN is the token for "(".
+	RPAREN     = grammar.Terminal(")")           // RPAREN is the token for ")".
+	LBRACK     = grammar.Terminal("[")           // LBRACK is the token for "[".
+	RBRACK     = grammar.Terminal("]")           // RBRACK is the token for "]".
+	LBRACE     = grammar.Terminal("{")           // LBRACE is the token for "{".
+	RBRACE     = grammar.Terminal("}")           // RBRACE is the token for "}".
+	LLBRACE    = grammar.Terminal("{{")          // LLBRACE is the token for "{{".
+	RRBRACE    = grammar.Terminal("}}")          // RRBRACE is the token for "}}".
+	LANGLE     = grammar.Terminal("<")           // LANGLE  is the token for "<".
+	RANGLE     = grammar.Terminal(">")           // RANGLE  is the token for ">".
+	SEP        = grammar.Terminal("%")           // SEP is the token for "%".
+	TSEP       = grammar.Terminal("%%")          // TSEP is the token for "%%".
+	COMMA      = grammar.Terminal(",")           // COMMA is the token for ",".
+	PREDEF     = grammar.Terminal("PREDEF")      // PREDEF is the token for /\$[A-Z][0-9A-Z_]*/.
+	LASSOC     = grammar.Terminal("@left")       // LASSOC  is the token for "@left".
+	RASSOC     = grammar.Terminal("@right")      // RASSOC  is the token for "@right".
+	NOASSOC    = grammar.Terminal("@none")       // NOASSOC is the token for "@none".
+	MODE       = grammar.Terminal("@mode")       // MODE is the token for "@mode".
+	POP        = grammar.Terminal("@pop")        // POP is the token for "@pop".
+	PUSH       = grammar.Terminal("@push")       // PUSH is the token for "@push".
+	SWITCH     = grammar.Terminal("@switch")     // SWITCH is the token for "@switch".
+	SKIP       = grammar.Terminal("@skip")       // SKIP is the token for "@skip".
+	WHITESPACE = grammar.Terminal("@whitespace") // WHITESPACE is the token for "@whitespace".
+	INDENT     = grammar.Terminal("@indent")     // INDENT is the token for "@indent".
+	PRIORITY   = grammar.Terminal("@priority")   // PRIORITY is the token for "@priority".
+	FRAGMENT   = grammar.Terminal("@fragment")   // FRAGMENT is the token for "@fragment".
+	START      = grammar.Terminal("@start")      // START is the token for "@start".
+	OVERRIDE   = grammar.Terminal("@override")   // OVERRIDE is the token for "@override".
+	REMOVE     = grammar.Terminal("@remove")     // REMOVE is the token for "@remove".
+	EXTERNAL   = grammar.Terminal("@external")   // EXTERNAL is the token for "@external".
+	GRAMMER    = grammar.Terminal("grammar")     // GRAMMER is the token for "grammar".
+	IMPORT     = grammar.Terminal("import")      // IMPORT is the token for "import".
+	IDENT      = grammar.Terminal("IDENT")       // IDENT is the token for /[a-z][0-9a-z_]*/.
+	TOKEN      = grammar.Terminal("TOKEN")       // TOKEN is the token for /[A-Z][0-9A-Z_]*/.
+	STRING     = grammar.Terminal("STRING")      // STRING is the token for /"([^\\"]\|\\[\\"'tnr]\|\\x[0-9A-Fa-f]{2}\|\\u[0-9A-Fa-f]{4}\|\\U[0-9A-Fa-f]{8})*"/.
+	ISTRING    = grammar.Terminal("ISTRING")     // ISTRING is the token for case-insensitive strings, a STRING followed by i.
+	REGEX      = grammar.Terminal("REGEX")       // REGEX is the token for /\/([^\/\\*]\|\\.)([^\/\\]\|\\.)*\//.
+	LABEL      = grammar.Terminal("LABEL")       // LABEL is the token for /#[A-Z][0-9A-Za-z_]*/.
+	FIELD      = grammar.Terminal("FIELD")       // FIELD is the token for /[a-z][0-9a-z_]*:/.
+	CODE       = grammar.Terminal("CODE")        // CODE is the token for blocks of Go code enclosed in {% and %}.
+	COMMENT    = grammar.Terminal("COMMENT")     // COMMENT is the token for single-line and multi-line comments.
 )
 
 // inputBuffer is an interface for the input.Input struct.
@@ -430,7 +636,9 @@ type inputBuffer interface {
 // Lexer is a lexical analyzer for the EBNF language.
 // EBNF (Extended Backus-Naur Form) is used to define context-free grammars and their corresponding languages.
 type Lexer struct {
-	in inputBuffer
+	in       inputBuffer
+	line     int
+	comments []lexer.Token
 }
 
 // New creates a new lexical analyzer for the EBNF language.
@@ -469,16 +677,30 @@ func (l *Lexer) NextToken() (lexer.Token, error) {
 			switch token.Terminal {
 			case ERR:
 				return lexer.Token{}, errors.New(token.Lexeme)
-			case WS, EOL, COMMENT:
-				// Skip whitespaces, newlines, and comments.
+			case WS, EOL:
+				// Skip whitespaces and newlines.
+				return l.NextToken()
+			case COMMENT:
+				// Keep the comments on lines of their own, so they can be attached to the declarations they document.
+				if token.Pos.Line > l.line {
+					l.comments = append(l.comments, token)
+				}
 				return l.NextToken()
 			default:
+				// Keep track of the line on which the last token ends.
+				l.line = token.Pos.Line + strings.Count(token.Lexeme, "\n")
 				return token, nil
 			}
 		}
 	}
 }
 
+// Comments returns the comments skipped so far in the order they appear in the input stream.
+// A comment following a token on the same line is not kept, since it does not document the declaration after it.
+func (l *Lexer) Comments() []lexer.Token {
+	return l.comments
+}
+
 `)
 
 	b.Write(generateEvalDFA(specs))
@@ -517,7 +739,7 @@ func generateEvalDFA(specs tokenSpecs) []byte {
 			fmt.Fprintf(&b, "		return lexer.Token{Terminal: %s, Lexeme: lexeme, Pos: pos}\n", spec.TerminalName)
 		} else {
 			fmt.Fprintf(&b, "		lexeme, pos := l.in.Lexeme()\n")
-			fmt.Fprintf(&b, "		lexeme = lexeme[1 : len(lexeme)-1]\n")
+			fmt.Fprintf(&b, "		lexeme = lexeme[%d : len(lexeme)-%d]\n", 1+len(spec.Prefix), 1+len(spec.Suffix))
 			fmt.Fprintf(&b, "		return lexer.Token{Terminal: %s, Lexeme: lexeme, Pos: pos}\n", spec.TerminalName)
 		}
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/lexer"
@@ -17,32 +18,53 @@ const (
 )
 
 const (
-	ERR     = grammar.Terminal("ERR")     // ERR is the error token.
-	WS      = grammar.Terminal("WS")      // WS is the token for whitespace characters.
-	EOL     = grammar.Terminal("EOL")     // WS is the token for newline characters.
-	DEF     = grammar.Terminal("=")       // DEF is the token for "=".
-	SEMI    = grammar.Terminal(";")       // SEMI is the token for ";".
-	ALT     = grammar.Terminal("|")       // ALT is the token for "|".
-	LPAREN  = grammar.Terminal("(")       // LPAREN is the token for "(".
-	RPAREN  = grammar.Terminal(")")       // RPAREN is the token for ")".
-	LBRACK  = grammar.Terminal("[")       // LBRACK is the token for "[".
-	RBRACK  = grammar.Terminal("]")       // RBRACK is the token for "]".
-	LBRACE  = grammar.Terminal("{")       // LBRACE is the token for "{".
-	RBRACE  = grammar.Terminal("}")       // RBRACE is the token for "}".
-	LLBRACE = grammar.Terminal("{{")      // LLBRACE is the token for "{{".
-	RRBRACE = grammar.Terminal("}}")      // RRBRACE is the token for "}}".
-	LANGLE  = grammar.Terminal("<")       // LANGLE  is the token for "<".
-	RANGLE  = grammar.Terminal(">")       // RANGLE  is the token for ">".
-	PREDEF  = grammar.Terminal("PREDEF")  // PREDEF is the token for /\$[A-Z][0-9A-Z_]*/.
-	LASSOC  = grammar.Terminal("@left")   // LASSOC  is the token for "@left".
-	RASSOC  = grammar.Terminal("@right")  // RASSOC  is the token for "@right".
-	NOASSOC = grammar.Terminal("@none")   // NOASSOC is the token for "@none".
-	GRAMMER = grammar.Terminal("grammar") // GRAMMER is the token for "grammar".
-	IDENT   = grammar.Terminal("IDENT")   // IDENT is the token for /[a-z][0-9a-z_]*/.
-	TOKEN   = grammar.Terminal("TOKEN")   // TOKEN is the token for /[A-Z][0-9A-Z_]*/.
-	STRING  = grammar.Terminal("STRING")  // STRING is the token for /"([^\\"]\|\\[\\"'tnr]\|\\x[0-9A-Fa-f]{2}\|\\u[0-9A-Fa-f]{4}\|\\U[0-9A-Fa-f]{8})*"/.
-	REGEX   = grammar.Terminal("REGEX")   // REGEX is the token for /\/([^\/\\*]\|\\.)([^\/\\]\|\\.)*\//.
-	COMMENT = grammar.Terminal("COMMENT") // COMMENT is the token for single-line and multi-line comments.
+	ERR        = grammar.Terminal("ERR")         // ERR is the error token.
+	WS         = grammar.Terminal("WS")          // WS is the token for whitespace characters.
+	EOL        = grammar.Terminal("EOL")         // WS is the token for newline characters.
+	DEF        = grammar.Terminal("=")           // DEF is the token for "=".
+	SEMI       = grammar.Terminal(";")           // SEMI is the token for ";".
+	ALT        = grammar.Terminal("|")           // ALT is the token for "|".
+	LPAREN     = grammar.Terminal("(")           // LPAREN is the token for "(".
+	RPAREN     = grammar.Terminal(")")           // RPAREN is the token for ")".
+	LBRACK     = grammar.Terminal("[")           // LBRACK is the token for "[".
+	RBRACK     = grammar.Terminal("]")           // RBRACK is the token for "]".
+	LBRACE     = grammar.Terminal("{")           // LBRACE is the token for "{".
+	RBRACE     = grammar.Terminal("}")           // RBRACE is the token for "}".
+	LLBRACE    = grammar.Terminal("{{")          // LLBRACE is the token for "{{".
+	RRBRACE    = grammar.Terminal("}}")          // RRBRACE is the token for "}}".
+	LANGLE     = grammar.Terminal("<")           // LANGLE  is the token for "<".
+	RANGLE     = grammar.Terminal(">")           // RANGLE  is the token for ">".
+	SEP        = grammar.Terminal("%")           // SEP is the token for "%".
+	TSEP       = grammar.Terminal("%%")          // TSEP is the token for "%%".
+	COMMA      = grammar.Terminal(",")           // COMMA is the token for ",".
+	PREDEF     = grammar.Terminal("PREDEF")      // PREDEF is the token for /\$[A-Z][0-9A-Z_]*/.
+	LASSOC     = grammar.Terminal("@left")       // LASSOC  is the token for "@left".
+	RASSOC     = grammar.Terminal("@right")      // RASSOC  is the token for "@right".
+	NOASSOC    = grammar.Terminal("@none")       // NOASSOC is the token for "@none".
+	MODE       = grammar.Terminal("@mode")       // MODE is the token for "@mode".
+	POP        = grammar.Terminal("@pop")        // POP is the token for "@pop".
+	PUSH       = grammar.Terminal("@push")       // PUSH is the token for "@push".
+	SWITCH     = grammar.Terminal("@switch")     // SWITCH is the token for "@switch".
+	SKIP       = grammar.Terminal("@skip")       // SKIP is the token for "@skip".
+	WHITESPACE = grammar.Terminal("@whitespace") // WHITESPACE is the token for "@whitespace".
+	INDENT     = grammar.Terminal("@indent")     // INDENT is the token for "@indent".
+	PRIORITY   = grammar.Terminal("@priority")   // PRIORITY is the token for "@priority".
+	FRAGMENT   = grammar.Terminal("@fragment")   // FRAGMENT is the token for "@fragment".
+	START      = grammar.Terminal("@start")      // START is the token for "@start".
+	OVERRIDE   = grammar.Terminal("@override")   // OVERRIDE is the token for "@override".
+	REMOVE     = grammar.Terminal("@remove")     // REMOVE is the token for "@remove".
+	EXTERNAL   = grammar.Terminal("@external")   // EXTERNAL is the token for "@external".
+	GRAMMER    = grammar.Terminal("grammar")     // GRAMMER is the token for "grammar".
+	IMPORT     = grammar.Terminal("import")      // IMPORT is the token for "import".
+	IDENT      = grammar.Terminal("IDENT")       // IDENT is the token for /[a-z][0-9a-z_]*/.
+	TOKEN      = grammar.Terminal("TOKEN")       // TOKEN is the token for /[A-Z][0-9A-Z_]*/.
+	STRING     = grammar.Terminal("STRING")      // STRING is the token for /"([^\\"]\|\\[\\"'tnr]\|\\x[0-9A-Fa-f]{2}\|\\u[0-9A-Fa-f]{4}\|\\U[0-9A-Fa-f]{8})*"/.
+	ISTRING    = grammar.Terminal("ISTRING")     // ISTRING is the token for case-insensitive strings, a STRING followed by i.
+	REGEX      = grammar.Terminal("REGEX")       // REGEX is the token for /\/([^\/\\*]\|\\.)([^\/\\]\|\\.)*\//.
+	LABEL      = grammar.Terminal("LABEL")       // LABEL is the token for /#[A-Z][0-9A-Za-z_]*/.
+	FIELD      = grammar.Terminal("FIELD")       // FIELD is the token for /[a-z][0-9a-z_]*:/.
+	CODE       = grammar.Terminal("CODE")        // CODE is the token for blocks of Go code enclosed in {% and %}.
+	COMMENT    = grammar.Terminal("COMMENT")     // COMMENT is the token for single-line and multi-line comments.
 )
 
 // inputBuffer is an interface for the input.Input struct.
@@ -56,7 +78,9 @@ type inputBuffer interface {
 // Lexer is a lexical analyzer for the EBNF language.
 // EBNF (Extended Backus-Naur Form) is used to define context-free grammars and their corresponding languages.
 type Lexer struct {
-	in inputBuffer
+	in       inputBuffer
+	line     int
+	comments []lexer.Token
 }
 
 // New creates a new lexical analyzer for the EBNF language.
@@ -95,16 +119,30 @@ func (l *Lexer) NextToken() (lexer.Token, error) {
 			switch token.Terminal {
 			case ERR:
 				return lexer.Token{}, errors.New(token.Lexeme)
-			case WS, EOL, COMMENT:
-				// Skip whitespaces, newlines, and comments.
+			case WS, EOL:
+				// Skip whitespaces and newlines.
+				return l.NextToken()
+			case COMMENT:
+				// Keep the comments on lines of their own, so they can be attached to the declarations they document.
+				if token.Pos.Line > l.line {
+					l.comments = append(l.comments, token)
+				}
 				return l.NextToken()
 			default:
+				// Keep track of the line on which the last token ends.
+				l.line = token.Pos.Line + strings.Count(token.Lexeme, "\n")
 				return token, nil
 			}
 		}
 	}
 }
 
+// Comments returns the comments skipped so far in the order they appear in the input stream.
+// A comment following a token on the same line is not kept, since it does not document the declaration after it.
+func (l *Lexer) Comments() []lexer.Token {
+	return l.comments
+}
+
 // evalDFA examines the final state of a deterministic finite automaton (DFA) after it has stopped processing input.
 // Based on the last encountered state, it returns the corresponding token and advances the input buffer reader.
 // If the final state is invalid, it returns an ERR token with the Lexeme set to the error message.
@@ -185,6 +223,21 @@ func (l *Lexer) evalDFA(state int) lexer.Token {
 		pos := l.in.Skip()
 		return lexer.Token{Terminal: RANGLE, Lexeme: ">", Pos: pos}
 
+	// SEP
+	case 128:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: SEP, Lexeme: "%", Pos: pos}
+
+	// TSEP
+	case 129:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: TSEP, Lexeme: "%%", Pos: pos}
+
+	// COMMA
+	case 130:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: COMMA, Lexeme: ",", Pos: pos}
+
 	// PREDEF
 	case 17:
 		lexeme, pos := l.in.Lexeme()
@@ -205,13 +258,83 @@ func (l *Lexer) evalDFA(state int) lexer.Token {
 		pos := l.in.Skip()
 		return lexer.Token{Terminal: NOASSOC, Lexeme: "@none", Pos: pos}
 
+	// MODE
+	case 73:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: MODE, Lexeme: "@mode", Pos: pos}
+
+	// POP
+	case 76:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: POP, Lexeme: "@pop", Pos: pos}
+
+	// PUSH
+	case 79:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: PUSH, Lexeme: "@push", Pos: pos}
+
+	// SWITCH
+	case 85:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: SWITCH, Lexeme: "@switch", Pos: pos}
+
+	// SKIP
+	case 88:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: SKIP, Lexeme: "@skip", Pos: pos}
+
+	// WHITESPACE
+	case 98:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: WHITESPACE, Lexeme: "@whitespace", Pos: pos}
+
+	// INDENT
+	case 104:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: INDENT, Lexeme: "@indent", Pos: pos}
+
+	// PRIORITY
+	case 112:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: PRIORITY, Lexeme: "@priority", Pos: pos}
+
+	// FRAGMENT
+	case 120:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: FRAGMENT, Lexeme: "@fragment", Pos: pos}
+
+	// START
+	case 127:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: START, Lexeme: "@start", Pos: pos}
+
+	// OVERRIDE
+	case 147:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: OVERRIDE, Lexeme: "@override", Pos: pos}
+
+	// REMOVE
+	case 152:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: REMOVE, Lexeme: "@remove", Pos: pos}
+
+	// EXTERNAL
+	case 160:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: EXTERNAL, Lexeme: "@external", Pos: pos}
+
 	// GRAMMER
 	case 38:
 		pos := l.in.Skip()
 		return lexer.Token{Terminal: GRAMMER, Lexeme: "grammar", Pos: pos}
 
+	// IMPORT
+	case 139:
+		pos := l.in.Skip()
+		return lexer.Token{Terminal: IMPORT, Lexeme: "import", Pos: pos}
+
 	// IDENT
-	case 32, 33, 34, 35, 36, 37, 39:
+	case 32, 33, 34, 35, 36, 37, 39, 134, 135, 136, 137, 138:
 		lexeme, pos := l.in.Lexeme()
 		return lexer.Token{Terminal: IDENT, Lexeme: lexeme, Pos: pos}
 
@@ -234,13 +357,35 @@ func (l *Lexer) evalDFA(state int) lexer.Token {
 
 	// Single-Line COMMENT
 	case 66:
-		pos := l.in.Skip()
-		return lexer.Token{Terminal: COMMENT, Lexeme: "", Pos: pos}
+		lexeme, pos := l.in.Lexeme()
+		return lexer.Token{Terminal: COMMENT, Lexeme: lexeme, Pos: pos}
 
 	// Multi-Line COMMENT
 	case 69:
-		pos := l.in.Skip()
-		return lexer.Token{Terminal: COMMENT, Lexeme: "", Pos: pos}
+		lexeme, pos := l.in.Lexeme()
+		return lexer.Token{Terminal: COMMENT, Lexeme: lexeme, Pos: pos}
+
+	// ISTRING
+	case 105:
+		lexeme, pos := l.in.Lexeme()
+		lexeme = lexeme[1 : len(lexeme)-2]
+		return lexer.Token{Terminal: ISTRING, Lexeme: lexeme, Pos: pos}
+
+	// LABEL
+	case 122:
+		lexeme, pos := l.in.Lexeme()
+		return lexer.Token{Terminal: LABEL, Lexeme: lexeme, Pos: pos}
+
+	// FIELD
+	case 123:
+		lexeme, pos := l.in.Lexeme()
+		return lexer.Token{Terminal: FIELD, Lexeme: lexeme, Pos: pos}
+
+	// CODE
+	case 133:
+		lexeme, pos := l.in.Lexeme()
+		lexeme = lexeme[2 : len(lexeme)-2]
+		return lexer.Token{Terminal: CODE, Lexeme: lexeme, Pos: pos}
 	}
 
 	// ERR
@@ -291,8 +436,11 @@ func advanceDFA(state int, r rune) int {
 			return 18
 		case r == 'g':
 			return 32
+		case r == 'i':
+			return 134
 		case 'a' <= r && r <= 'f',
-			'h' <= r && r <= 'z':
+			r == 'h',
+			'j' <= r && r <= 'z':
 			return 39
 		case 'A' <= r && r <= 'Z':
 			return 40
@@ -300,6 +448,12 @@ func advanceDFA(state int, r rune) int {
 			return 41
 		case r == '/':
 			return 62
+		case r == '#':
+			return 121
+		case r == '%':
+			return 128
+		case r == ',':
+			return 130
 		}
 
 	case 1:
@@ -316,6 +470,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 10:
 		switch r {
+		case '%':
+			return 131
 		case '{':
 			return 12
 		}
@@ -342,12 +498,28 @@ func advanceDFA(state int, r rune) int {
 
 	case 18:
 		switch r {
+		case 'e':
+			return 153
+		case 'f':
+			return 113
+		case 'i':
+			return 99
 		case 'l':
 			return 19
+		case 'm':
+			return 70
 		case 'n':
 			return 28
+		case 'o':
+			return 140
+		case 'p':
+			return 74
 		case 'r':
 			return 23
+		case 's':
+			return 80
+		case 'w':
+			return 89
 		}
 
 	case 19:
@@ -370,6 +542,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 23:
 		switch r {
+		case 'e':
+			return 148
 		case 'i':
 			return 24
 		}
@@ -412,6 +586,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 32:
 		switch {
+		case r == ':':
+			return 123
 		case r == 'r':
 			return 33
 		case '0' <= r && r <= '9',
@@ -423,6 +599,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 33:
 		switch {
+		case r == ':':
+			return 123
 		case r == 'a':
 			return 34
 		case '0' <= r && r <= '9',
@@ -433,6 +611,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 34:
 		switch {
+		case r == ':':
+			return 123
 		case r == 'm':
 			return 35
 		case '0' <= r && r <= '9',
@@ -444,6 +624,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 35:
 		switch {
+		case r == ':':
+			return 123
 		case r == 'm':
 			return 36
 		case '0' <= r && r <= '9',
@@ -455,6 +637,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 36:
 		switch {
+		case r == ':':
+			return 123
 		case r == 'a':
 			return 37
 		case '0' <= r && r <= '9',
@@ -465,6 +649,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 37:
 		switch {
+		case r == ':':
+			return 123
 		case r == 'r':
 			return 38
 		case '0' <= r && r <= '9',
@@ -476,6 +662,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 38:
 		switch {
+		case r == ':':
+			return 123
 		case '0' <= r && r <= '9':
 			return 39
 		case r == '_':
@@ -486,6 +674,8 @@ func advanceDFA(state int, r rune) int {
 
 	case 39:
 		switch {
+		case r == ':':
+			return 123
 		case '0' <= r && r <= '9',
 			r == '_',
 			'a' <= r && r <= 'z':
@@ -684,6 +874,12 @@ func advanceDFA(state int, r rune) int {
 			return 41
 		}
 
+	case 61:
+		switch r {
+		case 'i':
+			return 105
+		}
+
 	case 62:
 		switch {
 		case r == '*':
@@ -744,6 +940,503 @@ func advanceDFA(state int, r rune) int {
 			0x30 <= r && r <= 0x10FFFF:
 			return 67
 		}
+
+	case 70:
+		switch r {
+		case 'o':
+			return 71
+		}
+
+	case 71:
+		switch r {
+		case 'd':
+			return 72
+		}
+
+	case 72:
+		switch r {
+		case 'e':
+			return 73
+		}
+
+	case 74:
+		switch r {
+		case 'o':
+			return 75
+		case 'r':
+			return 106
+		case 'u':
+			return 77
+		}
+
+	case 75:
+		switch r {
+		case 'p':
+			return 76
+		}
+
+	case 77:
+		switch r {
+		case 's':
+			return 78
+		}
+
+	case 78:
+		switch r {
+		case 'h':
+			return 79
+		}
+
+	case 80:
+		switch r {
+		case 'k':
+			return 86
+		case 't':
+			return 124
+		case 'w':
+			return 81
+		}
+
+	case 81:
+		switch r {
+		case 'i':
+			return 82
+		}
+
+	case 82:
+		switch r {
+		case 't':
+			return 83
+		}
+
+	case 83:
+		switch r {
+		case 'c':
+			return 84
+		}
+
+	case 84:
+		switch r {
+		case 'h':
+			return 85
+		}
+
+	case 86:
+		switch r {
+		case 'i':
+			return 87
+		}
+
+	case 87:
+		switch r {
+		case 'p':
+			return 88
+		}
+
+	case 89:
+		switch r {
+		case 'h':
+			return 90
+		}
+
+	case 90:
+		switch r {
+		case 'i':
+			return 91
+		}
+
+	case 91:
+		switch r {
+		case 't':
+			return 92
+		}
+
+	case 92:
+		switch r {
+		case 'e':
+			return 93
+		}
+
+	case 93:
+		switch r {
+		case 's':
+			return 94
+		}
+
+	case 94:
+		switch r {
+		case 'p':
+			return 95
+		}
+
+	case 95:
+		switch r {
+		case 'a':
+			return 96
+		}
+
+	case 96:
+		switch r {
+		case 'c':
+			return 97
+		}
+
+	case 97:
+		switch r {
+		case 'e':
+			return 98
+		}
+
+	case 99:
+		switch r {
+		case 'n':
+			return 100
+		}
+
+	case 100:
+		switch r {
+		case 'd':
+			return 101
+		}
+
+	case 101:
+		switch r {
+		case 'e':
+			return 102
+		}
+
+	case 102:
+		switch r {
+		case 'n':
+			return 103
+		}
+
+	case 103:
+		switch r {
+		case 't':
+			return 104
+		}
+
+	case 106:
+		switch r {
+		case 'i':
+			return 107
+		}
+
+	case 107:
+		switch r {
+		case 'o':
+			return 108
+		}
+
+	case 108:
+		switch r {
+		case 'r':
+			return 109
+		}
+
+	case 109:
+		switch r {
+		case 'i':
+			return 110
+		}
+
+	case 110:
+		switch r {
+		case 't':
+			return 111
+		}
+
+	case 111:
+		switch r {
+		case 'y':
+			return 112
+		}
+
+	case 113:
+		switch r {
+		case 'r':
+			return 114
+		}
+
+	case 114:
+		switch r {
+		case 'a':
+			return 115
+		}
+
+	case 115:
+		switch r {
+		case 'g':
+			return 116
+		}
+
+	case 116:
+		switch r {
+		case 'm':
+			return 117
+		}
+
+	case 117:
+		switch r {
+		case 'e':
+			return 118
+		}
+
+	case 118:
+		switch r {
+		case 'n':
+			return 119
+		}
+
+	case 119:
+		switch r {
+		case 't':
+			return 120
+		}
+
+	case 121:
+		switch {
+		case 'A' <= r && r <= 'Z':
+			return 122
+		}
+
+	case 122:
+		switch {
+		case '0' <= r && r <= '9',
+			'A' <= r && r <= 'Z',
+			r == '_',
+			'a' <= r && r <= 'z':
+			return 122
+		}
+
+	case 124:
+		switch r {
+		case 'a':
+			return 125
+		}
+
+	case 125:
+		switch r {
+		case 'r':
+			return 126
+		}
+
+	case 126:
+		switch r {
+		case 't':
+			return 127
+		}
+
+	case 128:
+		switch r {
+		case '%':
+			return 129
+		}
+
+	case 131:
+		switch {
+		case r == '%':
+			return 132
+		case 0x00 <= r && r <= 0x24,
+			0x26 <= r && r <= 0x10FFFF:
+			return 131
+		}
+
+	case 132:
+		switch {
+		case r == '%':
+			return 132
+		case r == '}':
+			return 133
+		case 0x00 <= r && r <= 0x24,
+			0x26 <= r && r <= 0x7C,
+			0x7E <= r && r <= 0x10FFFF:
+			return 131
+		}
+
+	case 134:
+		switch {
+		case r == ':':
+			return 123
+		case r == 'm':
+			return 135
+		case '0' <= r && r <= '9',
+			r == '_',
+			'a' <= r && r <= 'l',
+			'n' <= r && r <= 'z':
+			return 39
+		}
+
+	case 135:
+		switch {
+		case r == ':':
+			return 123
+		case r == 'p':
+			return 136
+		case '0' <= r && r <= '9',
+			r == '_',
+			'a' <= r && r <= 'o',
+			'q' <= r && r <= 'z':
+			return 39
+		}
+
+	case 136:
+		switch {
+		case r == ':':
+			return 123
+		case r == 'o':
+			return 137
+		case '0' <= r && r <= '9',
+			r == '_',
+			'a' <= r && r <= 'n',
+			'p' <= r && r <= 'z':
+			return 39
+		}
+
+	case 137:
+		switch {
+		case r == ':':
+			return 123
+		case r == 'r':
+			return 138
+		case '0' <= r && r <= '9',
+			r == '_',
+			'a' <= r && r <= 'q',
+			's' <= r && r <= 'z':
+			return 39
+		}
+
+	case 138:
+		switch {
+		case r == ':':
+			return 123
+		case r == 't':
+			return 139
+		case '0' <= r && r <= '9',
+			r == '_',
+			'a' <= r && r <= 's',
+			'u' <= r && r <= 'z':
+			return 39
+		}
+
+	case 139:
+		switch {
+		case r == ':':
+			return 123
+		case '0' <= r && r <= '9',
+			r == '_',
+			'a' <= r && r <= 'z':
+			return 39
+		}
+
+	case 140:
+		switch r {
+		case 'v':
+			return 141
+		}
+
+	case 141:
+		switch r {
+		case 'e':
+			return 142
+		}
+
+	case 142:
+		switch r {
+		case 'r':
+			return 143
+		}
+
+	case 143:
+		switch r {
+		case 'r':
+			return 144
+		}
+
+	case 144:
+		switch r {
+		case 'i':
+			return 145
+		}
+
+	case 145:
+		switch r {
+		case 'd':
+			return 146
+		}
+
+	case 146:
+		switch r {
+		case 'e':
+			return 147
+		}
+
+	case 148:
+		switch r {
+		case 'm':
+			return 149
+		}
+
+	case 149:
+		switch r {
+		case 'o':
+			return 150
+		}
+
+	case 150:
+		switch r {
+		case 'v':
+			return 151
+		}
+
+	case 151:
+		switch r {
+		case 'e':
+			return 152
+		}
+
+	case 153:
+		switch r {
+		case 'x':
+			return 154
+		}
+
+	case 154:
+		switch r {
+		case 't':
+			return 155
+		}
+
+	case 155:
+		switch r {
+		case 'e':
+			return 156
+		}
+
+	case 156:
+		switch r {
+		case 'r':
+			return 157
+		}
+
+	case 157:
+		switch r {
+		case 'n':
+			return 158
+		}
+
+	case 158:
+		switch r {
+		case 'a':
+			return 159
+		}
+
+	case 159:
+		switch r {
+		case 'l':
+			return 160
+		}
 	}
 
 	return errorState
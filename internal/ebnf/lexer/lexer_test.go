@@ -226,15 +226,18 @@ func TestLexer_NextToken(t *testing.T) {
 						{OutRune: 'r'},
 						{OutRune: ' '},
 					},
-					SkipMocks: []SkipMock{
+					LexemeMocks: []LexemeMock{
 						{
+							OutVal: "// Comment",
 							OutPos: lexer.Position{
 								Filename: "test",
-								Offset:   2,
+								Offset:   0,
 								Line:     1,
-								Column:   3,
+								Column:   1,
 							},
 						},
+					},
+					SkipMocks: []SkipMock{
 						{
 							OutPos: lexer.Position{
 								Filename: "test",
@@ -294,15 +297,18 @@ func TestLexer_NextToken(t *testing.T) {
 						{OutRune: 'r'},
 						{OutRune: ' '},
 					},
-					SkipMocks: []SkipMock{
+					LexemeMocks: []LexemeMock{
 						{
+							OutVal: "/*Comment*/",
 							OutPos: lexer.Position{
 								Filename: "test",
-								Offset:   2,
+								Offset:   0,
 								Line:     1,
-								Column:   3,
+								Column:   1,
 							},
 						},
+					},
+					SkipMocks: []SkipMock{
 						{
 							OutPos: lexer.Position{
 								Filename: "test",
@@ -351,6 +357,50 @@ func TestLexer_NextToken(t *testing.T) {
 	}
 }
 
+func TestLexer_Comments(t *testing.T) {
+	tests := []struct {
+		name             string
+		l                *Lexer
+		expectedComments []lexer.Token
+	}{
+		{
+			name: "OK",
+			l: &Lexer{
+				comments: []lexer.Token{
+					{
+						Terminal: COMMENT,
+						Lexeme:   "// Comment",
+						Pos: lexer.Position{
+							Filename: "test",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+				},
+			},
+			expectedComments: []lexer.Token{
+				{
+					Terminal: COMMENT,
+					Lexeme:   "// Comment",
+					Pos: lexer.Position{
+						Filename: "test",
+						Offset:   0,
+						Line:     1,
+						Column:   1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedComments, tc.l.Comments())
+		})
+	}
+}
+
 func TestLexer_evalDFA(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -394,28 +444,505 @@ func TestLexer_evalDFA(t *testing.T) {
 						{
 							OutPos: lexer.Position{
 								Filename: "test",
-								Offset:   2,
+								Offset:   2,
+								Line:     1,
+								Column:   3,
+							},
+						},
+					},
+				},
+			},
+			state: 2,
+			expectedToken: lexer.Token{
+				Terminal: EOL,
+				Lexeme:   "",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   2,
+					Line:     1,
+					Column:   3,
+				},
+			},
+		},
+		{
+			name: "DEF",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 3,
+			expectedToken: lexer.Token{
+				Terminal: DEF,
+				Lexeme:   "=",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "SEMI",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 4,
+			expectedToken: lexer.Token{
+				Terminal: SEMI,
+				Lexeme:   ";",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "ALT",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 5,
+			expectedToken: lexer.Token{
+				Terminal: ALT,
+				Lexeme:   "|",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "LPAREN",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 6,
+			expectedToken: lexer.Token{
+				Terminal: LPAREN,
+				Lexeme:   "(",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "RPAREN",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 7,
+			expectedToken: lexer.Token{
+				Terminal: RPAREN,
+				Lexeme:   ")",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "LBRACK",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 8,
+			expectedToken: lexer.Token{
+				Terminal: LBRACK,
+				Lexeme:   "[",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "RBRACK",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 9,
+			expectedToken: lexer.Token{
+				Terminal: RBRACK,
+				Lexeme:   "]",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "LBRACE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 10,
+			expectedToken: lexer.Token{
+				Terminal: LBRACE,
+				Lexeme:   "{",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "RBRACE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 11,
+			expectedToken: lexer.Token{
+				Terminal: RBRACE,
+				Lexeme:   "}",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "LLBRACE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 12,
+			expectedToken: lexer.Token{
+				Terminal: LLBRACE,
+				Lexeme:   "{{",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "RRBRACE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 13,
+			expectedToken: lexer.Token{
+				Terminal: RRBRACE,
+				Lexeme:   "}}",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "LANGLE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 14,
+			expectedToken: lexer.Token{
+				Terminal: LANGLE,
+				Lexeme:   "<",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "RANGLE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 15,
+			expectedToken: lexer.Token{
+				Terminal: RANGLE,
+				Lexeme:   ">",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "SEP",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 128,
+			expectedToken: lexer.Token{
+				Terminal: SEP,
+				Lexeme:   "%",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "TSEP",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 129,
+			expectedToken: lexer.Token{
+				Terminal: TSEP,
+				Lexeme:   "%%",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "COMMA",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					SkipMocks: []SkipMock{
+						{
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
+								Line:     1,
+								Column:   5,
+							},
+						},
+					},
+				},
+			},
+			state: 130,
+			expectedToken: lexer.Token{
+				Terminal: COMMA,
+				Lexeme:   ",",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   4,
+					Line:     1,
+					Column:   5,
+				},
+			},
+		},
+		{
+			name: "PREDEF",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					LexemeMocks: []LexemeMock{
+						{
+							OutVal: "$STRING",
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   4,
 								Line:     1,
-								Column:   3,
+								Column:   5,
 							},
 						},
 					},
 				},
 			},
-			state: 2,
+			state: 17,
 			expectedToken: lexer.Token{
-				Terminal: EOL,
-				Lexeme:   "",
+				Terminal: PREDEF,
+				Lexeme:   "$STRING",
 				Pos: lexer.Position{
 					Filename: "test",
-					Offset:   2,
+					Offset:   4,
 					Line:     1,
-					Column:   3,
+					Column:   5,
 				},
 			},
 		},
 		{
-			name: "DEF",
+			name: "LASSOC",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -430,10 +957,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 3,
+			state: 22,
 			expectedToken: lexer.Token{
-				Terminal: DEF,
-				Lexeme:   "=",
+				Terminal: LASSOC,
+				Lexeme:   "@left",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -443,7 +970,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "SEMI",
+			name: "RASSOC",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -458,10 +985,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 4,
+			state: 27,
 			expectedToken: lexer.Token{
-				Terminal: SEMI,
-				Lexeme:   ";",
+				Terminal: RASSOC,
+				Lexeme:   "@right",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -471,7 +998,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "ALT",
+			name: "NOASSOC",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -486,10 +1013,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 5,
+			state: 31,
 			expectedToken: lexer.Token{
-				Terminal: ALT,
-				Lexeme:   "|",
+				Terminal: NOASSOC,
+				Lexeme:   "@none",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -499,7 +1026,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "LPAREN",
+			name: "MODE",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -514,10 +1041,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 6,
+			state: 73,
 			expectedToken: lexer.Token{
-				Terminal: LPAREN,
-				Lexeme:   "(",
+				Terminal: MODE,
+				Lexeme:   "@mode",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -527,7 +1054,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "RPAREN",
+			name: "POP",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -542,10 +1069,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 7,
+			state: 76,
 			expectedToken: lexer.Token{
-				Terminal: RPAREN,
-				Lexeme:   ")",
+				Terminal: POP,
+				Lexeme:   "@pop",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -555,7 +1082,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "LBRACK",
+			name: "PUSH",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -570,10 +1097,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 8,
+			state: 79,
 			expectedToken: lexer.Token{
-				Terminal: LBRACK,
-				Lexeme:   "[",
+				Terminal: PUSH,
+				Lexeme:   "@push",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -583,7 +1110,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "RBRACK",
+			name: "SWITCH",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -598,10 +1125,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 9,
+			state: 85,
 			expectedToken: lexer.Token{
-				Terminal: RBRACK,
-				Lexeme:   "]",
+				Terminal: SWITCH,
+				Lexeme:   "@switch",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -611,7 +1138,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "LBRACE",
+			name: "SKIP",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -626,10 +1153,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 10,
+			state: 88,
 			expectedToken: lexer.Token{
-				Terminal: LBRACE,
-				Lexeme:   "{",
+				Terminal: SKIP,
+				Lexeme:   "@skip",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -639,7 +1166,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "RBRACE",
+			name: "WHITESPACE",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -654,10 +1181,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 11,
+			state: 98,
 			expectedToken: lexer.Token{
-				Terminal: RBRACE,
-				Lexeme:   "}",
+				Terminal: WHITESPACE,
+				Lexeme:   "@whitespace",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -667,7 +1194,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "LLBRACE",
+			name: "INDENT",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -682,10 +1209,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 12,
+			state: 104,
 			expectedToken: lexer.Token{
-				Terminal: LLBRACE,
-				Lexeme:   "{{",
+				Terminal: INDENT,
+				Lexeme:   "@indent",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -695,7 +1222,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "RRBRACE",
+			name: "PRIORITY",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -710,10 +1237,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 13,
+			state: 112,
 			expectedToken: lexer.Token{
-				Terminal: RRBRACE,
-				Lexeme:   "}}",
+				Terminal: PRIORITY,
+				Lexeme:   "@priority",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -723,7 +1250,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "LANGLE",
+			name: "FRAGMENT",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -738,10 +1265,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 14,
+			state: 120,
 			expectedToken: lexer.Token{
-				Terminal: LANGLE,
-				Lexeme:   "<",
+				Terminal: FRAGMENT,
+				Lexeme:   "@fragment",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -751,7 +1278,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "RANGLE",
+			name: "START",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -766,10 +1293,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 15,
+			state: 127,
 			expectedToken: lexer.Token{
-				Terminal: RANGLE,
-				Lexeme:   ">",
+				Terminal: START,
+				Lexeme:   "@start",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -779,12 +1306,11 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "PREDEF",
+			name: "OVERRIDE",
 			l: &Lexer{
 				in: &mockInputBuffer{
-					LexemeMocks: []LexemeMock{
+					SkipMocks: []SkipMock{
 						{
-							OutVal: "$STRING",
 							OutPos: lexer.Position{
 								Filename: "test",
 								Offset:   4,
@@ -795,10 +1321,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 17,
+			state: 147,
 			expectedToken: lexer.Token{
-				Terminal: PREDEF,
-				Lexeme:   "$STRING",
+				Terminal: OVERRIDE,
+				Lexeme:   "@override",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -808,7 +1334,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "LASSOC",
+			name: "REMOVE",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -823,10 +1349,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 22,
+			state: 152,
 			expectedToken: lexer.Token{
-				Terminal: LASSOC,
-				Lexeme:   "@left",
+				Terminal: REMOVE,
+				Lexeme:   "@remove",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -836,7 +1362,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "RASSOC",
+			name: "EXTERNAL",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -851,10 +1377,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 27,
+			state: 160,
 			expectedToken: lexer.Token{
-				Terminal: RASSOC,
-				Lexeme:   "@right",
+				Terminal: EXTERNAL,
+				Lexeme:   "@external",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -864,7 +1390,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "NOASSOC",
+			name: "GRAMMER",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -879,10 +1405,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 31,
+			state: 38,
 			expectedToken: lexer.Token{
-				Terminal: NOASSOC,
-				Lexeme:   "@none",
+				Terminal: GRAMMER,
+				Lexeme:   "grammar",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -892,7 +1418,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			},
 		},
 		{
-			name: "GRAMMER",
+			name: "IMPORT",
 			l: &Lexer{
 				in: &mockInputBuffer{
 					SkipMocks: []SkipMock{
@@ -907,10 +1433,10 @@ func TestLexer_evalDFA(t *testing.T) {
 					},
 				},
 			},
-			state: 38,
+			state: 139,
 			expectedToken: lexer.Token{
-				Terminal: GRAMMER,
-				Lexeme:   "grammar",
+				Terminal: IMPORT,
+				Lexeme:   "import",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   4,
@@ -1180,6 +1706,122 @@ func TestLexer_evalDFA(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ISTRING",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					LexemeMocks: []LexemeMock{
+						{
+							OutVal: `"foo"i`,
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   16,
+								Line:     1,
+								Column:   17,
+							},
+						},
+					},
+				},
+			},
+			state: 105,
+			expectedToken: lexer.Token{
+				Terminal: ISTRING,
+				Lexeme:   "foo",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   16,
+					Line:     1,
+					Column:   17,
+				},
+			},
+		},
+		{
+			name: "LABEL",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					LexemeMocks: []LexemeMock{
+						{
+							OutVal: "#Add",
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   24,
+								Line:     1,
+								Column:   25,
+							},
+						},
+					},
+				},
+			},
+			state: 122,
+			expectedToken: lexer.Token{
+				Terminal: LABEL,
+				Lexeme:   "#Add",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   24,
+					Line:     1,
+					Column:   25,
+				},
+			},
+		},
+		{
+			name: "FIELD",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					LexemeMocks: []LexemeMock{
+						{
+							OutVal: "lhs:",
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   8,
+								Line:     1,
+								Column:   9,
+							},
+						},
+					},
+				},
+			},
+			state: 123,
+			expectedToken: lexer.Token{
+				Terminal: FIELD,
+				Lexeme:   "lhs:",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   8,
+					Line:     1,
+					Column:   9,
+				},
+			},
+		},
+		{
+			name: "CODE",
+			l: &Lexer{
+				in: &mockInputBuffer{
+					LexemeMocks: []LexemeMock{
+						{
+							OutVal: "{% $$ = $1 %}",
+							OutPos: lexer.Position{
+								Filename: "test",
+								Offset:   12,
+								Line:     1,
+								Column:   13,
+							},
+						},
+					},
+				},
+			},
+			state: 133,
+			expectedToken: lexer.Token{
+				Terminal: CODE,
+				Lexeme:   " $$ = $1 ",
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   12,
+					Line:     1,
+					Column:   13,
+				},
+			},
+		},
 		{
 			name: "REGEX",
 			l: &Lexer{
@@ -1213,8 +1855,9 @@ func TestLexer_evalDFA(t *testing.T) {
 			name: "COMMENT_SingleLine",
 			l: &Lexer{
 				in: &mockInputBuffer{
-					SkipMocks: []SkipMock{
+					LexemeMocks: []LexemeMock{
 						{
+							OutVal: "// Comment",
 							OutPos: lexer.Position{
 								Filename: "test",
 								Offset:   32,
@@ -1228,7 +1871,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			state: 66,
 			expectedToken: lexer.Token{
 				Terminal: COMMENT,
-				Lexeme:   "",
+				Lexeme:   "// Comment",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   32,
@@ -1241,8 +1884,9 @@ func TestLexer_evalDFA(t *testing.T) {
 			name: "COMMENT_MultiLine",
 			l: &Lexer{
 				in: &mockInputBuffer{
-					SkipMocks: []SkipMock{
+					LexemeMocks: []LexemeMock{
 						{
+							OutVal: "/* Comment */",
 							OutPos: lexer.Position{
 								Filename: "test",
 								Offset:   32,
@@ -1256,7 +1900,7 @@ func TestLexer_evalDFA(t *testing.T) {
 			state: 69,
 			expectedToken: lexer.Token{
 				Terminal: COMMENT,
-				Lexeme:   "",
+				Lexeme:   "/* Comment */",
 				Pos: lexer.Position{
 					Filename: "test",
 					Offset:   32,
@@ -1335,6 +1979,9 @@ func TestAdvanceDFA(t *testing.T) {
 		{11, '}', 13},
 		{0, '<', 14},
 		{0, '>', 15},
+		{0, '%', 128},
+		{128, '%', 129},
+		{0, ',', 130},
 
 		// $
 		{0, '$', 16},
@@ -1369,6 +2016,119 @@ func TestAdvanceDFA(t *testing.T) {
 		{29, 'n', 30},
 		{30, 'e', 31},
 
+		// @mode
+		{18, 'm', 70},
+		{70, 'o', 71},
+		{71, 'd', 72},
+		{72, 'e', 73},
+
+		// @pop
+		{18, 'p', 74},
+		{74, 'o', 75},
+		{75, 'p', 76},
+
+		// @push
+		{74, 'u', 77},
+		{77, 's', 78},
+		{78, 'h', 79},
+
+		// @switch
+		{18, 's', 80},
+		{80, 'w', 81},
+		{81, 'i', 82},
+		{82, 't', 83},
+		{83, 'c', 84},
+		{84, 'h', 85},
+
+		// @skip
+		{80, 'k', 86},
+		{86, 'i', 87},
+		{87, 'p', 88},
+
+		// @whitespace
+		{18, 'w', 89},
+		{89, 'h', 90},
+		{90, 'i', 91},
+		{91, 't', 92},
+		{92, 'e', 93},
+		{93, 's', 94},
+		{94, 'p', 95},
+		{95, 'a', 96},
+		{96, 'c', 97},
+		{97, 'e', 98},
+
+		// @indent
+		{18, 'i', 99},
+		{99, 'n', 100},
+		{100, 'd', 101},
+		{101, 'e', 102},
+		{102, 'n', 103},
+		{103, 't', 104},
+
+		// @priority
+		{74, 'r', 106},
+		{106, 'i', 107},
+		{107, 'o', 108},
+		{108, 'r', 109},
+		{109, 'i', 110},
+		{110, 't', 111},
+		{111, 'y', 112},
+
+		// @fragment
+		{18, 'f', 113},
+		{113, 'r', 114},
+		{114, 'a', 115},
+		{115, 'g', 116},
+		{116, 'm', 117},
+		{117, 'e', 118},
+		{118, 'n', 119},
+		{119, 't', 120},
+
+		// @start
+		{80, 't', 124},
+		{124, 'a', 125},
+		{125, 'r', 126},
+		{126, 't', 127},
+
+		// @override
+		{18, 'o', 140},
+		{140, 'v', 141},
+		{141, 'e', 142},
+		{142, 'r', 143},
+		{143, 'r', 144},
+		{144, 'i', 145},
+		{145, 'd', 146},
+		{146, 'e', 147},
+
+		// @remove
+		{23, 'e', 148},
+		{148, 'm', 149},
+		{149, 'o', 150},
+		{150, 'v', 151},
+		{151, 'e', 152},
+
+		// @external
+		{18, 'e', 153},
+		{153, 'x', 154},
+		{154, 't', 155},
+		{155, 'e', 156},
+		{156, 'r', 157},
+		{157, 'n', 158},
+		{158, 'a', 159},
+		{159, 'l', 160},
+
+		// "..."i
+		{61, 'i', 105},
+
+		// #Add_2
+		{0, '#', 121},
+		{121, 'A', 122},
+		{122, 'd', 122},
+		{122, 'd', 122},
+		{122, '_', 122},
+		{122, '2', 122},
+		{121, 'a', -1},
+
 		// grammar
 		{0, 'g', 32},
 		{32, 'r', 33},
@@ -1387,12 +2147,50 @@ func TestAdvanceDFA(t *testing.T) {
 		{38, 'i', 39}, // grammari
 		{39, 'n', 39}, // grammarian
 
+		// import
+		{0, 'i', 134},
+		{134, 'm', 135},
+		{135, 'p', 136},
+		{136, 'o', 137},
+		{137, 'r', 138},
+		{138, 't', 139},
+
+		{134, 'd', 39},  // id
+		{135, 'a', 39},  // ima
+		{136, 'l', 39},  // impl
+		{137, 's', 39},  // impos
+		{138, 'u', 39},  // imporu
+		{139, 'e', 39},  // importe
+		{139, '_', 39},  // import_
+		{134, ':', 123}, // i:
+		{139, ':', 123}, // import:
+
 		// name
 		{0, 'n', 39},
 		{39, 'a', 39},
 		{39, 'm', 39},
 		{39, 'e', 39},
 
+		// name:
+		{39, ':', 123},
+		{32, ':', 123}, // g:
+		{38, ':', 123}, // grammar:
+		{123, ':', -1},
+
+		// {% $$ %%}
+		{10, '%', 131},
+		{131, ' ', 131},
+		{131, '$', 131},
+		{131, '$', 131},
+		{131, ' ', 131},
+		{131, '%', 132},
+		{132, '%', 132},
+		{132, '}', 133},
+		{133, '}', -1},
+
+		// {% % %}
+		{132, ' ', 131},
+
 		// NAME
 		{0, 'N', 40},
 		{40, 'A', 40},
@@ -1532,6 +2330,74 @@ func TestLexer(t *testing.T) {
 			name:     "Please",
 			filename: "../fixture/please.grammar",
 		},
+		{
+			name:     "Modes",
+			filename: "../fixture/test.modes.grammar",
+		},
+		{
+			name:     "Skip",
+			filename: "../fixture/test.skip.grammar",
+		},
+		{
+			name:     "Whitespace",
+			filename: "../fixture/test.whitespace.grammar",
+		},
+		{
+			name:     "Indent",
+			filename: "../fixture/test.indent.grammar",
+		},
+		{
+			name:     "IgnoreCase",
+			filename: "../fixture/test.case.grammar",
+		},
+		{
+			name:     "Priority",
+			filename: "../fixture/test.priority.grammar",
+		},
+		{
+			name:     "Fragment",
+			filename: "../fixture/test.fragment.grammar",
+		},
+		{
+			name:     "Labels",
+			filename: "../fixture/test.labels.grammar",
+		},
+		{
+			name:     "Fields",
+			filename: "../fixture/test.fields.grammar",
+		},
+		{
+			name:     "Starts",
+			filename: "../fixture/test.starts.grammar",
+		},
+		{
+			name:     "Lists",
+			filename: "../fixture/test.lists.grammar",
+		},
+		{
+			name:     "Macros",
+			filename: "../fixture/test.macros.grammar",
+		},
+		{
+			name:     "Actions",
+			filename: "../fixture/test.actions.grammar",
+		},
+		{
+			name:     "Imports",
+			filename: "../fixture/test.imports.grammar",
+		},
+		{
+			name:     "Dialect",
+			filename: "../fixture/test.dialect.grammar",
+		},
+		{
+			name:     "External",
+			filename: "../fixture/test.external.grammar",
+		},
+		{
+			name:     "Docs",
+			filename: "../fixture/test.docs.grammar",
+		},
 	}
 
 	for _, tc := range tests {
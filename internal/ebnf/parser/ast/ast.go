@@ -4,6 +4,8 @@ package ast
 import (
 	"bytes"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/moorara/algo/dot"
 	"github.com/moorara/algo/generic"
@@ -168,6 +170,15 @@ func (n *Grammar) DOT() string {
 			label := fmt.Sprintf("TokenDecl::%s", n.Name)
 			graph.AddNode(dot.NewNode(name, "", label, dot.ColorSkyBlue, dot.StyleFilled, dot.ShapeBox, "", ""))
 
+		case *ModeDecl:
+			label := fmt.Sprintf("ModeDecl::%s", n.Name)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorSkyBlue, dot.StyleFilled, dot.ShapeSquare, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
 		case *PrecedenceDecl:
 			label := fmt.Sprintf("PrecedenceDecl::%s", n.Associativity)
 			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
@@ -177,6 +188,60 @@ func (n *Grammar) DOT() string {
 				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
 			}
 
+		case *SkipDecl:
+			label := fmt.Sprintf("SkipDecl::%s", strings.Join(n.Tokens, " "))
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *PriorityDecl:
+			label := fmt.Sprintf("PriorityDecl::%s", strings.Join(n.Tokens, " "))
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *ExternalDecl:
+			label := fmt.Sprintf("ExternalDecl::%s", strings.Join(n.Tokens, " "))
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *StartDecl:
+			label := fmt.Sprintf("StartDecl::%s", strings.Join(n.NonTerminals, " "))
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *FragmentDecl:
+			label := fmt.Sprintf("FragmentDecl::%s", n.Name)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *CodeDecl:
+			graph.AddNode(dot.NewNode(name, "", "CodeDecl", dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *ImportDecl:
+			label := fmt.Sprintf("ImportDecl::%s", n.Path)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *OverrideDecl:
+			graph.AddNode(dot.NewNode(name, "", "OverrideDecl", dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
+		case *RemoveDecl:
+			label := "RemoveDecl"
+			if n.Name != "" {
+				label += "::" + n.Name
+			}
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
+		case *WhitespaceDecl:
+			label := fmt.Sprintf("WhitespaceDecl::%s", strings.Join(n.Chars, " "))
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+		case *IndentDecl:
+			graph.AddNode(dot.NewNode(name, "", "IndentDecl", dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
+
 		case *TerminalHandle:
 			label := fmt.Sprintf("TerminalHandle::%s", n.Terminal)
 			graph.AddNode(dot.NewNode(name, "", label, dot.ColorBurlyWood, dot.StyleFilled, dot.ShapeBox, "", ""))
@@ -191,7 +256,7 @@ func (n *Grammar) DOT() string {
 			}
 
 		case *RuleDecl:
-			label := fmt.Sprintf("RuleDecl::%s →", n.LHS)
+			label := fmt.Sprintf("RuleDecl::%s →", n.head())
 			graph.AddNode(dot.NewNode(name, "", label, dot.ColorLightPink, dot.StyleFilled, dot.ShapeBox, "", ""))
 
 			for _, m := range n.Children() {
@@ -239,44 +304,588 @@ func (n *Grammar) DOT() string {
 				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
 			}
 
+		case *ListRHS:
+			label := "ZERO OR MORE SEPARATED"
+			if n.Plus {
+				label = "ONE OR MORE SEPARATED"
+			}
+			if n.Trailing {
+				label += " (TRAILING)"
+			}
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorLavender, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
+		case *LabelRHS:
+			label := fmt.Sprintf("LABEL #%s", n.Label)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorLavender, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
+		case *ActionRHS:
+			graph.AddNode(dot.NewNode(name, "", "ACTION", dot.ColorLavender, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
+		case *FieldRHS:
+			label := fmt.Sprintf("FIELD %s", n.Field)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorLavender, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
+		case *InstanceRHS:
+			label := fmt.Sprintf("INSTANCE %s", n.NonTerminal)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorLavender, dot.StyleFilled, dot.ShapeBox, "", ""))
+
+			for _, m := range n.Children() {
+				from, to := name, fmt.Sprintf("%d", nodeID[m])
+				graph.AddEdge(dot.NewEdge(from, to, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+			}
+
 		case *NonTerminalRHS:
 			label := fmt.Sprintf("NonTerminal::%s", n.NonTerminal)
 			graph.AddNode(dot.NewNode(name, "", label, dot.ColorTurquoise, dot.StyleFilled, dot.ShapeBox, "", ""))
 
-		case *TerminalRHS:
-			label := fmt.Sprintf("Terminal::%s", n.Terminal)
-			graph.AddNode(dot.NewNode(name, "", label, dot.ColorSpringGreen, dot.StyleFilled, dot.ShapeOval, "", ""))
+		case *TerminalRHS:
+			label := fmt.Sprintf("Terminal::%s", n.Terminal)
+			graph.AddNode(dot.NewNode(name, "", label, dot.ColorSpringGreen, dot.StyleFilled, dot.ShapeOval, "", ""))
+
+		case *EmptyRHS:
+			graph.AddNode(dot.NewNode(name, "", "ε", dot.ColorViolet, dot.StyleFilled, dot.ShapeCircle, "", ""))
+
+		}
+
+		return true
+	})
+
+	return graph.DOT() + "\n"
+}
+
+// Decl represents a declaration in an EBNF grammar.
+// This node corresponds to `decl → token | directive | rule | mode` production rule.
+type Decl interface {
+	Node
+	decl()
+}
+
+// StringTokenDecl represents a token declaration with a string value in an EBNF grammar.
+// This node corresponds to the `token → TOKEN "=" (STRING | ISTRING) [action]` production rule.
+type StringTokenDecl struct {
+	Name       string
+	Value      string
+	IgnoreCase bool
+	Action     string
+	Position   *lexer.Position
+}
+
+func (n *StringTokenDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "TokenDecl::%s=%q", n.Name, n.Value)
+	if n.IgnoreCase {
+		b.WriteString("i")
+	}
+	if n.Action != "" {
+		fmt.Fprintf(&b, " %s", n.Action)
+	}
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *StringTokenDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*StringTokenDecl)
+	return ok &&
+		n.Name == nn.Name &&
+		n.Value == nn.Value &&
+		n.IgnoreCase == nn.IgnoreCase &&
+		n.Action == nn.Action &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *StringTokenDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *StringTokenDecl) leaf() {}
+
+func (n *StringTokenDecl) decl() {}
+
+// RegexTokenDecl represents a token declaration with a regular expression in an EBNF grammar.
+// This node corresponds to the `token → TOKEN "=" REGEX [action]` production rule.
+type RegexTokenDecl struct {
+	Name     string
+	Regex    string
+	Action   string
+	Position *lexer.Position
+}
+
+func (n *RegexTokenDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "TokenDecl::%s=/%s/", n.Name, n.Regex)
+	if n.Action != "" {
+		fmt.Fprintf(&b, " %s", n.Action)
+	}
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *RegexTokenDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*RegexTokenDecl)
+	return ok &&
+		n.Name == nn.Name &&
+		n.Regex == nn.Regex &&
+		n.Action == nn.Action &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *RegexTokenDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *RegexTokenDecl) leaf() {}
+
+func (n *RegexTokenDecl) decl() {}
+
+// ModeDecl represents a lexer mode declaration in an EBNF grammar.
+// This node corresponds to the `mode → "@mode" IDENT "{" {token [";"]} "}"` production rule.
+type ModeDecl struct {
+	Name     string
+	Tokens   []Decl
+	Position *lexer.Position
+}
+
+func (n *ModeDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "ModeDecl::%s", n.Name)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *ModeDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*ModeDecl)
+	if !ok {
+		return false
+	}
+
+	if n.Name != nn.Name {
+		return false
+	}
+
+	if len(n.Tokens) != len(nn.Tokens) {
+		return false
+	}
+
+	for i := range len(n.Tokens) {
+		if !n.Tokens[i].Equal(nn.Tokens[i]) {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *ModeDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *ModeDecl) Children() []Node {
+	nodes := make([]Node, len(n.Tokens))
+	for i, token := range n.Tokens {
+		nodes[i] = token
+	}
+
+	return nodes
+}
+
+func (n *ModeDecl) decl() {}
+
+// PrecedenceDecl represents a precedence declaration in an EBNF grammar.
+// This node corresponds to the `token → ("@left" | "@right" | "@none") {{handle}}` production rule.
+type PrecedenceDecl struct {
+	Associativity lr.Associativity
+	Handles       []PrecedenceHandle
+	Position      *lexer.Position
+}
+
+func (n *PrecedenceDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "PrecedenceDecl::%s=%s", n.Associativity, n.Handles)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *PrecedenceDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*PrecedenceDecl)
+	if !ok {
+		return false
+	}
+
+	if n.Associativity != nn.Associativity {
+		return false
+	}
+
+	if len(n.Handles) != len(nn.Handles) {
+		return false
+	}
+
+	for i := range len(n.Handles) {
+		if !n.Handles[i].Equal(nn.Handles[i]) {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *PrecedenceDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *PrecedenceDecl) Children() []Node {
+	nodes := make([]Node, len(n.Handles))
+	for i, handle := range n.Handles {
+		nodes[i] = handle
+	}
+
+	return nodes
+}
+
+func (n *PrecedenceDecl) decl() {}
+
+// SkipDecl represents a skip declaration in an EBNF grammar.
+// This node corresponds to the `directive → "@skip" {{TOKEN}}` production rule.
+type SkipDecl struct {
+	Tokens   []string
+	Position *lexer.Position
+}
+
+func (n *SkipDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "SkipDecl::%s", n.Tokens)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *SkipDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*SkipDecl)
+	if !ok {
+		return false
+	}
+
+	if len(n.Tokens) != len(nn.Tokens) {
+		return false
+	}
+
+	for i := range len(n.Tokens) {
+		if n.Tokens[i] != nn.Tokens[i] {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *SkipDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *SkipDecl) Children() []Node {
+	return nil
+}
+
+func (n *SkipDecl) decl() {}
+
+// PriorityDecl represents a priority declaration in an EBNF grammar.
+// This node corresponds to the `directive → "@priority" {{TOKEN}}` production rule.
+type PriorityDecl struct {
+	Tokens   []string
+	Position *lexer.Position
+}
+
+func (n *PriorityDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "PriorityDecl::%s", n.Tokens)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *PriorityDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*PriorityDecl)
+	if !ok {
+		return false
+	}
+
+	if len(n.Tokens) != len(nn.Tokens) {
+		return false
+	}
+
+	for i := range len(n.Tokens) {
+		if n.Tokens[i] != nn.Tokens[i] {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *PriorityDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *PriorityDecl) Children() []Node {
+	return nil
+}
+
+func (n *PriorityDecl) decl() {}
+
+// ExternalDecl represents an external token declaration in an EBNF grammar.
+// This node corresponds to the `directive → "@external" {{TOKEN}}` production rule.
+type ExternalDecl struct {
+	Tokens   []string
+	Position *lexer.Position
+}
+
+func (n *ExternalDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "ExternalDecl::%s", n.Tokens)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *ExternalDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*ExternalDecl)
+	if !ok {
+		return false
+	}
+
+	if len(n.Tokens) != len(nn.Tokens) {
+		return false
+	}
+
+	for i := range len(n.Tokens) {
+		if n.Tokens[i] != nn.Tokens[i] {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *ExternalDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *ExternalDecl) Children() []Node {
+	return nil
+}
+
+func (n *ExternalDecl) decl() {}
+
+// StartDecl represents a start symbol declaration in an EBNF grammar.
+// This node corresponds to the `directive → "@start" {{IDENT}}` production rule.
+type StartDecl struct {
+	NonTerminals []string
+	Position     *lexer.Position
+}
+
+func (n *StartDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "StartDecl::%s", n.NonTerminals)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *StartDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*StartDecl)
+	if !ok {
+		return false
+	}
+
+	if len(n.NonTerminals) != len(nn.NonTerminals) {
+		return false
+	}
+
+	for i := range len(n.NonTerminals) {
+		if n.NonTerminals[i] != nn.NonTerminals[i] {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *StartDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *StartDecl) Children() []Node {
+	return nil
+}
+
+func (n *StartDecl) decl() {}
+
+// FragmentDecl represents a regex fragment declaration in an EBNF grammar.
+// This node corresponds to the `fragment → "@fragment" TOKEN "=" REGEX` production rule.
+type FragmentDecl struct {
+	Name     string
+	Regex    string
+	Position *lexer.Position
+}
+
+func (n *FragmentDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "FragmentDecl::%s=/%s/", n.Name, n.Regex)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *FragmentDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*FragmentDecl)
+	return ok &&
+		n.Name == nn.Name &&
+		n.Regex == nn.Regex &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *FragmentDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *FragmentDecl) Children() []Node {
+	return nil
+}
+
+func (n *FragmentDecl) decl() {}
+
+// CodeDecl represents a top-level block of Go code in an EBNF grammar.
+// This node corresponds to the `decl → CODE semi_opt` production rule.
+type CodeDecl struct {
+	Code     string
+	Position *lexer.Position
+}
+
+func (n *CodeDecl) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "CodeDecl::{%%%s%%}", n.Code)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
+
+	return b.String()
+}
+
+func (n *CodeDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*CodeDecl)
+	return ok &&
+		n.Code == nn.Code &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *CodeDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *CodeDecl) Children() []Node {
+	return nil
+}
+
+func (n *CodeDecl) decl() {}
+
+// ImportDecl represents an import declaration in an EBNF grammar.
+// This node corresponds to the `decl → "import" STRING semi_opt` production rule.
+type ImportDecl struct {
+	Path     string
+	Position *lexer.Position
+}
 
-		case *EmptyRHS:
-			graph.AddNode(dot.NewNode(name, "", "ε", dot.ColorViolet, dot.StyleFilled, dot.ShapeCircle, "", ""))
+func (n *ImportDecl) String() string {
+	var b bytes.Buffer
 
-		}
+	fmt.Fprintf(&b, "ImportDecl::%q", n.Path)
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
+	}
 
-		return true
-	})
+	return b.String()
+}
 
-	return graph.DOT() + "\n"
+func (n *ImportDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*ImportDecl)
+	return ok &&
+		n.Path == nn.Path &&
+		equalPositions(n.Position, nn.Position)
 }
 
-// Decl represents a declaration in an EBNF grammar.
-// This node corresponds to `decl → token | directive | rule` production rule.
-type Decl interface {
-	Node
-	decl()
+func (n *ImportDecl) Pos() *lexer.Position {
+	return n.Position
 }
 
-// StringTokenDecl represents a token declaration with a string value in an EBNF grammar.
-// This node corresponds to the `token → TOKEN "=" STRING` production rule.
-type StringTokenDecl struct {
-	Name     string
-	Value    string
+func (n *ImportDecl) Children() []Node {
+	return nil
+}
+
+func (n *ImportDecl) decl() {}
+
+// OverrideDecl represents a declaration that replaces a rule, a token, or a precedence level of an imported grammar.
+// This node corresponds to the `decl → override rule ";"`, `decl → "@override" token semi_opt`,
+// and `decl → "@override" directive semi_opt` production rules.
+type OverrideDecl struct {
+	Decl     Decl
 	Position *lexer.Position
 }
 
-func (n *StringTokenDecl) String() string {
+func (n *OverrideDecl) String() string {
 	var b bytes.Buffer
 
-	fmt.Fprintf(&b, "TokenDecl::%s=%q", n.Name, n.Value)
+	fmt.Fprint(&b, "OverrideDecl")
 	if hasPosition(n.Position) {
 		fmt.Fprintf(&b, " <%s>", n.Position)
 	}
@@ -284,34 +893,41 @@ func (n *StringTokenDecl) String() string {
 	return b.String()
 }
 
-func (n *StringTokenDecl) Equal(rhs Node) bool {
-	nn, ok := rhs.(*StringTokenDecl)
+func (n *OverrideDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*OverrideDecl)
 	return ok &&
-		n.Name == nn.Name &&
-		n.Value == nn.Value &&
+		n.Decl.Equal(nn.Decl) &&
 		equalPositions(n.Position, nn.Position)
 }
 
-func (n *StringTokenDecl) Pos() *lexer.Position {
+func (n *OverrideDecl) Pos() *lexer.Position {
 	return n.Position
 }
 
-func (n *StringTokenDecl) leaf() {}
+func (n *OverrideDecl) Children() []Node {
+	return []Node{n.Decl}
+}
 
-func (n *StringTokenDecl) decl() {}
+func (n *OverrideDecl) decl() {}
 
-// RegexTokenDecl represents a token declaration with a regular expression in an EBNF grammar.
-// This node corresponds to the `token → TOKEN "=" REGEX` production rule.
-type RegexTokenDecl struct {
+// RemoveDecl represents a declaration that removes rules, tokens, or a precedence level of an imported grammar.
+// Either Decl is set to the removed production rules or precedence level, or Name is set to the removed non-terminal or token.
+// This node corresponds to the `decl → "@remove" rule ";"`, `decl → "@remove" directive semi_opt`,
+// `decl → "@remove" IDENT semi_opt`, and `decl → "@remove" TOKEN semi_opt` production rules.
+type RemoveDecl struct {
+	Decl     Decl
 	Name     string
-	Regex    string
 	Position *lexer.Position
 }
 
-func (n *RegexTokenDecl) String() string {
+func (n *RemoveDecl) String() string {
 	var b bytes.Buffer
 
-	fmt.Fprintf(&b, "TokenDecl::%s=/%s/", n.Name, n.Regex)
+	fmt.Fprint(&b, "RemoveDecl")
+	if n.Name != "" {
+		fmt.Fprintf(&b, "::%s", n.Name)
+	}
+
 	if hasPosition(n.Position) {
 		fmt.Fprintf(&b, " <%s>", n.Position)
 	}
@@ -319,34 +935,49 @@ func (n *RegexTokenDecl) String() string {
 	return b.String()
 }
 
-func (n *RegexTokenDecl) Equal(rhs Node) bool {
-	nn, ok := rhs.(*RegexTokenDecl)
-	return ok &&
-		n.Name == nn.Name &&
-		n.Regex == nn.Regex &&
+func (n *RemoveDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*RemoveDecl)
+	if !ok {
+		return false
+	}
+
+	if (n.Decl == nil) != (nn.Decl == nil) {
+		return false
+	}
+
+	if n.Decl != nil && !n.Decl.Equal(nn.Decl) {
+		return false
+	}
+
+	return n.Name == nn.Name &&
 		equalPositions(n.Position, nn.Position)
 }
 
-func (n *RegexTokenDecl) Pos() *lexer.Position {
+func (n *RemoveDecl) Pos() *lexer.Position {
 	return n.Position
 }
 
-func (n *RegexTokenDecl) leaf() {}
+func (n *RemoveDecl) Children() []Node {
+	if n.Decl == nil {
+		return nil
+	}
 
-func (n *RegexTokenDecl) decl() {}
+	return []Node{n.Decl}
+}
 
-// PrecedenceDecl represents a precedence declaration in an EBNF grammar.
-// This node corresponds to the `token → ("@left" | "@right" | "@none") {{handle}}` production rule.
-type PrecedenceDecl struct {
-	Associativity lr.Associativity
-	Handles       []PrecedenceHandle
-	Position      *lexer.Position
+func (n *RemoveDecl) decl() {}
+
+// WhitespaceDecl represents a whitespace declaration in an EBNF grammar.
+// This node corresponds to the `directive → "@whitespace" {STRING}` production rule.
+type WhitespaceDecl struct {
+	Chars    []string
+	Position *lexer.Position
 }
 
-func (n *PrecedenceDecl) String() string {
+func (n *WhitespaceDecl) String() string {
 	var b bytes.Buffer
 
-	fmt.Fprintf(&b, "PrecedenceDecl::%s=%s", n.Associativity, n.Handles)
+	fmt.Fprintf(&b, "WhitespaceDecl::%s", n.Chars)
 	if hasPosition(n.Position) {
 		fmt.Fprintf(&b, " <%s>", n.Position)
 	}
@@ -354,22 +985,18 @@ func (n *PrecedenceDecl) String() string {
 	return b.String()
 }
 
-func (n *PrecedenceDecl) Equal(rhs Node) bool {
-	nn, ok := rhs.(*PrecedenceDecl)
+func (n *WhitespaceDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*WhitespaceDecl)
 	if !ok {
 		return false
 	}
 
-	if n.Associativity != nn.Associativity {
-		return false
-	}
-
-	if len(n.Handles) != len(nn.Handles) {
+	if len(n.Chars) != len(nn.Chars) {
 		return false
 	}
 
-	for i := range len(n.Handles) {
-		if !n.Handles[i].Equal(nn.Handles[i]) {
+	for i := range len(n.Chars) {
+		if n.Chars[i] != nn.Chars[i] {
 			return false
 		}
 	}
@@ -377,20 +1004,51 @@ func (n *PrecedenceDecl) Equal(rhs Node) bool {
 	return equalPositions(n.Position, nn.Position)
 }
 
-func (n *PrecedenceDecl) Pos() *lexer.Position {
+func (n *WhitespaceDecl) Pos() *lexer.Position {
 	return n.Position
 }
 
-func (n *PrecedenceDecl) Children() []Node {
-	nodes := make([]Node, len(n.Handles))
-	for i, handle := range n.Handles {
-		nodes[i] = handle
+func (n *WhitespaceDecl) Children() []Node {
+	return nil
+}
+
+func (n *WhitespaceDecl) decl() {}
+
+// IndentDecl represents an indentation declaration in an EBNF grammar.
+// This node corresponds to the `directive → "@indent"` production rule.
+type IndentDecl struct {
+	Position *lexer.Position
+}
+
+func (n *IndentDecl) String() string {
+	var b bytes.Buffer
+
+	b.WriteString("IndentDecl")
+	if hasPosition(n.Position) {
+		fmt.Fprintf(&b, " <%s>", n.Position)
 	}
 
-	return nodes
+	return b.String()
 }
 
-func (n *PrecedenceDecl) decl() {}
+func (n *IndentDecl) Equal(rhs Node) bool {
+	nn, ok := rhs.(*IndentDecl)
+	if !ok {
+		return false
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *IndentDecl) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *IndentDecl) Children() []Node {
+	return nil
+}
+
+func (n *IndentDecl) decl() {}
 
 // PrecedenceHandle represents a handle in a precedence level within an EBNF grammar.
 // This node corresponds to the `handle → term | "<" rule ">"` production rule.
@@ -470,8 +1128,10 @@ func (n *ProductionHandle) precedenceHandle() {}
 
 // RuleDecl represents a rule declaration in an EBNF grammar.
 // This node corresponds to the `rule → lhs "=" [rhs]` production rule.
+// Params is non-empty for a parameterized rule, i.e., `lhs → nonterm "<" params ">"`.
 type RuleDecl struct {
 	LHS      string
+	Params   []string
 	RHS      RHS
 	Position *lexer.Position
 }
@@ -479,7 +1139,7 @@ type RuleDecl struct {
 func (n *RuleDecl) String() string {
 	var b bytes.Buffer
 
-	fmt.Fprintf(&b, "RuleDecl::%s → %s", n.LHS, n.RHS)
+	fmt.Fprintf(&b, "RuleDecl::%s → %s", n.head(), n.RHS)
 
 	return b.String()
 }
@@ -488,6 +1148,7 @@ func (n *RuleDecl) Equal(rhs Node) bool {
 	nn, ok := rhs.(*RuleDecl)
 	return ok &&
 		n.LHS == nn.LHS &&
+		slices.Equal(n.Params, nn.Params) &&
 		n.RHS.Equal(nn.RHS) &&
 		equalPositions(n.Position, nn.Position)
 }
@@ -504,6 +1165,15 @@ func (n *RuleDecl) Children() []Node {
 
 func (n *RuleDecl) decl() {}
 
+// head returns the left-hand side of the rule along with its parameters, if any.
+func (n *RuleDecl) head() string {
+	if len(n.Params) == 0 {
+		return n.LHS
+	}
+
+	return fmt.Sprintf("%s<%s>", n.LHS, strings.Join(n.Params, ", "))
+}
+
 // RHS represents the right-hand side (rhs) non-terminal in an EBNF grammar.
 // This node corresponds to the `rhs → rhs rhs | rhs "|" rhs | "(" rhs ")" | "[" rhs "]" | "{" rhs "}" | "{{" rhs "}}" | nonterm | term | ε` production rule.
 type RHS interface {
@@ -733,6 +1403,221 @@ func (n *PlusRHS) Children() []Node {
 
 func (n *PlusRHS) rhs() {}
 
+// ListRHS represents a list of RHS nodes separated by another RHS node in an EBNF grammar.
+// This node corresponds to the `rhs → "{" rhs "%" rhs "}"`, `rhs → "{" rhs "%%" rhs "}"`,
+// `rhs → "{{" rhs "%" rhs "}}"`, and `rhs → "{{" rhs "%%" rhs "}}"` production rules.
+// Plus is true for one or more elements, and Trailing is true if an optional trailing separator is allowed.
+type ListRHS struct {
+	Op       RHS
+	Sep      RHS
+	Plus     bool
+	Trailing bool
+	Position *lexer.Position
+}
+
+func (n *ListRHS) String() string {
+	var b bytes.Buffer
+
+	sep := "%"
+	if n.Trailing {
+		sep = "%%"
+	}
+
+	if n.Plus {
+		fmt.Fprintf(&b, "ListRHS::{{%s %s %s}}", n.Op, sep, n.Sep)
+	} else {
+		fmt.Fprintf(&b, "ListRHS::{%s %s %s}", n.Op, sep, n.Sep)
+	}
+
+	return b.String()
+}
+
+func (n *ListRHS) Equal(rhs Node) bool {
+	nn, ok := rhs.(*ListRHS)
+	return ok &&
+		n.Op.Equal(nn.Op) &&
+		n.Sep.Equal(nn.Sep) &&
+		n.Plus == nn.Plus &&
+		n.Trailing == nn.Trailing &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *ListRHS) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *ListRHS) Children() []Node {
+	nodes := []Node{n.Op, n.Sep}
+
+	return nodes
+}
+
+func (n *ListRHS) rhs() {}
+
+// LabelRHS represents a labeled alternative in an EBNF grammar.
+// This node corresponds to the `rhs → rhs LABEL` production rule.
+type LabelRHS struct {
+	Op       RHS
+	Label    string
+	Position *lexer.Position
+}
+
+func (n *LabelRHS) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "LabelRHS::%s #%s", n.Op, n.Label)
+
+	return b.String()
+}
+
+func (n *LabelRHS) Equal(rhs Node) bool {
+	nn, ok := rhs.(*LabelRHS)
+	return ok &&
+		n.Op.Equal(nn.Op) &&
+		n.Label == nn.Label &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *LabelRHS) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *LabelRHS) Children() []Node {
+	nodes := []Node{n.Op}
+
+	return nodes
+}
+
+func (n *LabelRHS) rhs() {}
+
+// ActionRHS represents an alternative with a semantic action in an EBNF grammar.
+// This node corresponds to the `rhs → rhs CODE` production rule.
+// An empty alternative with a semantic action has an EmptyRHS as its operand.
+type ActionRHS struct {
+	Op       RHS
+	Code     string
+	Position *lexer.Position
+}
+
+func (n *ActionRHS) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "ActionRHS::%s {%%%s%%}", n.Op, n.Code)
+
+	return b.String()
+}
+
+func (n *ActionRHS) Equal(rhs Node) bool {
+	nn, ok := rhs.(*ActionRHS)
+	return ok &&
+		n.Op.Equal(nn.Op) &&
+		n.Code == nn.Code &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *ActionRHS) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *ActionRHS) Children() []Node {
+	nodes := []Node{n.Op}
+
+	return nodes
+}
+
+func (n *ActionRHS) rhs() {}
+
+// FieldRHS represents a named symbol in an EBNF grammar.
+// This node corresponds to the `rhs → FIELD rhs` production rule.
+type FieldRHS struct {
+	Field    string
+	Op       RHS
+	Position *lexer.Position
+}
+
+func (n *FieldRHS) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "FieldRHS::%s:%s", n.Field, n.Op)
+
+	return b.String()
+}
+
+func (n *FieldRHS) Equal(rhs Node) bool {
+	nn, ok := rhs.(*FieldRHS)
+	return ok &&
+		n.Field == nn.Field &&
+		n.Op.Equal(nn.Op) &&
+		equalPositions(n.Position, nn.Position)
+}
+
+func (n *FieldRHS) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *FieldRHS) Children() []Node {
+	nodes := []Node{n.Op}
+
+	return nodes
+}
+
+func (n *FieldRHS) rhs() {}
+
+// InstanceRHS represents an instantiation of a parameterized rule with a list of arguments in an EBNF grammar.
+// This node corresponds to the `rhs → nonterm "<" args ">"` production rule.
+type InstanceRHS struct {
+	NonTerminal string
+	Args        []RHS
+	Position    *lexer.Position
+}
+
+func (n *InstanceRHS) String() string {
+	var b bytes.Buffer
+
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+
+	fmt.Fprintf(&b, "InstanceRHS::%s<%s>", n.NonTerminal, strings.Join(args, ", "))
+
+	return b.String()
+}
+
+func (n *InstanceRHS) Equal(rhs Node) bool {
+	nn, ok := rhs.(*InstanceRHS)
+	if !ok {
+		return false
+	}
+
+	if n.NonTerminal != nn.NonTerminal || len(n.Args) != len(nn.Args) {
+		return false
+	}
+
+	for i := range len(n.Args) {
+		if !n.Args[i].Equal(nn.Args[i]) {
+			return false
+		}
+	}
+
+	return equalPositions(n.Position, nn.Position)
+}
+
+func (n *InstanceRHS) Pos() *lexer.Position {
+	return n.Position
+}
+
+func (n *InstanceRHS) Children() []Node {
+	nodes := make([]Node, len(n.Args))
+	for i, arg := range n.Args {
+		nodes[i] = arg
+	}
+
+	return nodes
+}
+
+func (n *InstanceRHS) rhs() {}
+
 // NonTerminalRHS represents a non-terminal symbol as the right-hand side of a rule in an EBNF grammar.
 // This node corresponds to the `rhs → nonterm` production rule.
 type NonTerminalRHS struct {
@@ -1084,6 +1084,106 @@ func TestStringTokenDecl(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WithAction",
+			n: &StringTokenDecl{
+				Name:   "QUOTE",
+				Value:  "\"",
+				Action: "@push string",
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `TokenDecl::QUOTE="\"" @push string <program.code:1:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs: &StringTokenDecl{
+						Name:  "QUOTE",
+						Value: "\"",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &StringTokenDecl{
+						Name:   "QUOTE",
+						Value:  "\"",
+						Action: "@push string",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+		{
+			name: "IgnoreCase",
+			n: &StringTokenDecl{
+				Name:       "BEGIN",
+				Value:      "begin",
+				IgnoreCase: true,
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `TokenDecl::BEGIN="begin"i <program.code:1:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs: &StringTokenDecl{
+						Name:  "BEGIN",
+						Value: "begin",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &StringTokenDecl{
+						Name:       "BEGIN",
+						Value:      "begin",
+						IgnoreCase: true,
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1165,6 +1265,175 @@ func TestRegexTokenDecl(t *testing.T) {
 	}
 }
 
+func TestModeDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *ModeDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &ModeDecl{
+				Name: "string",
+				Tokens: []Decl{
+					&RegexTokenDecl{
+						Name:  "CHARS",
+						Regex: `[^"]+`,
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   16,
+							Line:     2,
+							Column:   3,
+						},
+					},
+					&StringTokenDecl{
+						Name:   "QUOTE",
+						Value:  `"`,
+						Action: "@pop",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   36,
+							Line:     3,
+							Column:   3,
+						},
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `ModeDecl::string <program.code:1:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &ModeDecl{
+						Name: "other",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ModeDecl{
+						Name: "string",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ModeDecl{
+						Name: "string",
+						Tokens: []Decl{
+							&RegexTokenDecl{
+								Name:  "CHARS",
+								Regex: `[^"]+`,
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   16,
+									Line:     2,
+									Column:   3,
+								},
+							},
+							&StringTokenDecl{
+								Name:  "QUOTE",
+								Value: `"`,
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   36,
+									Line:     3,
+									Column:   3,
+								},
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ModeDecl{
+						Name: "string",
+						Tokens: []Decl{
+							&RegexTokenDecl{
+								Name:  "CHARS",
+								Regex: `[^"]+`,
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   16,
+									Line:     2,
+									Column:   3,
+								},
+							},
+							&StringTokenDecl{
+								Name:   "QUOTE",
+								Value:  `"`,
+								Action: "@pop",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   36,
+									Line:     3,
+									Column:   3,
+								},
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			for i, child := range tc.n.Children() {
+				assert.Equal(t, tc.n.Tokens[i], child)
+			}
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
 func TestPrecedenceDecl(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1357,31 +1626,31 @@ func TestPrecedenceDecl(t *testing.T) {
 	}
 }
 
-func TestTerminalHandle(t *testing.T) {
+func TestSkipDecl(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *TerminalHandle
+		n              *SkipDecl
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &TerminalHandle{
-				Terminal: "*",
+			n: &SkipDecl{
+				Tokens: []string{"COMMENT", "SHEBANG"},
 				Position: &lexer.Position{
 					Filename: "program.code",
-					Offset:   7,
+					Offset:   0,
 					Line:     1,
-					Column:   8,
+					Column:   1,
 				},
 			},
-			expectedString: `TerminalHandle::* <program.code:1:8>`,
+			expectedString: `SkipDecl::[COMMENT SHEBANG] <program.code:1:1>`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   7,
+				Offset:   0,
 				Line:     1,
-				Column:   8,
+				Column:   1,
 			},
 			equalTests: []EqualTest{
 				{
@@ -1389,13 +1658,37 @@ func TestTerminalHandle(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &TerminalHandle{
-						Terminal: "*",
+					rhs: &SkipDecl{
+						Tokens: []string{"COMMENT"},
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   7,
+							Offset:   0,
 							Line:     1,
-							Column:   8,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &SkipDecl{
+						Tokens: []string{"COMMENT", "NEWLINE"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &SkipDecl{
+						Tokens: []string{"COMMENT", "SHEBANG"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
 						},
 					},
 					expected: true,
@@ -1408,73 +1701,42 @@ func TestTerminalHandle(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert.Equal(t, tc.expectedString, tc.n.String())
 			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
 
 			for _, equalTest := range tc.equalTests {
 				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
 			}
 
-			tc.n.leaf()
-			tc.n.precedenceHandle()
+			tc.n.decl()
 		})
 	}
 }
 
-func TestProductionHandle(t *testing.T) {
+func TestPriorityDecl(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *ProductionHandle
+		n              *PriorityDecl
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &ProductionHandle{
-				LHS: "expr",
-				RHS: &ConcatRHS{
-					Ops: []RHS{
-						&NonTerminalRHS{
-							NonTerminal: "expr",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   15,
-								Line:     1,
-								Column:   16,
-							},
-						},
-						&TerminalRHS{
-							Terminal: "*",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   21,
-								Line:     1,
-								Column:   22,
-							},
-						},
-						&NonTerminalRHS{
-							NonTerminal: "expr",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   25,
-								Line:     1,
-								Column:   26,
-							},
-						},
-					},
-				},
+			n: &PriorityDecl{
+				Tokens: []string{"HEX_INT", "INT"},
 				Position: &lexer.Position{
 					Filename: "program.code",
-					Offset:   7,
+					Offset:   0,
 					Line:     1,
-					Column:   8,
+					Column:   1,
 				},
 			},
-			expectedString: `ProductionHandle::expr → ConcatRHS::NonTerminalRHS::expr <program.code:1:16> TerminalRHS::* <program.code:1:22> NonTerminalRHS::expr <program.code:1:26>`,
+			expectedString: `PriorityDecl::[HEX_INT INT] <program.code:1:1>`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   7,
+				Offset:   0,
 				Line:     1,
-				Column:   8,
+				Column:   1,
 			},
 			equalTests: []EqualTest{
 				{
@@ -1482,44 +1744,37 @@ func TestProductionHandle(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &ProductionHandle{
-						LHS: "expr",
-						RHS: &ConcatRHS{
-							Ops: []RHS{
-								&NonTerminalRHS{
-									NonTerminal: "expr",
-									Position: &lexer.Position{
-										Filename: "program.code",
-										Offset:   15,
-										Line:     1,
-										Column:   16,
-									},
-								},
-								&TerminalRHS{
-									Terminal: "*",
-									Position: &lexer.Position{
-										Filename: "program.code",
-										Offset:   21,
-										Line:     1,
-										Column:   22,
-									},
-								},
-								&NonTerminalRHS{
-									NonTerminal: "expr",
-									Position: &lexer.Position{
-										Filename: "program.code",
-										Offset:   25,
-										Line:     1,
-										Column:   26,
-									},
-								},
-							},
+					rhs: &PriorityDecl{
+						Tokens: []string{"HEX_INT"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
 						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &PriorityDecl{
+						Tokens: []string{"HEX_INT", "FLOAT"},
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   7,
+							Offset:   0,
 							Line:     1,
-							Column:   8,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &PriorityDecl{
+						Tokens: []string{"HEX_INT", "INT"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
 						},
 					},
 					expected: true,
@@ -1532,62 +1787,29 @@ func TestProductionHandle(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert.Equal(t, tc.expectedString, tc.n.String())
 			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
-
-			expectedChildren := []Node{tc.n.RHS}
-			assert.Equal(t, expectedChildren, tc.n.Children())
+			assert.Len(t, tc.n.Children(), 0)
 
 			for _, equalTest := range tc.equalTests {
 				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
 			}
 
-			tc.n.precedenceHandle()
+			tc.n.decl()
 		})
 	}
 }
 
-func TestRuleDecl(t *testing.T) {
+func TestExternalDecl(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *RuleDecl
+		n              *ExternalDecl
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &RuleDecl{
-				LHS: "expr",
-				RHS: &ConcatRHS{
-					Ops: []RHS{
-						&NonTerminalRHS{
-							NonTerminal: "expr",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   9,
-								Line:     1,
-								Column:   10,
-							},
-						},
-						&TerminalRHS{
-							Terminal: "+",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   15,
-								Line:     1,
-								Column:   16,
-							},
-						},
-						&NonTerminalRHS{
-							NonTerminal: "expr",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   19,
-								Line:     1,
-								Column:   20,
-							},
-						},
-					},
-				},
+			n: &ExternalDecl{
+				Tokens: []string{"RAW_STRING", "COMMENT"},
 				Position: &lexer.Position{
 					Filename: "program.code",
 					Offset:   0,
@@ -1595,7 +1817,7 @@ func TestRuleDecl(t *testing.T) {
 					Column:   1,
 				},
 			},
-			expectedString: `RuleDecl::expr → ConcatRHS::NonTerminalRHS::expr <program.code:1:10> TerminalRHS::+ <program.code:1:16> NonTerminalRHS::expr <program.code:1:20>`,
+			expectedString: `ExternalDecl::[RAW_STRING COMMENT] <program.code:1:1>`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
 				Offset:   0,
@@ -1608,39 +1830,32 @@ func TestRuleDecl(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &RuleDecl{
-						LHS: "expr",
-						RHS: &ConcatRHS{
-							Ops: []RHS{
-								&NonTerminalRHS{
-									NonTerminal: "expr",
-									Position: &lexer.Position{
-										Filename: "program.code",
-										Offset:   9,
-										Line:     1,
-										Column:   10,
-									},
-								},
-								&TerminalRHS{
-									Terminal: "+",
-									Position: &lexer.Position{
-										Filename: "program.code",
-										Offset:   15,
-										Line:     1,
-										Column:   16,
-									},
-								},
-								&NonTerminalRHS{
-									NonTerminal: "expr",
-									Position: &lexer.Position{
-										Filename: "program.code",
-										Offset:   19,
-										Line:     1,
-										Column:   20,
-									},
-								},
-							},
+					rhs: &ExternalDecl{
+						Tokens: []string{"RAW_STRING"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ExternalDecl{
+						Tokens: []string{"RAW_STRING", "HEREDOC"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
 						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ExternalDecl{
+						Tokens: []string{"RAW_STRING", "COMMENT"},
 						Position: &lexer.Position{
 							Filename: "program.code",
 							Offset:   0,
@@ -1658,9 +1873,7 @@ func TestRuleDecl(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert.Equal(t, tc.expectedString, tc.n.String())
 			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
-
-			expectedChildren := []Node{tc.n.RHS}
-			assert.Equal(t, expectedChildren, tc.n.Children())
+			assert.Len(t, tc.n.Children(), 0)
 
 			for _, equalTest := range tc.equalTests {
 				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
@@ -1671,44 +1884,31 @@ func TestRuleDecl(t *testing.T) {
 	}
 }
 
-func TestConcatRHS(t *testing.T) {
+func TestStartDecl(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *ConcatRHS
+		n              *StartDecl
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &ConcatRHS{
-				Ops: []RHS{
-					&TerminalRHS{
-						Terminal: "PROGRAM",
-						Position: &lexer.Position{
-							Filename: "program.code",
-							Offset:   9,
-							Line:     1,
-							Column:   10,
-						},
-					},
-					&TerminalRHS{
-						Terminal: "ID",
-						Position: &lexer.Position{
-							Filename: "program.code",
-							Offset:   19,
-							Line:     1,
-							Column:   20,
-						},
-					},
+			n: &StartDecl{
+				NonTerminals: []string{"stmt", "expr"},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
 				},
 			},
-			expectedString: `ConcatRHS::TerminalRHS::PROGRAM <program.code:1:10> TerminalRHS::ID <program.code:1:20>`,
+			expectedString: `StartDecl::[stmt expr] <program.code:1:1>`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   9,
+				Offset:   0,
 				Line:     1,
-				Column:   10,
+				Column:   1,
 			},
 			equalTests: []EqualTest{
 				{
@@ -1716,68 +1916,1552 @@ func TestConcatRHS(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &ConcatRHS{
-						Ops: []RHS{
-							&TerminalRHS{
-								Terminal: "ID",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   19,
-									Line:     1,
-									Column:   20,
-								},
-							},
+					rhs: &StartDecl{
+						NonTerminals: []string{"stmt"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
 						},
 					},
 					expected: false,
 				},
 				{
-					rhs: &ConcatRHS{
-						Ops: []RHS{
-							&TerminalRHS{
-								Terminal: "PROG",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   9,
-									Line:     1,
-									Column:   10,
-								},
-							},
-							&TerminalRHS{
-								Terminal: "ID",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   19,
-									Line:     1,
-									Column:   20,
-								},
-							},
-						},
-					},
+					rhs: &StartDecl{
+						NonTerminals: []string{"stmt", "decl"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &StartDecl{
+						NonTerminals: []string{"stmt", "expr"},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestFragmentDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *FragmentDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &FragmentDecl{
+				Name:  "DIGITS",
+				Regex: "[0-9]+",
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `FragmentDecl::DIGITS=/[0-9]+/ <program.code:1:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &FragmentDecl{
+						Name:  "DIGITS",
+						Regex: "[0-7]+",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &FragmentDecl{
+						Name:  "DIGITS",
+						Regex: "[0-9]+",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestCodeDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *CodeDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &CodeDecl{
+				Code: ` import "strconv" `,
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   14,
+					Line:     2,
+					Column:   1,
+				},
+			},
+			expectedString: `CodeDecl::{% import "strconv" %} <program.code:2:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   14,
+				Line:     2,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &CodeDecl{
+						Code: ` import "errors" `,
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &CodeDecl{
+						Code: ` import "strconv" `,
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestImportDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *ImportDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &ImportDecl{
+				Path: "expr.ebnf",
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   14,
+					Line:     2,
+					Column:   1,
+				},
+			},
+			expectedString: `ImportDecl::"expr.ebnf" <program.code:2:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   14,
+				Line:     2,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &ImportDecl{
+						Path: "stmt.ebnf",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ImportDecl{
+						Path: "expr.ebnf",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestOverrideDecl(t *testing.T) {
+	tests := []struct {
+		name             string
+		n                *OverrideDecl
+		expectedString   string
+		expectedPos      *lexer.Position
+		expectedChildren []Node
+		equalTests       []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &OverrideDecl{
+				Decl: &RegexTokenDecl{
+					Name:  "NUM",
+					Regex: `[0-9_]+`,
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   24,
+						Line:     2,
+						Column:   11,
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   14,
+					Line:     2,
+					Column:   1,
+				},
+			},
+			expectedString: `OverrideDecl <program.code:2:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   14,
+				Line:     2,
+				Column:   1,
+			},
+			expectedChildren: []Node{
+				&RegexTokenDecl{
+					Name:  "NUM",
+					Regex: `[0-9_]+`,
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   24,
+						Line:     2,
+						Column:   11,
+					},
+				},
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &OverrideDecl{
+						Decl: &RegexTokenDecl{
+							Name:  "NUM",
+							Regex: `[0-9]+`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   24,
+								Line:     2,
+								Column:   11,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &OverrideDecl{
+						Decl: &RegexTokenDecl{
+							Name:  "NUM",
+							Regex: `[0-9_]+`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   24,
+								Line:     2,
+								Column:   11,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			children := tc.n.Children()
+			assert.Len(t, children, len(tc.expectedChildren))
+			for i := range children {
+				assert.True(t, children[i].Equal(tc.expectedChildren[i]))
+			}
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestRemoveDecl(t *testing.T) {
+	tests := []struct {
+		name             string
+		n                *RemoveDecl
+		expectedString   string
+		expectedPos      *lexer.Position
+		expectedChildren []Node
+		equalTests       []EqualTest
+	}{
+		{
+			name: "Name",
+			n: &RemoveDecl{
+				Name: "STR",
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   14,
+					Line:     2,
+					Column:   1,
+				},
+			},
+			expectedString: `RemoveDecl::STR <program.code:2:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   14,
+				Line:     2,
+				Column:   1,
+			},
+			expectedChildren: []Node{},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &RemoveDecl{
+						Name: "NUM",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &RemoveDecl{
+						Decl: &RegexTokenDecl{
+							Name:  "STR",
+							Regex: `"[^"]*"`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   22,
+								Line:     2,
+								Column:   11,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &RemoveDecl{
+						Name: "STR",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+		{
+			name: "Decl",
+			n: &RemoveDecl{
+				Decl: &RegexTokenDecl{
+					Name:  "STR",
+					Regex: `"[^"]*"`,
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   22,
+						Line:     2,
+						Column:   11,
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   14,
+					Line:     2,
+					Column:   1,
+				},
+			},
+			expectedString: `RemoveDecl <program.code:2:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   14,
+				Line:     2,
+				Column:   1,
+			},
+			expectedChildren: []Node{
+				&RegexTokenDecl{
+					Name:  "STR",
+					Regex: `"[^"]*"`,
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   22,
+						Line:     2,
+						Column:   11,
+					},
+				},
+			},
+			equalTests: []EqualTest{
+				{
+					rhs: &RemoveDecl{
+						Decl: &RegexTokenDecl{
+							Name:  "STR",
+							Regex: `'[^']*'`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   22,
+								Line:     2,
+								Column:   11,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &RemoveDecl{
+						Decl: &RegexTokenDecl{
+							Name:  "STR",
+							Regex: `"[^"]*"`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   22,
+								Line:     2,
+								Column:   11,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   14,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			children := tc.n.Children()
+			assert.Len(t, children, len(tc.expectedChildren))
+			for i := range children {
+				assert.True(t, children[i].Equal(tc.expectedChildren[i]))
+			}
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestWhitespaceDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *WhitespaceDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &WhitespaceDecl{
+				Chars: []string{" ", `\t`},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `WhitespaceDecl::[  \t] <program.code:1:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &WhitespaceDecl{
+						Chars: []string{" "},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &WhitespaceDecl{
+						Chars: []string{" ", `\n`},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &WhitespaceDecl{
+						Chars: []string{" ", `\t`},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestIndentDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *IndentDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &IndentDecl{
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `IndentDecl <program.code:1:1>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &IndentDecl{
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   10,
+							Line:     2,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &IndentDecl{
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+			assert.Len(t, tc.n.Children(), 0)
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestTerminalHandle(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *TerminalHandle
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &TerminalHandle{
+				Terminal: "*",
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   7,
+					Line:     1,
+					Column:   8,
+				},
+			},
+			expectedString: `TerminalHandle::* <program.code:1:8>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   7,
+				Line:     1,
+				Column:   8,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &TerminalHandle{
+						Terminal: "*",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   7,
+							Line:     1,
+							Column:   8,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.leaf()
+			tc.n.precedenceHandle()
+		})
+	}
+}
+
+func TestProductionHandle(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *ProductionHandle
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &ProductionHandle{
+				LHS: "expr",
+				RHS: &ConcatRHS{
+					Ops: []RHS{
+						&NonTerminalRHS{
+							NonTerminal: "expr",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   15,
+								Line:     1,
+								Column:   16,
+							},
+						},
+						&TerminalRHS{
+							Terminal: "*",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   21,
+								Line:     1,
+								Column:   22,
+							},
+						},
+						&NonTerminalRHS{
+							NonTerminal: "expr",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   25,
+								Line:     1,
+								Column:   26,
+							},
+						},
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   7,
+					Line:     1,
+					Column:   8,
+				},
+			},
+			expectedString: `ProductionHandle::expr → ConcatRHS::NonTerminalRHS::expr <program.code:1:16> TerminalRHS::* <program.code:1:22> NonTerminalRHS::expr <program.code:1:26>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   7,
+				Line:     1,
+				Column:   8,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &ProductionHandle{
+						LHS: "expr",
+						RHS: &ConcatRHS{
+							Ops: []RHS{
+								&NonTerminalRHS{
+									NonTerminal: "expr",
+									Position: &lexer.Position{
+										Filename: "program.code",
+										Offset:   15,
+										Line:     1,
+										Column:   16,
+									},
+								},
+								&TerminalRHS{
+									Terminal: "*",
+									Position: &lexer.Position{
+										Filename: "program.code",
+										Offset:   21,
+										Line:     1,
+										Column:   22,
+									},
+								},
+								&NonTerminalRHS{
+									NonTerminal: "expr",
+									Position: &lexer.Position{
+										Filename: "program.code",
+										Offset:   25,
+										Line:     1,
+										Column:   26,
+									},
+								},
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   7,
+							Line:     1,
+							Column:   8,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			expectedChildren := []Node{tc.n.RHS}
+			assert.Equal(t, expectedChildren, tc.n.Children())
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.precedenceHandle()
+		})
+	}
+}
+
+func TestRuleDecl(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *RuleDecl
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &RuleDecl{
+				LHS: "expr",
+				RHS: &ConcatRHS{
+					Ops: []RHS{
+						&NonTerminalRHS{
+							NonTerminal: "expr",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   9,
+								Line:     1,
+								Column:   10,
+							},
+						},
+						&TerminalRHS{
+							Terminal: "+",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   15,
+								Line:     1,
+								Column:   16,
+							},
+						},
+						&NonTerminalRHS{
+							NonTerminal: "expr",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   19,
+								Line:     1,
+								Column:   20,
+							},
+						},
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `RuleDecl::expr → ConcatRHS::NonTerminalRHS::expr <program.code:1:10> TerminalRHS::+ <program.code:1:16> NonTerminalRHS::expr <program.code:1:20>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &RuleDecl{
+						LHS: "expr",
+						RHS: &ConcatRHS{
+							Ops: []RHS{
+								&NonTerminalRHS{
+									NonTerminal: "expr",
+									Position: &lexer.Position{
+										Filename: "program.code",
+										Offset:   9,
+										Line:     1,
+										Column:   10,
+									},
+								},
+								&TerminalRHS{
+									Terminal: "+",
+									Position: &lexer.Position{
+										Filename: "program.code",
+										Offset:   15,
+										Line:     1,
+										Column:   16,
+									},
+								},
+								&NonTerminalRHS{
+									NonTerminal: "expr",
+									Position: &lexer.Position{
+										Filename: "program.code",
+										Offset:   19,
+										Line:     1,
+										Column:   20,
+									},
+								},
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+		{
+			name: "Parameterized",
+			n: &RuleDecl{
+				LHS:    "list",
+				Params: []string{"X", "S"},
+				RHS: &ConcatRHS{
+					Ops: []RHS{
+						&TerminalRHS{
+							Terminal: "X",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   13,
+								Line:     1,
+								Column:   14,
+							},
+						},
+						&StarRHS{
+							Op: &ConcatRHS{
+								Ops: []RHS{
+									&TerminalRHS{
+										Terminal: "S",
+										Position: &lexer.Position{
+											Filename: "program.code",
+											Offset:   16,
+											Line:     1,
+											Column:   17,
+										},
+									},
+									&TerminalRHS{
+										Terminal: "X",
+										Position: &lexer.Position{
+											Filename: "program.code",
+											Offset:   18,
+											Line:     1,
+											Column:   19,
+										},
+									},
+								},
+							},
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   15,
+								Line:     1,
+								Column:   16,
+							},
+						},
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   0,
+					Line:     1,
+					Column:   1,
+				},
+			},
+			expectedString: `RuleDecl::list<X, S> → ConcatRHS::TerminalRHS::X <program.code:1:14> StarRHS::ConcatRHS::TerminalRHS::S <program.code:1:17> TerminalRHS::X <program.code:1:19>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   0,
+				Line:     1,
+				Column:   1,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &RuleDecl{
+						LHS:    "list",
+						Params: []string{"X"},
+						RHS: &TerminalRHS{
+							Terminal: "X",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   13,
+								Line:     1,
+								Column:   14,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			expectedChildren := []Node{tc.n.RHS}
+			assert.Equal(t, expectedChildren, tc.n.Children())
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.decl()
+		})
+	}
+}
+
+func TestConcatRHS(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *ConcatRHS
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &ConcatRHS{
+				Ops: []RHS{
+					&TerminalRHS{
+						Terminal: "PROGRAM",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   9,
+							Line:     1,
+							Column:   10,
+						},
+					},
+					&TerminalRHS{
+						Terminal: "ID",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   19,
+							Line:     1,
+							Column:   20,
+						},
+					},
+				},
+			},
+			expectedString: `ConcatRHS::TerminalRHS::PROGRAM <program.code:1:10> TerminalRHS::ID <program.code:1:20>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   9,
+				Line:     1,
+				Column:   10,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &ConcatRHS{
+						Ops: []RHS{
+							&TerminalRHS{
+								Terminal: "ID",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   19,
+									Line:     1,
+									Column:   20,
+								},
+							},
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ConcatRHS{
+						Ops: []RHS{
+							&TerminalRHS{
+								Terminal: "PROG",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   9,
+									Line:     1,
+									Column:   10,
+								},
+							},
+							&TerminalRHS{
+								Terminal: "ID",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   19,
+									Line:     1,
+									Column:   20,
+								},
+							},
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ConcatRHS{
+						Ops: []RHS{
+							&TerminalRHS{
+								Terminal: "PROGRAM",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   9,
+									Line:     1,
+									Column:   10,
+								},
+							},
+							&TerminalRHS{
+								Terminal: "ID",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   19,
+									Line:     1,
+									Column:   20,
+								},
+							},
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			for i, child := range tc.n.Children() {
+				assert.Equal(t, tc.n.Ops[i], child)
+			}
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.rhs()
+		})
+	}
+}
+
+func TestAltRHS(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *AltRHS
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &AltRHS{
+				Ops: []RHS{
+					&TerminalRHS{
+						Terminal: "*",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   9,
+							Line:     1,
+							Column:   10,
+						},
+					},
+					&TerminalRHS{
+						Terminal: "+",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   15,
+							Line:     1,
+							Column:   16,
+						},
+					},
+				},
+			},
+			expectedString: `AltRHS::TerminalRHS::* <program.code:1:10> | TerminalRHS::+ <program.code:1:16>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   9,
+				Line:     1,
+				Column:   10,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &AltRHS{
+						Ops: []RHS{
+							&TerminalRHS{
+								Terminal: "*",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   9,
+									Line:     1,
+									Column:   10,
+								},
+							},
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &AltRHS{
+						Ops: []RHS{
+							&TerminalRHS{
+								Terminal: "*",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   9,
+									Line:     1,
+									Column:   10,
+								},
+							},
+							&TerminalRHS{
+								Terminal: "-",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   15,
+									Line:     1,
+									Column:   16,
+								},
+							},
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &AltRHS{
+						Ops: []RHS{
+							&TerminalRHS{
+								Terminal: "*",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   9,
+									Line:     1,
+									Column:   10,
+								},
+							},
+							&TerminalRHS{
+								Terminal: "+",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   15,
+									Line:     1,
+									Column:   16,
+								},
+							},
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			for i, child := range tc.n.Children() {
+				assert.Equal(t, tc.n.Ops[i], child)
+			}
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.rhs()
+		})
+	}
+}
+
+func TestOptRHS(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *OptRHS
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &OptRHS{
+				Op: &NonTerminalRHS{
+					NonTerminal: "decl",
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   7,
+						Line:     1,
+						Column:   8,
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   6,
+					Line:     1,
+					Column:   7,
+				},
+			},
+			expectedString: `OptRHS::NonTerminalRHS::decl <program.code:1:8>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   6,
+				Line:     1,
+				Column:   7,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &OptRHS{
+						Op: &NonTerminalRHS{
+							NonTerminal: "decl",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   6,
+							Line:     1,
+							Column:   7,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			expectedChildren := []Node{tc.n.Op}
+			assert.Equal(t, expectedChildren, tc.n.Children())
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.rhs()
+		})
+	}
+}
+
+func TestStarRHS(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *StarRHS
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &StarRHS{
+				Op: &NonTerminalRHS{
+					NonTerminal: "decl",
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   7,
+						Line:     1,
+						Column:   8,
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   6,
+					Line:     1,
+					Column:   7,
+				},
+			},
+			expectedString: `StarRHS::NonTerminalRHS::decl <program.code:1:8>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   6,
+				Line:     1,
+				Column:   7,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
 					expected: false,
 				},
 				{
-					rhs: &ConcatRHS{
-						Ops: []RHS{
-							&TerminalRHS{
-								Terminal: "PROGRAM",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   9,
-									Line:     1,
-									Column:   10,
-								},
-							},
-							&TerminalRHS{
-								Terminal: "ID",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   19,
-									Line:     1,
-									Column:   20,
-								},
+					rhs: &StarRHS{
+						Op: &NonTerminalRHS{
+							NonTerminal: "decl",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
 							},
 						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   6,
+							Line:     1,
+							Column:   7,
+						},
 					},
 					expected: true,
 				},
@@ -1790,10 +3474,89 @@ func TestConcatRHS(t *testing.T) {
 			assert.Equal(t, tc.expectedString, tc.n.String())
 			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
 
-			for i, child := range tc.n.Children() {
-				assert.Equal(t, tc.n.Ops[i], child)
+			expectedChildren := []Node{tc.n.Op}
+			assert.Equal(t, expectedChildren, tc.n.Children())
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
 			}
 
+			tc.n.rhs()
+		})
+	}
+}
+
+func TestPlusRHS(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *PlusRHS
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &PlusRHS{
+				Op: &NonTerminalRHS{
+					NonTerminal: "decl",
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   7,
+						Line:     1,
+						Column:   8,
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   6,
+					Line:     1,
+					Column:   7,
+				},
+			},
+			expectedString: `PlusRHS::NonTerminalRHS::decl <program.code:1:8>`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   6,
+				Line:     1,
+				Column:   7,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &PlusRHS{
+						Op: &NonTerminalRHS{
+							NonTerminal: "decl",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   6,
+							Line:     1,
+							Column:   7,
+						},
+					},
+					expected: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			expectedChildren := []Node{tc.n.Op}
+			assert.Equal(t, expectedChildren, tc.n.Children())
+
 			for _, equalTest := range tc.equalTests {
 				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
 			}
@@ -1803,44 +3566,212 @@ func TestConcatRHS(t *testing.T) {
 	}
 }
 
-func TestAltRHS(t *testing.T) {
+func TestListRHS(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *AltRHS
+		n              *ListRHS
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &AltRHS{
-				Ops: []RHS{
-					&TerminalRHS{
-						Terminal: "*",
+			n: &ListRHS{
+				Op: &NonTerminalRHS{
+					NonTerminal: "arg",
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   7,
+						Line:     1,
+						Column:   8,
+					},
+				},
+				Sep: &TerminalRHS{
+					Terminal: `","`,
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   13,
+						Line:     1,
+						Column:   14,
+					},
+				},
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   6,
+					Line:     1,
+					Column:   7,
+				},
+			},
+			expectedString: `ListRHS::{NonTerminalRHS::arg <program.code:1:8> % TerminalRHS::"," <program.code:1:14>}`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   6,
+				Line:     1,
+				Column:   7,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+				{
+					rhs: &ListRHS{
+						Op: &NonTerminalRHS{
+							NonTerminal: "arg",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
+							},
+						},
+						Sep: &TerminalRHS{
+							Terminal: `","`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   13,
+								Line:     1,
+								Column:   14,
+							},
+						},
+						Trailing: true,
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   9,
+							Offset:   6,
 							Line:     1,
-							Column:   10,
+							Column:   7,
 						},
 					},
-					&TerminalRHS{
-						Terminal: "+",
+					expected: false,
+				},
+				{
+					rhs: &ListRHS{
+						Op: &NonTerminalRHS{
+							NonTerminal: "arg",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
+							},
+						},
+						Sep: &TerminalRHS{
+							Terminal: `","`,
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   13,
+								Line:     1,
+								Column:   14,
+							},
+						},
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   15,
+							Offset:   6,
 							Line:     1,
-							Column:   16,
+							Column:   7,
 						},
 					},
+					expected: true,
+				},
+			},
+		},
+		{
+			name: "PlusWithTrailing",
+			n: &ListRHS{
+				Op: &NonTerminalRHS{
+					NonTerminal: "arg",
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   8,
+						Line:     1,
+						Column:   9,
+					},
+				},
+				Sep: &TerminalRHS{
+					Terminal: `","`,
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   15,
+						Line:     1,
+						Column:   16,
+					},
+				},
+				Plus:     true,
+				Trailing: true,
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   6,
+					Line:     1,
+					Column:   7,
+				},
+			},
+			expectedString: `ListRHS::{{NonTerminalRHS::arg <program.code:1:9> %% TerminalRHS::"," <program.code:1:16>}}`,
+			expectedPos: &lexer.Position{
+				Filename: "program.code",
+				Offset:   6,
+				Line:     1,
+				Column:   7,
+			},
+			equalTests: []EqualTest{
+				{
+					rhs:      nil,
+					expected: false,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
+
+			expectedChildren := []Node{tc.n.Op, tc.n.Sep}
+			assert.Equal(t, expectedChildren, tc.n.Children())
+
+			for _, equalTest := range tc.equalTests {
+				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
+			}
+
+			tc.n.rhs()
+		})
+	}
+}
+
+func TestLabelRHS(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              *LabelRHS
+		expectedString string
+		expectedPos    *lexer.Position
+		equalTests     []EqualTest
+	}{
+		{
+			name: "OK",
+			n: &LabelRHS{
+				Op: &TerminalRHS{
+					Terminal: "NUM",
+					Position: &lexer.Position{
+						Filename: "program.code",
+						Offset:   7,
+						Line:     1,
+						Column:   8,
+					},
+				},
+				Label: "Num",
+				Position: &lexer.Position{
+					Filename: "program.code",
+					Offset:   11,
+					Line:     1,
+					Column:   12,
 				},
 			},
-			expectedString: `AltRHS::TerminalRHS::* <program.code:1:10> | TerminalRHS::+ <program.code:1:16>`,
+			expectedString: `LabelRHS::TerminalRHS::NUM <program.code:1:8> #Num`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   9,
+				Offset:   11,
 				Line:     1,
-				Column:   10,
+				Column:   12,
 			},
 			equalTests: []EqualTest{
 				{
@@ -1848,68 +3779,44 @@ func TestAltRHS(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &AltRHS{
-						Ops: []RHS{
-							&TerminalRHS{
-								Terminal: "*",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   9,
-									Line:     1,
-									Column:   10,
-								},
+					rhs: &LabelRHS{
+						Op: &TerminalRHS{
+							Terminal: "NUM",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
 							},
 						},
-					},
-					expected: false,
-				},
-				{
-					rhs: &AltRHS{
-						Ops: []RHS{
-							&TerminalRHS{
-								Terminal: "*",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   9,
-									Line:     1,
-									Column:   10,
-								},
-							},
-							&TerminalRHS{
-								Terminal: "-",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   15,
-									Line:     1,
-									Column:   16,
-								},
-							},
+						Label: "Int",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   11,
+							Line:     1,
+							Column:   12,
 						},
 					},
 					expected: false,
 				},
 				{
-					rhs: &AltRHS{
-						Ops: []RHS{
-							&TerminalRHS{
-								Terminal: "*",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   9,
-									Line:     1,
-									Column:   10,
-								},
-							},
-							&TerminalRHS{
-								Terminal: "+",
-								Position: &lexer.Position{
-									Filename: "program.code",
-									Offset:   15,
-									Line:     1,
-									Column:   16,
-								},
+					rhs: &LabelRHS{
+						Op: &TerminalRHS{
+							Terminal: "NUM",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
 							},
 						},
+						Label: "Num",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   11,
+							Line:     1,
+							Column:   12,
+						},
 					},
 					expected: true,
 				},
@@ -1922,9 +3829,8 @@ func TestAltRHS(t *testing.T) {
 			assert.Equal(t, tc.expectedString, tc.n.String())
 			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
 
-			for i, child := range tc.n.Children() {
-				assert.Equal(t, tc.n.Ops[i], child)
-			}
+			expectedChildren := []Node{tc.n.Op}
+			assert.Equal(t, expectedChildren, tc.n.Children())
 
 			for _, equalTest := range tc.equalTests {
 				assert.Equal(t, equalTest.expected, tc.n.Equal(equalTest.rhs))
@@ -1935,19 +3841,19 @@ func TestAltRHS(t *testing.T) {
 	}
 }
 
-func TestOptRHS(t *testing.T) {
+func TestActionRHS(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *OptRHS
+		n              *ActionRHS
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &OptRHS{
-				Op: &NonTerminalRHS{
-					NonTerminal: "decl",
+			n: &ActionRHS{
+				Op: &TerminalRHS{
+					Terminal: "NUM",
 					Position: &lexer.Position{
 						Filename: "program.code",
 						Offset:   7,
@@ -1955,19 +3861,20 @@ func TestOptRHS(t *testing.T) {
 						Column:   8,
 					},
 				},
+				Code: " $$ = $1 ",
 				Position: &lexer.Position{
 					Filename: "program.code",
-					Offset:   6,
+					Offset:   11,
 					Line:     1,
-					Column:   7,
+					Column:   12,
 				},
 			},
-			expectedString: `OptRHS::NonTerminalRHS::decl <program.code:1:8>`,
+			expectedString: `ActionRHS::TerminalRHS::NUM <program.code:1:8> {% $$ = $1 %}`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   6,
+				Offset:   11,
 				Line:     1,
-				Column:   7,
+				Column:   12,
 			},
 			equalTests: []EqualTest{
 				{
@@ -1975,9 +3882,9 @@ func TestOptRHS(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &OptRHS{
-						Op: &NonTerminalRHS{
-							NonTerminal: "decl",
+					rhs: &ActionRHS{
+						Op: &TerminalRHS{
+							Terminal: "NUM",
 							Position: &lexer.Position{
 								Filename: "program.code",
 								Offset:   7,
@@ -1985,11 +3892,33 @@ func TestOptRHS(t *testing.T) {
 								Column:   8,
 							},
 						},
+						Code: " $$ = nil ",
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   6,
+							Offset:   11,
 							Line:     1,
-							Column:   7,
+							Column:   12,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &ActionRHS{
+						Op: &TerminalRHS{
+							Terminal: "NUM",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
+							},
+						},
+						Code: " $$ = $1 ",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   11,
+							Line:     1,
+							Column:   12,
 						},
 					},
 					expected: true,
@@ -2015,19 +3944,20 @@ func TestOptRHS(t *testing.T) {
 	}
 }
 
-func TestStarRHS(t *testing.T) {
+func TestFieldRHS(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *StarRHS
+		n              *FieldRHS
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &StarRHS{
-				Op: &NonTerminalRHS{
-					NonTerminal: "decl",
+			n: &FieldRHS{
+				Field: "value",
+				Op: &TerminalRHS{
+					Terminal: "NUM",
 					Position: &lexer.Position{
 						Filename: "program.code",
 						Offset:   7,
@@ -2037,17 +3967,17 @@ func TestStarRHS(t *testing.T) {
 				},
 				Position: &lexer.Position{
 					Filename: "program.code",
-					Offset:   6,
+					Offset:   1,
 					Line:     1,
-					Column:   7,
+					Column:   2,
 				},
 			},
-			expectedString: `StarRHS::NonTerminalRHS::decl <program.code:1:8>`,
+			expectedString: `FieldRHS::value:TerminalRHS::NUM <program.code:1:8>`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   6,
+				Offset:   1,
 				Line:     1,
-				Column:   7,
+				Column:   2,
 			},
 			equalTests: []EqualTest{
 				{
@@ -2055,9 +3985,10 @@ func TestStarRHS(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &StarRHS{
-						Op: &NonTerminalRHS{
-							NonTerminal: "decl",
+					rhs: &FieldRHS{
+						Field: "num",
+						Op: &TerminalRHS{
+							Terminal: "NUM",
 							Position: &lexer.Position{
 								Filename: "program.code",
 								Offset:   7,
@@ -2067,9 +3998,30 @@ func TestStarRHS(t *testing.T) {
 						},
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   6,
+							Offset:   1,
 							Line:     1,
-							Column:   7,
+							Column:   2,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &FieldRHS{
+						Field: "value",
+						Op: &TerminalRHS{
+							Terminal: "NUM",
+							Position: &lexer.Position{
+								Filename: "program.code",
+								Offset:   7,
+								Line:     1,
+								Column:   8,
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   1,
+							Line:     1,
+							Column:   2,
 						},
 					},
 					expected: true,
@@ -2095,39 +4047,51 @@ func TestStarRHS(t *testing.T) {
 	}
 }
 
-func TestPlusRHS(t *testing.T) {
+func TestInstanceRHS(t *testing.T) {
 	tests := []struct {
 		name           string
-		n              *PlusRHS
+		n              *InstanceRHS
 		expectedString string
 		expectedPos    *lexer.Position
 		equalTests     []EqualTest
 	}{
 		{
 			name: "OK",
-			n: &PlusRHS{
-				Op: &NonTerminalRHS{
-					NonTerminal: "decl",
-					Position: &lexer.Position{
-						Filename: "program.code",
-						Offset:   7,
-						Line:     1,
-						Column:   8,
+			n: &InstanceRHS{
+				NonTerminal: "pair",
+				Args: []RHS{
+					&NonTerminalRHS{
+						NonTerminal: "key",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   5,
+							Line:     1,
+							Column:   6,
+						},
+					},
+					&TerminalRHS{
+						Terminal: "ID",
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   10,
+							Line:     1,
+							Column:   11,
+						},
 					},
 				},
 				Position: &lexer.Position{
 					Filename: "program.code",
-					Offset:   6,
+					Offset:   0,
 					Line:     1,
-					Column:   7,
+					Column:   1,
 				},
 			},
-			expectedString: `PlusRHS::NonTerminalRHS::decl <program.code:1:8>`,
+			expectedString: `InstanceRHS::pair<NonTerminalRHS::key <program.code:1:6>, TerminalRHS::ID <program.code:1:11>>`,
 			expectedPos: &lexer.Position{
 				Filename: "program.code",
-				Offset:   6,
+				Offset:   0,
 				Line:     1,
-				Column:   7,
+				Column:   1,
 			},
 			equalTests: []EqualTest{
 				{
@@ -2135,21 +4099,88 @@ func TestPlusRHS(t *testing.T) {
 					expected: false,
 				},
 				{
-					rhs: &PlusRHS{
-						Op: &NonTerminalRHS{
-							NonTerminal: "decl",
-							Position: &lexer.Position{
-								Filename: "program.code",
-								Offset:   7,
-								Line:     1,
-								Column:   8,
+					rhs: &InstanceRHS{
+						NonTerminal: "pair",
+						Args: []RHS{
+							&NonTerminalRHS{
+								NonTerminal: "key",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   5,
+									Line:     1,
+									Column:   6,
+								},
 							},
 						},
 						Position: &lexer.Position{
 							Filename: "program.code",
-							Offset:   6,
+							Offset:   0,
 							Line:     1,
-							Column:   7,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &InstanceRHS{
+						NonTerminal: "pair",
+						Args: []RHS{
+							&NonTerminalRHS{
+								NonTerminal: "key",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   5,
+									Line:     1,
+									Column:   6,
+								},
+							},
+							&TerminalRHS{
+								Terminal: "NUM",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   10,
+									Line:     1,
+									Column:   11,
+								},
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
+						},
+					},
+					expected: false,
+				},
+				{
+					rhs: &InstanceRHS{
+						NonTerminal: "pair",
+						Args: []RHS{
+							&NonTerminalRHS{
+								NonTerminal: "key",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   5,
+									Line:     1,
+									Column:   6,
+								},
+							},
+							&TerminalRHS{
+								Terminal: "ID",
+								Position: &lexer.Position{
+									Filename: "program.code",
+									Offset:   10,
+									Line:     1,
+									Column:   11,
+								},
+							},
+						},
+						Position: &lexer.Position{
+							Filename: "program.code",
+							Offset:   0,
+							Line:     1,
+							Column:   1,
 						},
 					},
 					expected: true,
@@ -2163,7 +4194,7 @@ func TestPlusRHS(t *testing.T) {
 			assert.Equal(t, tc.expectedString, tc.n.String())
 			assert.True(t, equalPositions(tc.expectedPos, tc.n.Pos()))
 
-			expectedChildren := []Node{tc.n.Op}
+			expectedChildren := []Node{tc.n.Args[0], tc.n.Args[1]}
 			assert.Equal(t, expectedChildren, tc.n.Children())
 
 			for _, equalTest := range tc.equalTests {
@@ -3,6 +3,7 @@ package ast
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/moorara/algo/parser/lr"
 
@@ -25,6 +26,401 @@ func Parse(filename string, src io.Reader) (*Grammar, error) {
 
 	res, err := p.ParseAndEvaluate(func(i int, rhs []*lr.Value) (any, error) {
 		switch i {
+		// externals → TOKEN
+		case 90:
+			return []string{rhs[0].Val.(string)}, nil
+
+		// externals → externals TOKEN
+		case 89:
+			tokens := rhs[0].Val.([]string)
+			return append(tokens, rhs[1].Val.(string)), nil
+
+		// directive → "@external" externals
+		case 88:
+			return &ExternalDecl{
+				Tokens:   rhs[1].Val.([]string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "@remove" TOKEN semi_opt
+		case 87:
+			return &RemoveDecl{
+				Name:     rhs[1].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "@remove" IDENT semi_opt
+		case 86:
+			return &RemoveDecl{
+				Name:     rhs[1].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "@remove" directive semi_opt
+		case 85:
+			return &RemoveDecl{
+				Decl:     rhs[1].Val.(Decl),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "@remove" rule ";"
+		case 84:
+			return &RemoveDecl{
+				Decl:     rhs[1].Val.(Decl),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "@override" directive semi_opt
+		case 83:
+			return &OverrideDecl{
+				Decl:     rhs[1].Val.(Decl),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "@override" token semi_opt
+		case 82:
+			return &OverrideDecl{
+				Decl:     rhs[1].Val.(Decl),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// override → "@override"
+		case 81:
+			// Discard
+			return nil, nil
+
+		// decl → override rule ";"
+		case 80:
+			return &OverrideDecl{
+				Decl:     rhs[1].Val.(Decl),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → "import" STRING semi_opt
+		case 79:
+			return &ImportDecl{
+				Path:     rhs[1].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → CODE semi_opt
+		case 78:
+			return &CodeDecl{
+				Code:     rhs[0].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// rule → lhs "=" CODE
+		case 77:
+			rule := newRuleDecl(rhs[0])
+			rule.RHS = &ActionRHS{
+				Op:       &EmptyRHS{},
+				Code:     rhs[2].Val.(string),
+				Position: rhs[2].Pos,
+			}
+
+			return rule, nil
+
+		// rhs → rhs "|" CODE
+		case 76:
+			var ops []RHS
+
+			if c, ok := rhs[0].Val.(*AltRHS); ok {
+				ops = append(ops, c.Ops...)
+			} else {
+				ops = append(ops, rhs[0].Val.(RHS))
+			}
+
+			ops = append(ops, &ActionRHS{
+				Op:       &EmptyRHS{},
+				Code:     rhs[2].Val.(string),
+				Position: rhs[2].Pos,
+			})
+
+			return &AltRHS{
+				Ops: ops,
+			}, nil
+
+		// rhs → rhs CODE
+		case 75:
+			return &ActionRHS{
+				Op:       rhs[0].Val.(RHS),
+				Code:     rhs[1].Val.(string),
+				Position: rhs[1].Pos,
+			}, nil
+
+		// args → rhs
+		case 74:
+			return []RHS{rhs[0].Val.(RHS)}, nil
+
+		// args → args "," rhs
+		case 73:
+			args := rhs[0].Val.([]RHS)
+			return append(args, rhs[2].Val.(RHS)), nil
+
+		// rhs → nonterm "<" args ">"
+		case 72:
+			return &InstanceRHS{
+				NonTerminal: rhs[0].Val.(string),
+				Args:        rhs[2].Val.([]RHS),
+				Position:    rhs[0].Pos,
+			}, nil
+
+		// params → TOKEN
+		case 71:
+			return []string{rhs[0].Val.(string)}, nil
+
+		// params → params "," TOKEN
+		case 70:
+			params := rhs[0].Val.([]string)
+			return append(params, rhs[2].Val.(string)), nil
+
+		// lhs → nonterm "<" params ">"
+		case 69:
+			return &RuleDecl{
+				LHS:      rhs[0].Val.(string),
+				Params:   rhs[2].Val.([]string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// rhs → "{{" rhs "%%" rhs "}}"
+		case 68:
+			return &ListRHS{
+				Op:       rhs[1].Val.(RHS),
+				Sep:      rhs[3].Val.(RHS),
+				Plus:     true,
+				Trailing: true,
+				Position: rhs[0].Pos,
+			}, nil
+
+		// rhs → "{{" rhs "%" rhs "}}"
+		case 67:
+			return &ListRHS{
+				Op:       rhs[1].Val.(RHS),
+				Sep:      rhs[3].Val.(RHS),
+				Plus:     true,
+				Position: rhs[0].Pos,
+			}, nil
+
+		// rhs → "{" rhs "%%" rhs "}"
+		case 66:
+			return &ListRHS{
+				Op:       rhs[1].Val.(RHS),
+				Sep:      rhs[3].Val.(RHS),
+				Trailing: true,
+				Position: rhs[0].Pos,
+			}, nil
+
+		// rhs → "{" rhs "%" rhs "}"
+		case 65:
+			return &ListRHS{
+				Op:       rhs[1].Val.(RHS),
+				Sep:      rhs[3].Val.(RHS),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// starts → IDENT
+		case 64:
+			return []string{rhs[0].Val.(string)}, nil
+
+		// starts → starts IDENT
+		case 63:
+			nonTerminals := rhs[0].Val.([]string)
+			return append(nonTerminals, rhs[1].Val.(string)), nil
+
+		// directive → "@start" starts
+		case 62:
+			return &StartDecl{
+				NonTerminals: rhs[1].Val.([]string),
+				Position:     rhs[0].Pos,
+			}, nil
+
+		// rhs → FIELD rhs
+		case 61:
+			return &FieldRHS{
+				Field:    strings.TrimSuffix(rhs[0].Val.(string), ":"),
+				Op:       rhs[1].Val.(RHS),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// rhs → rhs LABEL
+		case 60:
+			return &LabelRHS{
+				Op:       rhs[0].Val.(RHS),
+				Label:    strings.TrimPrefix(rhs[1].Val.(string), "#"),
+				Position: rhs[1].Pos,
+			}, nil
+
+		// fragment → "@fragment" TOKEN "=" REGEX
+		case 59:
+			return &FragmentDecl{
+				Name:     rhs[1].Val.(string),
+				Regex:    rhs[3].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → fragment semi_opt
+		case 58:
+			return rhs[0].Val, nil
+
+		// priorities → TOKEN
+		case 57:
+			return []string{rhs[0].Val.(string)}, nil
+
+		// priorities → priorities TOKEN
+		case 56:
+			tokens := rhs[0].Val.([]string)
+			return append(tokens, rhs[1].Val.(string)), nil
+
+		// directive → "@priority" priorities
+		case 55:
+			return &PriorityDecl{
+				Tokens:   rhs[1].Val.([]string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// term → ISTRING
+		case 54:
+			return fmt.Sprintf("%qi", rhs[0].Val), nil
+
+		// token → TOKEN "=" ISTRING action
+		case 53:
+			return &StringTokenDecl{
+				Name:       rhs[0].Val.(string),
+				Value:      rhs[2].Val.(string),
+				IgnoreCase: true,
+				Action:     rhs[3].Val.(string),
+				Position:   rhs[0].Pos,
+			}, nil
+
+		// token → TOKEN "=" ISTRING
+		case 52:
+			return &StringTokenDecl{
+				Name:       rhs[0].Val.(string),
+				Value:      rhs[2].Val.(string),
+				IgnoreCase: true,
+				Position:   rhs[0].Pos,
+			}, nil
+
+		// directive → "@indent"
+		case 51:
+			return &IndentDecl{
+				Position: rhs[0].Pos,
+			}, nil
+
+		// chars → ε
+		case 50:
+			return []string{}, nil
+
+		// chars → chars STRING
+		case 49:
+			chars := rhs[0].Val.([]string)
+			return append(chars, rhs[1].Val.(string)), nil
+
+		// directive → "@whitespace" chars
+		case 48:
+			return &WhitespaceDecl{
+				Chars:    rhs[1].Val.([]string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// skips → TOKEN
+		case 47:
+			return []string{rhs[0].Val.(string)}, nil
+
+		// skips → skips TOKEN
+		case 46:
+			tokens := rhs[0].Val.([]string)
+			return append(tokens, rhs[1].Val.(string)), nil
+
+		// directive → "@skip" skips
+		case 45:
+			return &SkipDecl{
+				Tokens:   rhs[1].Val.([]string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// action → "@switch" IDENT
+		case 44:
+			return "@switch " + rhs[1].Val.(string), nil
+
+		// action → "@pop"
+		case 43:
+			return "@pop", nil
+
+		// action → "@push" IDENT
+		case 42:
+			return "@push " + rhs[1].Val.(string), nil
+
+		// token → TOKEN "=" PREDEF action
+		case 41:
+			value := rhs[2].Val.(string)
+
+			regex, ok := parser.Predefs[value]
+			if !ok {
+				return nil, fmt.Errorf("invalid predefined regex: %s", value)
+			}
+
+			return &RegexTokenDecl{
+				Name:     rhs[0].Val.(string),
+				Regex:    regex,
+				Action:   rhs[3].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// token → TOKEN "=" REGEX action
+		case 40:
+			return &RegexTokenDecl{
+				Name:     rhs[0].Val.(string),
+				Regex:    rhs[2].Val.(string),
+				Action:   rhs[3].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// token → TOKEN "=" STRING action
+		case 39:
+			return &StringTokenDecl{
+				Name:     rhs[0].Val.(string),
+				Value:    rhs[2].Val.(string),
+				Action:   rhs[3].Val.(string),
+				Position: rhs[0].Pos,
+			}, nil
+
+		// mode_decls → ε
+		case 38:
+			// Discard
+			return nil, nil
+
+		// mode_decls → mode_decls token semi_opt
+		case 37:
+			var tokens []Decl
+
+			if rhs[0].Val != nil {
+				tokens = rhs[0].Val.([]Decl)
+			}
+
+			tokens = append(tokens, rhs[1].Val.(Decl))
+
+			return tokens, nil
+
+		// mode → "@mode" IDENT "{" mode_decls "}"
+		case 36:
+			var tokens []Decl
+
+			if rhs[3].Val != nil {
+				tokens = rhs[3].Val.([]Decl)
+			}
+
+			return &ModeDecl{
+				Name:     rhs[1].Val.(string),
+				Tokens:   tokens,
+				Position: rhs[0].Pos,
+			}, nil
+
+		// decl → mode
+		case 35:
+			return rhs[0].Val, nil
+
 		// term → STRING
 		case 34:
 			return fmt.Sprintf("%q", rhs[0].Val), nil
@@ -138,19 +534,17 @@ func Parse(filename string, src io.Reader) (*Grammar, error) {
 
 		// rule → lhs "="
 		case 21:
-			return &RuleDecl{
-				LHS:      rhs[0].Val.(string),
-				RHS:      &EmptyRHS{},
-				Position: rhs[0].Pos,
-			}, nil
+			rule := newRuleDecl(rhs[0])
+			rule.RHS = &EmptyRHS{}
+
+			return rule, nil
 
 		// rule → lhs "=" rhs
 		case 20:
-			return &RuleDecl{
-				LHS:      rhs[0].Val.(string),
-				RHS:      rhs[2].Val.(RHS),
-				Position: rhs[0].Pos,
-			}, nil
+			rule := newRuleDecl(rhs[0])
+			rule.RHS = rhs[2].Val.(RHS)
+
+			return rule, nil
 
 		// rule_handle → "<" rule ">"
 		case 19:
@@ -316,3 +710,16 @@ func Parse(filename string, src io.Reader) (*Grammar, error) {
 
 	return res.Val.(*Grammar), nil
 }
+
+// newRuleDecl creates a rule declaration for the evaluated left-hand side of a rule.
+// The left-hand side of a parameterized rule is already evaluated to a rule declaration without a right-hand side.
+func newRuleDecl(lhs *lr.Value) *RuleDecl {
+	if rule, ok := lhs.Val.(*RuleDecl); ok {
+		return rule
+	}
+
+	return &RuleDecl{
+		LHS:      lhs.Val.(string),
+		Position: lhs.Pos,
+	}
+}
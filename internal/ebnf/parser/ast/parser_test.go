@@ -25,6 +25,91 @@ func TestParse(t *testing.T) {
 			filename:             "../../fixture/test.success.grammar",
 			expectedErrorStrings: nil,
 		},
+		{
+			name:                 "SuccessWithModes",
+			filename:             "../../fixture/test.modes.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithSkips",
+			filename:             "../../fixture/test.skip.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithWhitespaces",
+			filename:             "../../fixture/test.whitespace.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithIndent",
+			filename:             "../../fixture/test.indent.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithIgnoreCase",
+			filename:             "../../fixture/test.case.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithPriorities",
+			filename:             "../../fixture/test.priority.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithFragments",
+			filename:             "../../fixture/test.fragment.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithPredefs",
+			filename:             "../../fixture/test.predef.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithLabels",
+			filename:             "../../fixture/test.labels.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithFields",
+			filename:             "../../fixture/test.fields.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithStarts",
+			filename:             "../../fixture/test.starts.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithLists",
+			filename:             "../../fixture/test.lists.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithMacros",
+			filename:             "../../fixture/test.macros.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithActions",
+			filename:             "../../fixture/test.actions.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithImports",
+			filename:             "../../fixture/test.imports.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithDialect",
+			filename:             "../../fixture/test.dialect.grammar",
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "SuccessWithExternals",
+			filename:             "../../fixture/test.external.grammar",
+			expectedErrorStrings: nil,
+		},
 	}
 
 	for _, tc := range tests {
@@ -50,15 +50,15 @@ import (
 var (
 	// terminals is an ordered list of terminal symbols for the EBNF grammar.
 	terminals = []grammar.Terminal{
-		"=", ";", "|", "(", ")", "[", "]", "{", "}", "{{", "}}", "<", ">",
-		"grammar", "@left", "@right", "@none",
-		"IDENT", "TOKEN", "STRING", "REGEX", "PREDEF",
+		"=", ";", "|", "(", ")", "[", "]", "{", "}", "{{", "}}", "<", ">", "%", "%%", ",",
+		"grammar", "@left", "@right", "@none", "@mode", "@push", "@pop", "@switch", "@skip", "@whitespace", "@indent", "@priority", "@fragment", "@start", "import", "@override", "@remove", "@external",
+		"IDENT", "TOKEN", "STRING", "ISTRING", "REGEX", "PREDEF", "LABEL", "FIELD", "CODE",
 	}
 
 	// nonTerminals is an ordered list of non-terminal symbols for the EBNF grammar.
 	nonTerminals = []grammar.NonTerminal{
-		"grammar", "name", "decls", "decl", "semi_opt", "token",
-		"directive", "handles", "rule_handle", "rule", "lhs", "rhs", "nonterm", "term",
+		"grammar", "name", "decls", "decl", "semi_opt", "token", "action", "mode", "mode_decls",
+		"directive", "handles", "rule_handle", "skips", "chars", "priorities", "fragment", "starts", "externals", "params", "args", "override", "rule", "lhs", "rhs", "nonterm", "term",
 	}
 
 	// productions is an ordered list of productions rules for the EBNF grammar.
@@ -98,6 +98,62 @@ var (
 		/* 32: nonterm → IDENT */ {Head: "nonterm", Body: grammar.String[grammar.Symbol]{grammar.Terminal("IDENT")}},
 		/* 33: term → TOKEN */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
 		/* 34: term → STRING */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("STRING")}},
+		/* 35: decl → mode */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("mode")}},
+		/* 36: mode → "@mode" IDENT "{" mode_decls "}" */ {Head: "mode", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@mode"), grammar.Terminal("IDENT"), grammar.Terminal("{"), grammar.NonTerminal("mode_decls"), grammar.Terminal("}")}},
+		/* 37: mode_decls → mode_decls token semi_opt */ {Head: "mode_decls", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("mode_decls"), grammar.NonTerminal("token"), grammar.NonTerminal("semi_opt")}},
+		/* 38: mode_decls → ε */ {Head: "mode_decls", Body: grammar.E},
+		/* 39: token → TOKEN "=" STRING action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("STRING"), grammar.NonTerminal("action")}},
+		/* 40: token → TOKEN "=" REGEX action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("REGEX"), grammar.NonTerminal("action")}},
+		/* 41: token → TOKEN "=" PREDEF action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("PREDEF"), grammar.NonTerminal("action")}},
+		/* 42: action → "@push" IDENT */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@push"), grammar.Terminal("IDENT")}},
+		/* 43: action → "@pop" */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@pop")}},
+		/* 44: action → "@switch" IDENT */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@switch"), grammar.Terminal("IDENT")}},
+		/* 45: directive → "@skip" skips */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@skip"), grammar.NonTerminal("skips")}},
+		/* 46: skips → skips TOKEN */ {Head: "skips", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("skips"), grammar.Terminal("TOKEN")}},
+		/* 47: skips → TOKEN */ {Head: "skips", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 48: directive → "@whitespace" chars */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@whitespace"), grammar.NonTerminal("chars")}},
+		/* 49: chars → chars STRING */ {Head: "chars", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("chars"), grammar.Terminal("STRING")}},
+		/* 50: chars → ε */ {Head: "chars", Body: grammar.E},
+		/* 51: directive → "@indent" */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@indent")}},
+		/* 52: token → TOKEN "=" ISTRING */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("ISTRING")}},
+		/* 53: token → TOKEN "=" ISTRING action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("ISTRING"), grammar.NonTerminal("action")}},
+		/* 54: term → ISTRING */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ISTRING")}},
+		/* 55: directive → "@priority" priorities */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@priority"), grammar.NonTerminal("priorities")}},
+		/* 56: priorities → priorities TOKEN */ {Head: "priorities", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("priorities"), grammar.Terminal("TOKEN")}},
+		/* 57: priorities → TOKEN */ {Head: "priorities", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 58: decl → fragment semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("fragment"), grammar.NonTerminal("semi_opt")}},
+		/* 59: fragment → "@fragment" TOKEN "=" REGEX */ {Head: "fragment", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@fragment"), grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("REGEX")}},
+		/* 60: rhs → rhs LABEL */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("LABEL")}},
+		/* 61: rhs → FIELD rhs */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("FIELD"), grammar.NonTerminal("rhs")}},
+		/* 62: directive → "@start" starts */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@start"), grammar.NonTerminal("starts")}},
+		/* 63: starts → starts IDENT */ {Head: "starts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("starts"), grammar.Terminal("IDENT")}},
+		/* 64: starts → IDENT */ {Head: "starts", Body: grammar.String[grammar.Symbol]{grammar.Terminal("IDENT")}},
+		/* 65: rhs → "{" rhs "%" rhs "}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("rhs"), grammar.Terminal("%"), grammar.NonTerminal("rhs"), grammar.Terminal("}")}},
+		/* 66: rhs → "{" rhs "%%" rhs "}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("rhs"), grammar.Terminal("%%"), grammar.NonTerminal("rhs"), grammar.Terminal("}")}},
+		/* 67: rhs → "{{" rhs "%" rhs "}}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{{"), grammar.NonTerminal("rhs"), grammar.Terminal("%"), grammar.NonTerminal("rhs"), grammar.Terminal("}}")}},
+		/* 68: rhs → "{{" rhs "%%" rhs "}}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{{"), grammar.NonTerminal("rhs"), grammar.Terminal("%%"), grammar.NonTerminal("rhs"), grammar.Terminal("}}")}},
+		/* 69: lhs → nonterm "<" params ">" */ {Head: "lhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("nonterm"), grammar.Terminal("<"), grammar.NonTerminal("params"), grammar.Terminal(">")}},
+		/* 70: params → params "," TOKEN */ {Head: "params", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("params"), grammar.Terminal(","), grammar.Terminal("TOKEN")}},
+		/* 71: params → TOKEN */ {Head: "params", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 72: rhs → nonterm "<" args ">" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("nonterm"), grammar.Terminal("<"), grammar.NonTerminal("args"), grammar.Terminal(">")}},
+		/* 73: args → args "," rhs */ {Head: "args", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("args"), grammar.Terminal(","), grammar.NonTerminal("rhs")}},
+		/* 74: args → rhs */ {Head: "args", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs")}},
+		/* 75: rhs → rhs CODE */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("CODE")}},
+		/* 76: rhs → rhs "|" CODE */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("|"), grammar.Terminal("CODE")}},
+		/* 77: rule → lhs "=" CODE */ {Head: "rule", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("lhs"), grammar.Terminal("="), grammar.Terminal("CODE")}},
+		/* 78: decl → CODE semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("CODE"), grammar.NonTerminal("semi_opt")}},
+		/* 79: decl → "import" STRING semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("import"), grammar.Terminal("STRING"), grammar.NonTerminal("semi_opt")}},
+		/* 80: decl → override rule ";" */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("override"), grammar.NonTerminal("rule"), grammar.Terminal(";")}},
+		/* 81: override → "@override" */ {Head: "override", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override")}},
+		/* 82: decl → "@override" token semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override"), grammar.NonTerminal("token"), grammar.NonTerminal("semi_opt")}},
+		/* 83: decl → "@override" directive semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override"), grammar.NonTerminal("directive"), grammar.NonTerminal("semi_opt")}},
+		/* 84: decl → "@remove" rule ";" */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.NonTerminal("rule"), grammar.Terminal(";")}},
+		/* 85: decl → "@remove" directive semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.NonTerminal("directive"), grammar.NonTerminal("semi_opt")}},
+		/* 86: decl → "@remove" IDENT semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.Terminal("IDENT"), grammar.NonTerminal("semi_opt")}},
+		/* 87: decl → "@remove" TOKEN semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.Terminal("TOKEN"), grammar.NonTerminal("semi_opt")}},
+		/* 88: directive → "@external" externals */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@external"), grammar.NonTerminal("externals")}},
+		/* 89: externals → externals TOKEN */ {Head: "externals", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("externals"), grammar.Terminal("TOKEN")}},
+		/* 90: externals → TOKEN */ {Head: "externals", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
 	}
 
 	// G is the EBNF grammar.
@@ -110,6 +166,15 @@ var (
 
 	// precedences define the associativity and precedence for the EBNF grammar.
 	precedences = lr.PrecedenceLevels{
+		{
+			Associativity: lr.RIGHT,
+			Handles: lr.NewPrecedenceHandles(
+				lr.PrecedenceHandleForProduction(&grammar.Production{
+					Head: "rhs",
+					Body: grammar.String[grammar.Symbol]{grammar.Terminal("FIELD"), grammar.NonTerminal("rhs")},
+				}),
+			),
+		},
 		{
 			Associativity: lr.LEFT,
 			Handles: lr.NewPrecedenceHandles(
@@ -129,6 +194,10 @@ var (
 				lr.PrecedenceHandleForTerminal("IDENT"),
 				lr.PrecedenceHandleForTerminal("TOKEN"),
 				lr.PrecedenceHandleForTerminal("STRING"),
+				lr.PrecedenceHandleForTerminal("ISTRING"),
+				lr.PrecedenceHandleForTerminal("LABEL"),
+				lr.PrecedenceHandleForTerminal("FIELD"),
+				lr.PrecedenceHandleForTerminal("CODE"),
 			),
 		},
 		{
@@ -149,6 +218,10 @@ var (
 				lr.PrecedenceHandleForTerminal("@left"),
 				lr.PrecedenceHandleForTerminal("@right"),
 				lr.PrecedenceHandleForTerminal("@none"),
+				lr.PrecedenceHandleForTerminal("@skip"),
+				lr.PrecedenceHandleForTerminal("@priority"),
+				lr.PrecedenceHandleForTerminal("@start"),
+				lr.PrecedenceHandleForTerminal("@external"),
 			),
 		},
 	}
@@ -260,15 +333,15 @@ func productionIndex(prods []*grammar.Production, p *grammar.Production) int {
 var (
 	// terminals is an ordered list of terminal symbols for the EBNF grammar.
 	terminals = []grammar.Terminal{
-		"=", ";", "|", "(", ")", "[", "]", "{", "}", "{{", "}}", "<", ">",
-		"grammar", "@left", "@right", "@none",
-		"IDENT", "TOKEN", "STRING", "REGEX", "PREDEF",
+		"=", ";", "|", "(", ")", "[", "]", "{", "}", "{{", "}}", "<", ">", "%", "%%", ",",
+		"grammar", "@left", "@right", "@none", "@mode", "@push", "@pop", "@switch", "@skip", "@whitespace", "@indent", "@priority", "@fragment", "@start", "import", "@override", "@remove", "@external",
+		"IDENT", "TOKEN", "STRING", "ISTRING", "REGEX", "PREDEF", "LABEL", "FIELD", "CODE",
 	}
 
 	// nonTerminals is an ordered list of non-terminal symbols for the EBNF grammar.
 	nonTerminals = []grammar.NonTerminal{
-		"grammar", "name", "decls", "decl", "semi_opt", "token",
-		"directive", "handles", "rule_handle", "rule", "lhs", "rhs", "nonterm", "term",
+		"grammar", "name", "decls", "decl", "semi_opt", "token", "action", "mode", "mode_decls",
+		"directive", "handles", "rule_handle", "skips", "chars", "priorities", "fragment", "starts", "externals", "params", "args", "override", "rule", "lhs", "rhs", "nonterm", "term",
 	}
 
 	// productions is an ordered list of productions rules for the EBNF grammar.
@@ -308,6 +381,62 @@ var (
 		/* 32: nonterm → IDENT */ {Head: "nonterm", Body: grammar.String[grammar.Symbol]{grammar.Terminal("IDENT")}},
 		/* 33: term → TOKEN */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
 		/* 34: term → STRING */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("STRING")}},
+		/* 35: decl → mode */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("mode")}},
+		/* 36: mode → "@mode" IDENT "{" mode_decls "}" */ {Head: "mode", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@mode"), grammar.Terminal("IDENT"), grammar.Terminal("{"), grammar.NonTerminal("mode_decls"), grammar.Terminal("}")}},
+		/* 37: mode_decls → mode_decls token semi_opt */ {Head: "mode_decls", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("mode_decls"), grammar.NonTerminal("token"), grammar.NonTerminal("semi_opt")}},
+		/* 38: mode_decls → ε */ {Head: "mode_decls", Body: grammar.E},
+		/* 39: token → TOKEN "=" STRING action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("STRING"), grammar.NonTerminal("action")}},
+		/* 40: token → TOKEN "=" REGEX action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("REGEX"), grammar.NonTerminal("action")}},
+		/* 41: token → TOKEN "=" PREDEF action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("PREDEF"), grammar.NonTerminal("action")}},
+		/* 42: action → "@push" IDENT */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@push"), grammar.Terminal("IDENT")}},
+		/* 43: action → "@pop" */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@pop")}},
+		/* 44: action → "@switch" IDENT */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@switch"), grammar.Terminal("IDENT")}},
+		/* 45: directive → "@skip" skips */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@skip"), grammar.NonTerminal("skips")}},
+		/* 46: skips → skips TOKEN */ {Head: "skips", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("skips"), grammar.Terminal("TOKEN")}},
+		/* 47: skips → TOKEN */ {Head: "skips", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 48: directive → "@whitespace" chars */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@whitespace"), grammar.NonTerminal("chars")}},
+		/* 49: chars → chars STRING */ {Head: "chars", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("chars"), grammar.Terminal("STRING")}},
+		/* 50: chars → ε */ {Head: "chars", Body: grammar.E},
+		/* 51: directive → "@indent" */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@indent")}},
+		/* 52: token → TOKEN "=" ISTRING */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("ISTRING")}},
+		/* 53: token → TOKEN "=" ISTRING action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("ISTRING"), grammar.NonTerminal("action")}},
+		/* 54: term → ISTRING */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ISTRING")}},
+		/* 55: directive → "@priority" priorities */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@priority"), grammar.NonTerminal("priorities")}},
+		/* 56: priorities → priorities TOKEN */ {Head: "priorities", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("priorities"), grammar.Terminal("TOKEN")}},
+		/* 57: priorities → TOKEN */ {Head: "priorities", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 58: decl → fragment semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("fragment"), grammar.NonTerminal("semi_opt")}},
+		/* 59: fragment → "@fragment" TOKEN "=" REGEX */ {Head: "fragment", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@fragment"), grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("REGEX")}},
+		/* 60: rhs → rhs LABEL */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("LABEL")}},
+		/* 61: rhs → FIELD rhs */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("FIELD"), grammar.NonTerminal("rhs")}},
+		/* 62: directive → "@start" starts */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@start"), grammar.NonTerminal("starts")}},
+		/* 63: starts → starts IDENT */ {Head: "starts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("starts"), grammar.Terminal("IDENT")}},
+		/* 64: starts → IDENT */ {Head: "starts", Body: grammar.String[grammar.Symbol]{grammar.Terminal("IDENT")}},
+		/* 65: rhs → "{" rhs "%" rhs "}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("rhs"), grammar.Terminal("%"), grammar.NonTerminal("rhs"), grammar.Terminal("}")}},
+		/* 66: rhs → "{" rhs "%%" rhs "}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("rhs"), grammar.Terminal("%%"), grammar.NonTerminal("rhs"), grammar.Terminal("}")}},
+		/* 67: rhs → "{{" rhs "%" rhs "}}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{{"), grammar.NonTerminal("rhs"), grammar.Terminal("%"), grammar.NonTerminal("rhs"), grammar.Terminal("}}")}},
+		/* 68: rhs → "{{" rhs "%%" rhs "}}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{{"), grammar.NonTerminal("rhs"), grammar.Terminal("%%"), grammar.NonTerminal("rhs"), grammar.Terminal("}}")}},
+		/* 69: lhs → nonterm "<" params ">" */ {Head: "lhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("nonterm"), grammar.Terminal("<"), grammar.NonTerminal("params"), grammar.Terminal(">")}},
+		/* 70: params → params "," TOKEN */ {Head: "params", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("params"), grammar.Terminal(","), grammar.Terminal("TOKEN")}},
+		/* 71: params → TOKEN */ {Head: "params", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 72: rhs → nonterm "<" args ">" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("nonterm"), grammar.Terminal("<"), grammar.NonTerminal("args"), grammar.Terminal(">")}},
+		/* 73: args → args "," rhs */ {Head: "args", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("args"), grammar.Terminal(","), grammar.NonTerminal("rhs")}},
+		/* 74: args → rhs */ {Head: "args", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs")}},
+		/* 75: rhs → rhs CODE */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("CODE")}},
+		/* 76: rhs → rhs "|" CODE */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("|"), grammar.Terminal("CODE")}},
+		/* 77: rule → lhs "=" CODE */ {Head: "rule", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("lhs"), grammar.Terminal("="), grammar.Terminal("CODE")}},
+		/* 78: decl → CODE semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("CODE"), grammar.NonTerminal("semi_opt")}},
+		/* 79: decl → "import" STRING semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("import"), grammar.Terminal("STRING"), grammar.NonTerminal("semi_opt")}},
+		/* 80: decl → override rule ";" */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("override"), grammar.NonTerminal("rule"), grammar.Terminal(";")}},
+		/* 81: override → "@override" */ {Head: "override", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override")}},
+		/* 82: decl → "@override" token semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override"), grammar.NonTerminal("token"), grammar.NonTerminal("semi_opt")}},
+		/* 83: decl → "@override" directive semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override"), grammar.NonTerminal("directive"), grammar.NonTerminal("semi_opt")}},
+		/* 84: decl → "@remove" rule ";" */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.NonTerminal("rule"), grammar.Terminal(";")}},
+		/* 85: decl → "@remove" directive semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.NonTerminal("directive"), grammar.NonTerminal("semi_opt")}},
+		/* 86: decl → "@remove" IDENT semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.Terminal("IDENT"), grammar.NonTerminal("semi_opt")}},
+		/* 87: decl → "@remove" TOKEN semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.Terminal("TOKEN"), grammar.NonTerminal("semi_opt")}},
+		/* 88: directive → "@external" externals */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@external"), grammar.NonTerminal("externals")}},
+		/* 89: externals → externals TOKEN */ {Head: "externals", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("externals"), grammar.Terminal("TOKEN")}},
+		/* 90: externals → TOKEN */ {Head: "externals", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
 	}
 
 	// G is the EBNF grammar.
@@ -320,6 +449,15 @@ var (
 
 	// precedences define the associativity and precedence for the EBNF grammar.
 	precedences = lr.PrecedenceLevels{
+		{
+			Associativity: lr.RIGHT,
+			Handles: lr.NewPrecedenceHandles(
+				lr.PrecedenceHandleForProduction(&grammar.Production{
+					Head: "rhs",
+					Body: grammar.String[grammar.Symbol]{grammar.Terminal("FIELD"), grammar.NonTerminal("rhs")},
+				}),
+			),
+		},
 		{
 			Associativity: lr.LEFT,
 			Handles: lr.NewPrecedenceHandles(
@@ -339,6 +477,10 @@ var (
 				lr.PrecedenceHandleForTerminal("IDENT"),
 				lr.PrecedenceHandleForTerminal("TOKEN"),
 				lr.PrecedenceHandleForTerminal("STRING"),
+				lr.PrecedenceHandleForTerminal("ISTRING"),
+				lr.PrecedenceHandleForTerminal("LABEL"),
+				lr.PrecedenceHandleForTerminal("FIELD"),
+				lr.PrecedenceHandleForTerminal("CODE"),
 			),
 		},
 		{
@@ -359,6 +501,10 @@ var (
 				lr.PrecedenceHandleForTerminal("@left"),
 				lr.PrecedenceHandleForTerminal("@right"),
 				lr.PrecedenceHandleForTerminal("@none"),
+				lr.PrecedenceHandleForTerminal("@skip"),
+				lr.PrecedenceHandleForTerminal("@priority"),
+				lr.PrecedenceHandleForTerminal("@start"),
+				lr.PrecedenceHandleForTerminal("@external"),
 			),
 		},
 	}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"unicode"
 
 	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/grammar"
@@ -16,16 +19,118 @@ import (
 	ebnflexer "github.com/gardenbed/emerge/internal/ebnf/lexer"
 )
 
+// Building blocks shared by the predefined regular expressions.
+const (
+	escape       = `\\[\\"'tnr]|\\x[0-9A-Fa-f]{2}|\\u[0-9A-Fa-f]{4}|\\U[0-9A-Fa-f]{8}`
+	lineComment  = `(#|\/\/)[^\n\r]*`
+	blockComment = `\/\*([^*]|\*+[^*\/])*\*+\/`
+)
+
+// The identifier characters follow the ID_Start and ID_Continue properties of Unicode (UAX #31).
+// In addition, an underscore can start an identifier.
+var (
+	identStart = identClass(
+		unicode.L, unicode.Nl, unicode.Other_ID_Start,
+		&unicode.RangeTable{R16: []unicode.Range16{{Lo: '_', Hi: '_', Stride: 1}}},
+	)
+
+	identContinue = identClass(
+		unicode.L, unicode.Nl, unicode.Other_ID_Start,
+		unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue,
+	)
+)
+
+// identClass returns a character group matching the characters in any of the given Unicode tables.
+// The Pattern_Syntax and Pattern_White_Space characters are excluded as required by UAX #31.
+func identClass(tables ...*unicode.RangeTable) string {
+	var ranges [][2]rune
+	add := func(lo, hi, stride rune) {
+		if stride == 1 {
+			ranges = append(ranges, [2]rune{lo, hi})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			ranges = append(ranges, [2]rune{r, r})
+		}
+	}
+
+	for _, t := range tables {
+		for _, r := range t.R16 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+		for _, r := range t.R32 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+	}
+
+	slices.SortFunc(ranges, func(a, b [2]rune) int {
+		return int(a[0] - b[0])
+	})
+
+	var b strings.Builder
+	b.WriteString("[")
+
+	lo, next := rune(-1), rune(0)
+	flush := func() {
+		if lo < 0 {
+			return
+		}
+		if fmt.Fprintf(&b, `\x%08X`, lo); next-1 > lo {
+			fmt.Fprintf(&b, `-\x%08X`, next-1)
+		}
+		lo = -1
+	}
+
+	for _, rg := range ranges {
+		for r := max(rg[0], next); r <= rg[1]; r++ {
+			if unicode.In(r, unicode.Pattern_Syntax, unicode.Pattern_White_Space) {
+				flush()
+				continue
+			}
+			if lo < 0 || r != next {
+				flush()
+				lo = r
+			}
+			next = r + 1
+		}
+	}
+
+	flush()
+	b.WriteString("]")
+
+	return b.String()
+}
+
 // Predefs defines the acceptable values for the PREDEF token in the EBNF specification.
 // Each value is a predefined regular expression for defining a token.
 var Predefs = map[string]string{
-	"$DIGIT":   `[0-9]`,
-	"$HEX":     `[0-9A-Fa-f]`,
-	"$LETTER":  `[A-Za-z]`,
+	// Characters
+	"$DIGIT":          `[0-9]`,
+	"$HEX":            `[0-9A-Fa-f]`,
+	"$LETTER":         `[A-Za-z]`,
+	"$IDENT_START":    identStart,
+	"$IDENT_CONTINUE": identContinue,
+
+	// Identifiers
+	"$IDENT": identStart + identContinue + `*`,
+
+	// Numbers
 	"$INT":     `-?[0-9]+`,
-	"$FLOAT":   `-?[0-9]+(\.[0-9]+)?`,
-	"$STRING":  `"([^\\"]|\\[\\"'tnr])*"`,
-	"$COMMENT": `(#|\/\/)[^\n\r]*|\/\*.*?\*\/`,
+	"$DEC_INT": `[0-9](_?[0-9])*`,
+	"$HEX_INT": `0[xX][0-9A-Fa-f](_?[0-9A-Fa-f])*`,
+	"$OCT_INT": `0[oO][0-7](_?[0-7])*`,
+	"$BIN_INT": `0[bB][01](_?[01])*`,
+	"$FLOAT":   `-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?`,
+
+	// Strings
+	"$STRING":     `"([^\\"]|` + escape + `)*"`,
+	"$SQ_STRING":  `'([^\\']|` + escape + `)*'`,
+	"$RAW_STRING": "`[^`]*`",
+
+	// Comments
+	"$COMMENT":       lineComment + `|` + blockComment,
+	"$LINE_COMMENT":  lineComment,
+	"$BLOCK_COMMENT": blockComment,
 }
 
 // ProductionFunc is a function that is invoked each time a production rule
@@ -88,6 +193,16 @@ func (p *Parser) nextToken() (lexer.Token, error) {
 	return token, err
 }
 
+// Comments returns the comments skipped by the lexer so far in the order they appear in the input.
+// It returns nil if the lexer does not keep the comments it skips.
+func (p *Parser) Comments() []lexer.Token {
+	if L, ok := p.L.(interface{ Comments() []lexer.Token }); ok {
+		return L.Comments()
+	}
+
+	return nil
+}
+
 // Parse implements the LR parsing algorithm.
 // It analyzes a sequence of input tokens (terminal symbols) provided by the lexical analyzer.
 // It attempts to parse the input according to the production rules of the EBNF grammar.
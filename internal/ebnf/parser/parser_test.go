@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 	"testing/iotest"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 
@@ -33,6 +34,53 @@ func (m *MockLexer) NextToken() (lexer.Token, error) {
 	return m.NextTokenMocks[i].OutToken, m.NextTokenMocks[i].OutError
 }
 
+// MockCommentLexer is an implementation of lexer.Lexer that keeps comments for testing purposes.
+type MockCommentLexer struct {
+	MockLexer
+	OutComments []lexer.Token
+}
+
+func (m *MockCommentLexer) Comments() []lexer.Token {
+	return m.OutComments
+}
+
+func TestIdentClass(t *testing.T) {
+	tests := []struct {
+		name          string
+		tables        []*unicode.RangeTable
+		expectedClass string
+	}{
+		{
+			name: "Ranges",
+			tables: []*unicode.RangeTable{
+				{R16: []unicode.Range16{{Lo: 'a', Hi: 'c', Stride: 1}, {Lo: 'x', Hi: 'z', Stride: 1}}},
+			},
+			expectedClass: `[\x00000061-\x00000063\x00000078-\x0000007A]`,
+		},
+		{
+			name: "StridesAndOverlaps",
+			tables: []*unicode.RangeTable{
+				{R16: []unicode.Range16{{Lo: 'a', Hi: 'e', Stride: 2}}},
+				{R16: []unicode.Range16{{Lo: 'b', Hi: 'c', Stride: 1}}, R32: []unicode.Range32{{Lo: 0x10000, Hi: 0x10001, Stride: 1}}},
+			},
+			expectedClass: `[\x00000061-\x00000063\x00000065\x00010000-\x00010001]`,
+		},
+		{
+			name: "PatternSyntaxExcluded",
+			tables: []*unicode.RangeTable{
+				{R16: []unicode.Range16{{Lo: '(', Hi: '0', Stride: 1}, {Lo: 0x2E2E, Hi: 0x2E30, Stride: 1}}},
+			},
+			expectedClass: `[\x00000030]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedClass, identClass(tc.tables...))
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -69,6 +117,59 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestParser_Comments(t *testing.T) {
+	tests := []struct {
+		name             string
+		p                *Parser
+		expectedComments []lexer.Token
+	}{
+		{
+			name: "NoComments",
+			p: &Parser{
+				L: &MockLexer{},
+			},
+			expectedComments: nil,
+		},
+		{
+			name: "OK",
+			p: &Parser{
+				L: &MockCommentLexer{
+					OutComments: []lexer.Token{
+						{
+							Terminal: grammar.Terminal("COMMENT"),
+							Lexeme:   "// Comment",
+							Pos: lexer.Position{
+								Filename: "test",
+								Offset:   0,
+								Line:     1,
+								Column:   1,
+							},
+						},
+					},
+				},
+			},
+			expectedComments: []lexer.Token{
+				{
+					Terminal: grammar.Terminal("COMMENT"),
+					Lexeme:   "// Comment",
+					Pos: lexer.Position{
+						Filename: "test",
+						Offset:   0,
+						Line:     1,
+						Column:   1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedComments, tc.p.Comments())
+		})
+	}
+}
+
 func TestParser_Parse(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -12,15 +12,15 @@ import (
 var (
 	// terminals is an ordered list of terminal symbols for the EBNF grammar.
 	terminals = []grammar.Terminal{
-		"=", ";", "|", "(", ")", "[", "]", "{", "}", "{{", "}}", "<", ">",
-		"grammar", "@left", "@right", "@none",
-		"IDENT", "TOKEN", "STRING", "REGEX", "PREDEF",
+		"=", ";", "|", "(", ")", "[", "]", "{", "}", "{{", "}}", "<", ">", "%", "%%", ",",
+		"grammar", "@left", "@right", "@none", "@mode", "@push", "@pop", "@switch", "@skip", "@whitespace", "@indent", "@priority", "@fragment", "@start", "import", "@override", "@remove", "@external",
+		"IDENT", "TOKEN", "STRING", "ISTRING", "REGEX", "PREDEF", "LABEL", "FIELD", "CODE",
 	}
 
 	// nonTerminals is an ordered list of non-terminal symbols for the EBNF grammar.
 	nonTerminals = []grammar.NonTerminal{
-		"grammar", "name", "decls", "decl", "semi_opt", "token",
-		"directive", "handles", "rule_handle", "rule", "lhs", "rhs", "nonterm", "term",
+		"grammar", "name", "decls", "decl", "semi_opt", "token", "action", "mode", "mode_decls",
+		"directive", "handles", "rule_handle", "skips", "chars", "priorities", "fragment", "starts", "externals", "params", "args", "override", "rule", "lhs", "rhs", "nonterm", "term",
 	}
 
 	// productions is an ordered list of productions rules for the EBNF grammar.
@@ -60,6 +60,62 @@ var (
 		/* 32: nonterm → IDENT */ {Head: "nonterm", Body: grammar.String[grammar.Symbol]{grammar.Terminal("IDENT")}},
 		/* 33: term → TOKEN */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
 		/* 34: term → STRING */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("STRING")}},
+		/* 35: decl → mode */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("mode")}},
+		/* 36: mode → "@mode" IDENT "{" mode_decls "}" */ {Head: "mode", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@mode"), grammar.Terminal("IDENT"), grammar.Terminal("{"), grammar.NonTerminal("mode_decls"), grammar.Terminal("}")}},
+		/* 37: mode_decls → mode_decls token semi_opt */ {Head: "mode_decls", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("mode_decls"), grammar.NonTerminal("token"), grammar.NonTerminal("semi_opt")}},
+		/* 38: mode_decls → ε */ {Head: "mode_decls", Body: grammar.E},
+		/* 39: token → TOKEN "=" STRING action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("STRING"), grammar.NonTerminal("action")}},
+		/* 40: token → TOKEN "=" REGEX action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("REGEX"), grammar.NonTerminal("action")}},
+		/* 41: token → TOKEN "=" PREDEF action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("PREDEF"), grammar.NonTerminal("action")}},
+		/* 42: action → "@push" IDENT */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@push"), grammar.Terminal("IDENT")}},
+		/* 43: action → "@pop" */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@pop")}},
+		/* 44: action → "@switch" IDENT */ {Head: "action", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@switch"), grammar.Terminal("IDENT")}},
+		/* 45: directive → "@skip" skips */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@skip"), grammar.NonTerminal("skips")}},
+		/* 46: skips → skips TOKEN */ {Head: "skips", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("skips"), grammar.Terminal("TOKEN")}},
+		/* 47: skips → TOKEN */ {Head: "skips", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 48: directive → "@whitespace" chars */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@whitespace"), grammar.NonTerminal("chars")}},
+		/* 49: chars → chars STRING */ {Head: "chars", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("chars"), grammar.Terminal("STRING")}},
+		/* 50: chars → ε */ {Head: "chars", Body: grammar.E},
+		/* 51: directive → "@indent" */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@indent")}},
+		/* 52: token → TOKEN "=" ISTRING */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("ISTRING")}},
+		/* 53: token → TOKEN "=" ISTRING action */ {Head: "token", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("ISTRING"), grammar.NonTerminal("action")}},
+		/* 54: term → ISTRING */ {Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ISTRING")}},
+		/* 55: directive → "@priority" priorities */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@priority"), grammar.NonTerminal("priorities")}},
+		/* 56: priorities → priorities TOKEN */ {Head: "priorities", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("priorities"), grammar.Terminal("TOKEN")}},
+		/* 57: priorities → TOKEN */ {Head: "priorities", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 58: decl → fragment semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("fragment"), grammar.NonTerminal("semi_opt")}},
+		/* 59: fragment → "@fragment" TOKEN "=" REGEX */ {Head: "fragment", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@fragment"), grammar.Terminal("TOKEN"), grammar.Terminal("="), grammar.Terminal("REGEX")}},
+		/* 60: rhs → rhs LABEL */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("LABEL")}},
+		/* 61: rhs → FIELD rhs */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("FIELD"), grammar.NonTerminal("rhs")}},
+		/* 62: directive → "@start" starts */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@start"), grammar.NonTerminal("starts")}},
+		/* 63: starts → starts IDENT */ {Head: "starts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("starts"), grammar.Terminal("IDENT")}},
+		/* 64: starts → IDENT */ {Head: "starts", Body: grammar.String[grammar.Symbol]{grammar.Terminal("IDENT")}},
+		/* 65: rhs → "{" rhs "%" rhs "}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("rhs"), grammar.Terminal("%"), grammar.NonTerminal("rhs"), grammar.Terminal("}")}},
+		/* 66: rhs → "{" rhs "%%" rhs "}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("rhs"), grammar.Terminal("%%"), grammar.NonTerminal("rhs"), grammar.Terminal("}")}},
+		/* 67: rhs → "{{" rhs "%" rhs "}}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{{"), grammar.NonTerminal("rhs"), grammar.Terminal("%"), grammar.NonTerminal("rhs"), grammar.Terminal("}}")}},
+		/* 68: rhs → "{{" rhs "%%" rhs "}}" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{{"), grammar.NonTerminal("rhs"), grammar.Terminal("%%"), grammar.NonTerminal("rhs"), grammar.Terminal("}}")}},
+		/* 69: lhs → nonterm "<" params ">" */ {Head: "lhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("nonterm"), grammar.Terminal("<"), grammar.NonTerminal("params"), grammar.Terminal(">")}},
+		/* 70: params → params "," TOKEN */ {Head: "params", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("params"), grammar.Terminal(","), grammar.Terminal("TOKEN")}},
+		/* 71: params → TOKEN */ {Head: "params", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
+		/* 72: rhs → nonterm "<" args ">" */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("nonterm"), grammar.Terminal("<"), grammar.NonTerminal("args"), grammar.Terminal(">")}},
+		/* 73: args → args "," rhs */ {Head: "args", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("args"), grammar.Terminal(","), grammar.NonTerminal("rhs")}},
+		/* 74: args → rhs */ {Head: "args", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs")}},
+		/* 75: rhs → rhs CODE */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("CODE")}},
+		/* 76: rhs → rhs "|" CODE */ {Head: "rhs", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("rhs"), grammar.Terminal("|"), grammar.Terminal("CODE")}},
+		/* 77: rule → lhs "=" CODE */ {Head: "rule", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("lhs"), grammar.Terminal("="), grammar.Terminal("CODE")}},
+		/* 78: decl → CODE semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("CODE"), grammar.NonTerminal("semi_opt")}},
+		/* 79: decl → "import" STRING semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("import"), grammar.Terminal("STRING"), grammar.NonTerminal("semi_opt")}},
+		/* 80: decl → override rule ";" */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("override"), grammar.NonTerminal("rule"), grammar.Terminal(";")}},
+		/* 81: override → "@override" */ {Head: "override", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override")}},
+		/* 82: decl → "@override" token semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override"), grammar.NonTerminal("token"), grammar.NonTerminal("semi_opt")}},
+		/* 83: decl → "@override" directive semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@override"), grammar.NonTerminal("directive"), grammar.NonTerminal("semi_opt")}},
+		/* 84: decl → "@remove" rule ";" */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.NonTerminal("rule"), grammar.Terminal(";")}},
+		/* 85: decl → "@remove" directive semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.NonTerminal("directive"), grammar.NonTerminal("semi_opt")}},
+		/* 86: decl → "@remove" IDENT semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.Terminal("IDENT"), grammar.NonTerminal("semi_opt")}},
+		/* 87: decl → "@remove" TOKEN semi_opt */ {Head: "decl", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@remove"), grammar.Terminal("TOKEN"), grammar.NonTerminal("semi_opt")}},
+		/* 88: directive → "@external" externals */ {Head: "directive", Body: grammar.String[grammar.Symbol]{grammar.Terminal("@external"), grammar.NonTerminal("externals")}},
+		/* 89: externals → externals TOKEN */ {Head: "externals", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("externals"), grammar.Terminal("TOKEN")}},
+		/* 90: externals → TOKEN */ {Head: "externals", Body: grammar.String[grammar.Symbol]{grammar.Terminal("TOKEN")}},
 	}
 
 	// G is the EBNF grammar.
@@ -72,6 +128,15 @@ var (
 
 	// precedences define the associativity and precedence for the EBNF grammar.
 	precedences = lr.PrecedenceLevels{
+		{
+			Associativity: lr.RIGHT,
+			Handles: lr.NewPrecedenceHandles(
+				lr.PrecedenceHandleForProduction(&grammar.Production{
+					Head: "rhs",
+					Body: grammar.String[grammar.Symbol]{grammar.Terminal("FIELD"), grammar.NonTerminal("rhs")},
+				}),
+			),
+		},
 		{
 			Associativity: lr.LEFT,
 			Handles: lr.NewPrecedenceHandles(
@@ -91,6 +156,10 @@ var (
 				lr.PrecedenceHandleForTerminal("IDENT"),
 				lr.PrecedenceHandleForTerminal("TOKEN"),
 				lr.PrecedenceHandleForTerminal("STRING"),
+				lr.PrecedenceHandleForTerminal("ISTRING"),
+				lr.PrecedenceHandleForTerminal("LABEL"),
+				lr.PrecedenceHandleForTerminal("FIELD"),
+				lr.PrecedenceHandleForTerminal("CODE"),
 			),
 		},
 		{
@@ -111,6 +180,10 @@ var (
 				lr.PrecedenceHandleForTerminal("@left"),
 				lr.PrecedenceHandleForTerminal("@right"),
 				lr.PrecedenceHandleForTerminal("@none"),
+				lr.PrecedenceHandleForTerminal("@skip"),
+				lr.PrecedenceHandleForTerminal("@priority"),
+				lr.PrecedenceHandleForTerminal("@start"),
+				lr.PrecedenceHandleForTerminal("@external"),
 			),
 		},
 	}
@@ -122,7 +195,7 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 	case 0:
 		switch a {
 		case "grammar":
-			return lr.SHIFT, 43, nil // SHIFT 43
+			return lr.SHIFT, 134, nil // SHIFT 134
 		}
 
 	case 1:
@@ -134,750 +207,4100 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 	case 2:
 		switch a {
 		case "@left":
-			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
 		case "@right":
-			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
 		case "@none":
-			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@mode":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@skip":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@whitespace":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@indent":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@priority":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@fragment":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@start":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "import":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@override":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@remove":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "@external":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
 		case "IDENT":
-			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
 		case "TOKEN":
-			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
+		case "CODE":
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
 		case grammar.Endmarker:
-			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+			return lr.REDUCE, 36, nil // REDUCE mode → "@mode" "IDENT" "{" mode_decls "}"
 		}
 
 	case 3:
 		switch a {
 		case ";":
-			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "|":
-			return lr.SHIFT, 24, nil // SHIFT 24
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case ")":
-			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "]":
-			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "}":
-			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "}}":
-			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case ">":
-			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case "%":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case "%%":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case ",":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case "ISTRING":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case "LABEL":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case "FIELD":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
+		case "CODE":
+			return lr.REDUCE, 65, nil // REDUCE rhs → "{" rhs "%" rhs "}"
 		}
 
 	case 4:
 		switch a {
 		case ";":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "|":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "(":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case ")":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "[":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "]":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "{":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "}":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "{{":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "}}":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case ">":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case "%":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case "%%":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case ",":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "IDENT":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "TOKEN":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		case "STRING":
-			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case "ISTRING":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case "LABEL":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case "FIELD":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
+		case "CODE":
+			return lr.REDUCE, 66, nil // REDUCE rhs → "{" rhs "%%" rhs "}"
 		}
 
 	case 5:
 		switch a {
 		case ";":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "|":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "(":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case ")":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "[":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "]":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "{":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "}":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "{{":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "}}":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case ">":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case "%":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case "%%":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case ",":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "IDENT":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "TOKEN":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		case "STRING":
-			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case "ISTRING":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case "LABEL":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case "FIELD":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
+		case "CODE":
+			return lr.REDUCE, 67, nil // REDUCE rhs → "{{" rhs "%" rhs "}}"
 		}
 
 	case 6:
 		switch a {
 		case ";":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "|":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "(":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case ")":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "[":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "]":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "{":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "}":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "{{":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "}}":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case ">":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case "%":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case "%%":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case ",":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "IDENT":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "TOKEN":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		case "STRING":
-			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case "ISTRING":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case "LABEL":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case "FIELD":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
+		case "CODE":
+			return lr.REDUCE, 68, nil // REDUCE rhs → "{{" rhs "%%" rhs "}}"
 		}
 
 	case 7:
 		switch a {
 		case ";":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@left":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@right":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@none":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@mode":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@skip":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@whitespace":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@indent":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@priority":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@fragment":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@start":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "import":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@override":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@remove":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "@external":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "IDENT":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "TOKEN":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case "CODE":
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		case grammar.Endmarker:
+			return lr.REDUCE, 59, nil // REDUCE fragment → "@fragment" "TOKEN" "=" "REGEX"
+		}
+
+	case 8:
+		switch a {
+		case "=":
+			return lr.REDUCE, 69, nil // REDUCE lhs → nonterm "<" params ">"
+		}
+
+	case 9:
+		switch a {
+		case "}":
+			return lr.SHIFT, 2, nil // SHIFT 2
+		case "TOKEN":
+			return lr.SHIFT, 153, nil // SHIFT 153
+		}
+
+	case 10:
+		switch a {
 		case "|":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 92, nil // SHIFT 92
 		case "(":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
-		case ")":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
-		case "]":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "}":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 3, nil // SHIFT 3
 		case "{{":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 11:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "}":
+			return lr.SHIFT, 4, nil // SHIFT 4
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 12:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "}}":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
-		case ">":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 5, nil // SHIFT 5
 		case "IDENT":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
 		}
 
-	case 8:
+	case 13:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "}}":
+			return lr.SHIFT, 6, nil // SHIFT 6
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 14:
 		switch a {
 		case ";":
-			return lr.REDUCE, 20, nil // REDUCE rule → lhs "=" rhs
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "|":
-			return lr.SHIFT, 24, nil // SHIFT 24
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case ")":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "]":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "}":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "}}":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case ">":
-			return lr.REDUCE, 20, nil // REDUCE rule → lhs "=" rhs
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "%":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "%%":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case ",":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "ISTRING":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "LABEL":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "FIELD":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
+		case "CODE":
+			return lr.REDUCE, 72, nil // REDUCE rhs → nonterm "<" args ">"
 		}
 
-	case 9:
+	case 15:
 		switch a {
 		case ";":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
-		case "<":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "}":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		case "@left":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		case "@right":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		case "@none":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@mode":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@skip":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@whitespace":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@indent":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@priority":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@fragment":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@start":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "import":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@override":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@remove":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "@external":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		case "IDENT":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		case "TOKEN":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
-		case "STRING":
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
+		case "CODE":
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		case grammar.Endmarker:
-			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+			return lr.REDUCE, 53, nil // REDUCE token → "TOKEN" "=" "ISTRING" action
 		}
 
-	case 10:
+	case 16:
 		switch a {
 		case ";":
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "}":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		case "@left":
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		case "@right":
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		case "@none":
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@mode":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@skip":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@whitespace":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@indent":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@priority":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@fragment":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@start":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "import":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@override":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@remove":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "@external":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		case "IDENT":
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		case "TOKEN":
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
+		case "CODE":
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		case grammar.Endmarker:
-			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+			return lr.REDUCE, 41, nil // REDUCE token → "TOKEN" "=" "PREDEF" action
 		}
 
-	case 11:
+	case 17:
 		switch a {
 		case ";":
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "}":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		case "@left":
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		case "@right":
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		case "@none":
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@mode":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@skip":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@whitespace":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@indent":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@priority":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@fragment":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@start":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "import":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@override":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@remove":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "@external":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		case "IDENT":
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		case "TOKEN":
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
+		case "CODE":
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		case grammar.Endmarker:
-			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+			return lr.REDUCE, 40, nil // REDUCE token → "TOKEN" "=" "REGEX" action
 		}
 
-	case 12:
+	case 18:
 		switch a {
 		case ";":
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "}":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		case "@left":
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		case "@right":
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		case "@none":
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@mode":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@skip":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@whitespace":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@indent":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@priority":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@fragment":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@start":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "import":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@override":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@remove":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "@external":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		case "IDENT":
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		case "TOKEN":
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
+		case "CODE":
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		case grammar.Endmarker:
-			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+			return lr.REDUCE, 39, nil // REDUCE token → "TOKEN" "=" "STRING" action
 		}
 
-	case 13:
+	case 19:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case ">":
+			return lr.REDUCE, 73, nil // REDUCE args → args "," rhs
+		case ",":
+			return lr.REDUCE, 73, nil // REDUCE args → args "," rhs
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 20:
 		switch a {
 		case "@left":
-			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
 		case "@right":
-			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
 		case "@none":
-			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@mode":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@skip":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@indent":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@priority":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@fragment":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@start":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "import":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@override":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@remove":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "@external":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
 		case "IDENT":
-			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
 		case "TOKEN":
-			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
+		case "CODE":
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+			return lr.REDUCE, 83, nil // REDUCE decl → "@override" directive semi_opt
 		}
 
-	case 14:
+	case 21:
 		switch a {
 		case "@left":
-			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
 		case "@right":
-			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
 		case "@none":
-			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@mode":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@skip":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@indent":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@priority":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@fragment":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@start":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "import":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@override":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@remove":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "@external":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
 		case "IDENT":
-			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
 		case "TOKEN":
-			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
+		case "CODE":
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+			return lr.REDUCE, 82, nil // REDUCE decl → "@override" token semi_opt
 		}
 
-	case 15:
+	case 22:
 		switch a {
 		case "@left":
-			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
 		case "@right":
-			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
 		case "@none":
-			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@mode":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@skip":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@indent":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@priority":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@fragment":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@start":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "import":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@override":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@remove":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "@external":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
 		case "IDENT":
-			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
 		case "TOKEN":
-			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
+		case "CODE":
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+			return lr.REDUCE, 85, nil // REDUCE decl → "@remove" directive semi_opt
 		}
 
-	case 16:
+	case 23:
 		switch a {
 		case "@left":
-			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
 		case "@right":
-			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
 		case "@none":
-			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@mode":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@skip":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@whitespace":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@indent":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@priority":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@fragment":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@start":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "import":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@override":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@remove":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "@external":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
 		case "IDENT":
-			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
 		case "TOKEN":
-			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
+		case "CODE":
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
 		case grammar.Endmarker:
-			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+			return lr.REDUCE, 80, nil // REDUCE decl → override rule ";"
 		}
 
-	case 17:
+	case 24:
 		switch a {
-		case ";":
-			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
-		case "<":
-			return lr.SHIFT, 52, nil // SHIFT 52
 		case "@left":
-			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
 		case "@right":
-			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
 		case "@none":
-			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@mode":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@skip":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@indent":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@priority":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@fragment":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@start":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "import":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@override":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@remove":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "@external":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
 		case "IDENT":
-			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
-		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
+		case "CODE":
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+			return lr.REDUCE, 86, nil // REDUCE decl → "@remove" "IDENT" semi_opt
 		}
 
-	case 18:
+	case 25:
 		switch a {
-		case ";":
-			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
-		case "<":
-			return lr.SHIFT, 52, nil // SHIFT 52
 		case "@left":
-			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
 		case "@right":
-			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
 		case "@none":
-			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@mode":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@skip":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@indent":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@priority":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@fragment":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@start":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "import":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@override":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@remove":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "@external":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
 		case "IDENT":
-			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
-		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
+		case "CODE":
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+			return lr.REDUCE, 87, nil // REDUCE decl → "@remove" "TOKEN" semi_opt
 		}
 
-	case 19:
+	case 26:
 		switch a {
-		case ";":
-			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
-		case "<":
-			return lr.SHIFT, 52, nil // SHIFT 52
 		case "@left":
-			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
 		case "@right":
-			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
 		case "@none":
-			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@mode":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@skip":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@whitespace":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@indent":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@priority":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@fragment":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@start":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "import":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@override":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@remove":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "@external":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
 		case "IDENT":
-			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
-		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
+		case "CODE":
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
 		case grammar.Endmarker:
-			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+			return lr.REDUCE, 84, nil // REDUCE decl → "@remove" rule ";"
 		}
 
-	case 20:
+	case 27:
 		switch a {
 		case "@left":
-			return lr.SHIFT, 36, nil // SHIFT 36
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
 		case "@right":
-			return lr.SHIFT, 38, nil // SHIFT 38
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
 		case "@none":
-			return lr.SHIFT, 37, nil // SHIFT 37
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@mode":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@skip":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@indent":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@priority":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@fragment":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@start":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "import":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@override":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@remove":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "@external":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
 		case "TOKEN":
-			return lr.SHIFT, 56, nil // SHIFT 56
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
+		case "CODE":
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 0, nil // REDUCE grammar → name decls
+			return lr.REDUCE, 79, nil // REDUCE decl → "import" "STRING" semi_opt
 		}
 
-	case 21:
+	case 28:
 		switch a {
-		case ";":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case "<":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case "@left":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case "@right":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case "@none":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case "IDENT":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "REGEX":
+			return lr.SHIFT, 7, nil // SHIFT 7
+		}
+
+	case 29:
+		switch a {
+		case ">":
+			return lr.SHIFT, 8, nil // SHIFT 8
+		case ",":
+			return lr.SHIFT, 87, nil // SHIFT 87
+		}
+
+	case 30:
+		switch a {
+		case "}":
+			return lr.REDUCE, 38, nil // REDUCE mode_decls → ε
 		case "TOKEN":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case "STRING":
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
-		case grammar.Endmarker:
-			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+			return lr.REDUCE, 38, nil // REDUCE mode_decls → ε
 		}
 
-	case 22:
+	case 31:
 		switch a {
-		case ";":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case "<":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case "@left":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case "@right":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case "@none":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case "IDENT":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "}":
+			return lr.REDUCE, 37, nil // REDUCE mode_decls → mode_decls token semi_opt
 		case "TOKEN":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case "STRING":
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
-		case grammar.Endmarker:
-			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+			return lr.REDUCE, 37, nil // REDUCE mode_decls → mode_decls token semi_opt
 		}
 
-	case 23:
+	case 32:
 		switch a {
-		case ";":
-			return lr.SHIFT, 53, nil // SHIFT 53
 		case "@left":
-			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
 		case "@right":
-			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
 		case "@none":
-			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@mode":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@skip":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@indent":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@priority":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@fragment":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@start":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "import":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@override":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@remove":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "@external":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
 		case "IDENT":
-			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
 		case "TOKEN":
-			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
+		case "CODE":
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
 		case grammar.Endmarker:
-			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+			return lr.REDUCE, 1, nil // REDUCE name → "grammar" "IDENT" semi_opt
 		}
 
-	case 24:
+	case 33:
+		switch a {
+		case ">":
+			return lr.REDUCE, 70, nil // REDUCE params → params "," "TOKEN"
+		case ",":
+			return lr.REDUCE, 70, nil // REDUCE params → params "," "TOKEN"
+		}
+
+	case 34:
 		switch a {
-		case ";":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
-		case "|":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
-		case ")":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
-		case "]":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
-		case "}":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
-		case "}}":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
-		case ">":
-			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
 		}
 
-	case 25:
+	case 35:
 		switch a {
-		case ";":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
-		case "|":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
 		case "(":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
-		case ")":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
-		case "]":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
-		case "}":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
-		case "}}":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
-		case ">":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "IDENT":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
 		}
 
-	case 26:
+	case 36:
 		switch a {
-		case "|":
-			return lr.SHIFT, 24, nil // SHIFT 24
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
-		case ")":
-			return lr.SHIFT, 4, nil // SHIFT 4
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
 		}
 
-	case 27:
+	case 37:
 		switch a {
-		case "|":
-			return lr.SHIFT, 24, nil // SHIFT 24
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
-		case "]":
-			return lr.SHIFT, 5, nil // SHIFT 5
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		}
+
+	case 38:
+		switch a {
+		case ">":
+			return lr.SHIFT, 14, nil // SHIFT 14
+		case ",":
 			return lr.SHIFT, 54, nil // SHIFT 54
 		}
 
-	case 28:
+	case 39:
 		switch a {
+		case ";":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
 		case "|":
-			return lr.SHIFT, 24, nil // SHIFT 24
+			return lr.SHIFT, 92, nil // SHIFT 92
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case ")":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "]":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "}":
-			return lr.SHIFT, 6, nil // SHIFT 6
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "}}":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+		case ">":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+		case "%":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+		case "%%":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
+		case ",":
+			return lr.REDUCE, 28, nil // REDUCE rhs → rhs "|" rhs
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
 		}
 
-	case 29:
+	case 40:
 		switch a {
+		case ";":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "|":
-			return lr.SHIFT, 24, nil // SHIFT 24
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case ")":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "]":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "}":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "}}":
-			return lr.SHIFT, 7, nil // SHIFT 7
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case ">":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "%":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "%%":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case ",":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "ISTRING":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "LABEL":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "FIELD":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
+		case "CODE":
+			return lr.REDUCE, 24, nil // REDUCE rhs → "(" rhs ")"
 		}
 
-	case 30:
+	case 41:
+		switch a {
+		case ";":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "|":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "(":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case ")":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "[":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "]":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "{":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "}":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "{{":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "}}":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case ">":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "%":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "%%":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case ",":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "IDENT":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "TOKEN":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "STRING":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "ISTRING":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "LABEL":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "FIELD":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		case "CODE":
+			return lr.REDUCE, 25, nil // REDUCE rhs → "[" rhs "]"
+		}
+
+	case 42:
+		switch a {
+		case ";":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "|":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "(":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case ")":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "[":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "]":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "{":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "}":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "{{":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "}}":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case ">":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "%":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "%%":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case ",":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "IDENT":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "TOKEN":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "STRING":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "ISTRING":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "LABEL":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "FIELD":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		case "CODE":
+			return lr.REDUCE, 76, nil // REDUCE rhs → rhs "|" "CODE"
+		}
+
+	case 43:
+		switch a {
+		case ";":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "|":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "(":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case ")":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "[":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "]":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "{":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "}":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "{{":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "}}":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case ">":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "%":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "%%":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case ",":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "IDENT":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "TOKEN":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "STRING":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "ISTRING":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "LABEL":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "FIELD":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		case "CODE":
+			return lr.REDUCE, 26, nil // REDUCE rhs → "{" rhs "}"
+		}
+
+	case 44:
+		switch a {
+		case ";":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "|":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "(":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case ")":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "[":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "]":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "{":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "}":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "{{":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "}}":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case ">":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "%":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "%%":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case ",":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "IDENT":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "TOKEN":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "STRING":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "ISTRING":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "LABEL":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "FIELD":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		case "CODE":
+			return lr.REDUCE, 27, nil // REDUCE rhs → "{{" rhs "}}"
+		}
+
+	case 45:
+		switch a {
+		case ";":
+			return lr.REDUCE, 20, nil // REDUCE rule → lhs "=" rhs
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case ">":
+			return lr.REDUCE, 20, nil // REDUCE rule → lhs "=" rhs
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 46:
+		switch a {
+		case ";":
+			return lr.REDUCE, 77, nil // REDUCE rule → lhs "=" "CODE"
+		case ">":
+			return lr.REDUCE, 77, nil // REDUCE rule → lhs "=" "CODE"
+		}
+
+	case 47:
+		switch a {
+		case ";":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "<":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@left":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@right":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@none":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@mode":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@skip":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@whitespace":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@indent":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@priority":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@fragment":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@start":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "import":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@override":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@remove":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "@external":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "IDENT":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "TOKEN":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "STRING":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "ISTRING":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case "CODE":
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		case grammar.Endmarker:
+			return lr.REDUCE, 19, nil // REDUCE rule_handle → "<" rule ">"
+		}
+
+	case 48:
+		switch a {
+		case ";":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "}":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@left":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@right":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@none":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@mode":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@push":
+			return lr.SHIFT, 104, nil // SHIFT 104
+		case "@pop":
+			return lr.SHIFT, 106, nil // SHIFT 106
+		case "@switch":
+			return lr.SHIFT, 105, nil // SHIFT 105
+		case "@skip":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@whitespace":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@indent":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@priority":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@fragment":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@start":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "import":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@override":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@remove":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "@external":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "IDENT":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "TOKEN":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case "CODE":
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		case grammar.Endmarker:
+			return lr.REDUCE, 52, nil // REDUCE token → "TOKEN" "=" "ISTRING"
+		}
+
+	case 49:
+		switch a {
+		case ";":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "}":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@left":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@right":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@none":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@mode":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@push":
+			return lr.SHIFT, 104, nil // SHIFT 104
+		case "@pop":
+			return lr.SHIFT, 106, nil // SHIFT 106
+		case "@switch":
+			return lr.SHIFT, 105, nil // SHIFT 105
+		case "@skip":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@whitespace":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@indent":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@priority":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@fragment":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@start":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "import":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@override":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@remove":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "@external":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "IDENT":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "TOKEN":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case "CODE":
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		case grammar.Endmarker:
+			return lr.REDUCE, 11, nil // REDUCE token → "TOKEN" "=" "PREDEF"
+		}
+
+	case 50:
+		switch a {
+		case ";":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "}":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@left":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@right":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@none":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@mode":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@push":
+			return lr.SHIFT, 104, nil // SHIFT 104
+		case "@pop":
+			return lr.SHIFT, 106, nil // SHIFT 106
+		case "@switch":
+			return lr.SHIFT, 105, nil // SHIFT 105
+		case "@skip":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@whitespace":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@indent":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@priority":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@fragment":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@start":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "import":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@override":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@remove":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "@external":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "IDENT":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "TOKEN":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case "CODE":
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		case grammar.Endmarker:
+			return lr.REDUCE, 10, nil // REDUCE token → "TOKEN" "=" "REGEX"
+		}
+
+	case 51:
+		switch a {
+		case ";":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "}":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@left":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@right":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@none":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@mode":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@push":
+			return lr.SHIFT, 104, nil // SHIFT 104
+		case "@pop":
+			return lr.SHIFT, 106, nil // SHIFT 106
+		case "@switch":
+			return lr.SHIFT, 105, nil // SHIFT 105
+		case "@skip":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@whitespace":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@indent":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@priority":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@fragment":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@start":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "import":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@override":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@remove":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "@external":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "IDENT":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "TOKEN":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case "CODE":
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		case grammar.Endmarker:
+			return lr.REDUCE, 9, nil // REDUCE token → "TOKEN" "=" "STRING"
+		}
+
+	case 52:
+		switch a {
+		case ";":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "}":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@left":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@right":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@none":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@mode":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@skip":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@whitespace":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@indent":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@priority":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@fragment":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@start":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "import":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@override":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@remove":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "@external":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "IDENT":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "TOKEN":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case "CODE":
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		case grammar.Endmarker:
+			return lr.REDUCE, 42, nil // REDUCE action → "@push" "IDENT"
+		}
+
+	case 53:
+		switch a {
+		case ";":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "}":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@left":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@right":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@none":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@mode":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@skip":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@whitespace":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@indent":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@priority":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@fragment":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@start":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "import":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@override":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@remove":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "@external":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "IDENT":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "TOKEN":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case "CODE":
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		case grammar.Endmarker:
+			return lr.REDUCE, 44, nil // REDUCE action → "@switch" "IDENT"
+		}
+
+	case 54:
+		switch a {
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		}
+
+	case 55:
+		switch a {
+		case ";":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@left":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@right":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@none":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@mode":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@skip":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@whitespace":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@indent":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@priority":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@fragment":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@start":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "import":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@override":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@remove":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "@external":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "IDENT":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "TOKEN":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "STRING":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case "CODE":
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		case grammar.Endmarker:
+			return lr.REDUCE, 49, nil // REDUCE chars → chars "STRING"
+		}
+
+	case 56:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 57:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 58:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 59:
+		switch a {
+		case ";":
+			return lr.SHIFT, 23, nil // SHIFT 23
+		}
+
+	case 60:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@right":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@none":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@mode":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@skip":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@indent":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@priority":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@fragment":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@start":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "import":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@override":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@remove":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "@external":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "IDENT":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "TOKEN":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case "CODE":
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		case grammar.Endmarker:
+			return lr.REDUCE, 5, nil // REDUCE decl → directive semi_opt
+		}
+
+	case 61:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@right":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@none":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@mode":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@skip":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@indent":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@priority":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@fragment":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@start":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "import":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@override":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@remove":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "@external":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "IDENT":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "TOKEN":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case "CODE":
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		case grammar.Endmarker:
+			return lr.REDUCE, 58, nil // REDUCE decl → fragment semi_opt
+		}
+
+	case 62:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@right":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@none":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@mode":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@skip":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@indent":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@priority":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@fragment":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@start":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "import":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@override":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@remove":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "@external":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "IDENT":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "TOKEN":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case "CODE":
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		case grammar.Endmarker:
+			return lr.REDUCE, 4, nil // REDUCE decl → token semi_opt
+		}
+
+	case 63:
+		switch a {
+		case "=":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "<":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 64:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 65:
+		switch a {
+		case ";":
+			return lr.SHIFT, 26, nil // SHIFT 26
+		}
+
+	case 66:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 67:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@right":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@none":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@mode":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@skip":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@whitespace":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@indent":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@priority":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@fragment":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@start":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "import":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@override":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@remove":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "@external":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "IDENT":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "TOKEN":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case "CODE":
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		case grammar.Endmarker:
+			return lr.REDUCE, 78, nil // REDUCE decl → "CODE" semi_opt
+		}
+
+	case 68:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@right":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@none":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@mode":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@skip":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@whitespace":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@indent":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@priority":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@fragment":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@start":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "import":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@override":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@remove":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "@external":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "IDENT":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "TOKEN":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case "CODE":
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		case grammar.Endmarker:
+			return lr.REDUCE, 6, nil // REDUCE decl → rule ";"
+		}
+
+	case 69:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@right":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@none":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@mode":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@skip":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@whitespace":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@indent":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@priority":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@fragment":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@start":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "import":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@override":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@remove":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "@external":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "IDENT":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "TOKEN":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case "CODE":
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		case grammar.Endmarker:
+			return lr.REDUCE, 2, nil // REDUCE decls → decls decl
+		}
+
+	case 70:
+		switch a {
+		case ";":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@left":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@right":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@none":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@mode":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@skip":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@whitespace":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@indent":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@priority":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@fragment":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@start":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "import":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@override":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@remove":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "@external":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "IDENT":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case "TOKEN":
+			return lr.SHIFT, 78, nil // SHIFT 78
+		case "CODE":
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		case grammar.Endmarker:
+			return lr.REDUCE, 88, nil // REDUCE directive → "@external" externals
+		}
+
+	case 71:
+		switch a {
+		case ";":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "<":
+			return lr.SHIFT, 146, nil // SHIFT 146
+		case "@left":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@right":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@none":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@mode":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@skip":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@whitespace":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@indent":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@priority":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@fragment":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@start":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "import":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@override":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@remove":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "@external":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "IDENT":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "CODE":
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		case grammar.Endmarker:
+			return lr.REDUCE, 12, nil // REDUCE directive → "@left" handles
+		}
+
+	case 72:
+		switch a {
+		case ";":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "<":
+			return lr.SHIFT, 146, nil // SHIFT 146
+		case "@left":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@right":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@none":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@mode":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@skip":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@whitespace":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@indent":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@priority":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@fragment":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@start":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "import":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@override":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@remove":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "@external":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "IDENT":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "CODE":
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		case grammar.Endmarker:
+			return lr.REDUCE, 14, nil // REDUCE directive → "@none" handles
+		}
+
+	case 73:
+		switch a {
+		case ";":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@left":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@right":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@none":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@mode":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@skip":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@whitespace":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@indent":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@priority":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@fragment":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@start":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "import":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@override":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@remove":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "@external":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "IDENT":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case "TOKEN":
+			return lr.SHIFT, 88, nil // SHIFT 88
+		case "CODE":
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		case grammar.Endmarker:
+			return lr.REDUCE, 55, nil // REDUCE directive → "@priority" priorities
+		}
+
+	case 74:
+		switch a {
+		case ";":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "<":
+			return lr.SHIFT, 146, nil // SHIFT 146
+		case "@left":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@right":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@none":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@mode":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@skip":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@whitespace":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@indent":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@priority":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@fragment":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@start":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "import":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@override":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@remove":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "@external":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "IDENT":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "CODE":
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		case grammar.Endmarker:
+			return lr.REDUCE, 13, nil // REDUCE directive → "@right" handles
+		}
+
+	case 75:
+		switch a {
+		case ";":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@left":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@right":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@none":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@mode":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@skip":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@whitespace":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@indent":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@priority":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@fragment":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@start":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "import":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@override":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@remove":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "@external":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "IDENT":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case "TOKEN":
+			return lr.SHIFT, 101, nil // SHIFT 101
+		case "CODE":
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		case grammar.Endmarker:
+			return lr.REDUCE, 45, nil // REDUCE directive → "@skip" skips
+		}
+
+	case 76:
+		switch a {
+		case ";":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@left":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@right":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@none":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@mode":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@skip":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@whitespace":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@indent":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@priority":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@fragment":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@start":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "import":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@override":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@remove":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "@external":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "IDENT":
+			return lr.SHIFT, 102, nil // SHIFT 102
+		case "TOKEN":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case "CODE":
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		case grammar.Endmarker:
+			return lr.REDUCE, 62, nil // REDUCE directive → "@start" starts
+		}
+
+	case 77:
+		switch a {
+		case ";":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@left":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@right":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@none":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@mode":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@skip":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@whitespace":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@indent":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@priority":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@fragment":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@start":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "import":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@override":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@remove":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "@external":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "IDENT":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "TOKEN":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case "STRING":
+			return lr.SHIFT, 55, nil // SHIFT 55
+		case "CODE":
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		case grammar.Endmarker:
+			return lr.REDUCE, 48, nil // REDUCE directive → "@whitespace" chars
+		}
+
+	case 78:
+		switch a {
+		case ";":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@left":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@right":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@none":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@start":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "import":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@override":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "@external":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "IDENT":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "TOKEN":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		case grammar.Endmarker:
+			return lr.REDUCE, 89, nil // REDUCE externals → externals "TOKEN"
+		}
+
+	case 79:
+		switch a {
+		case "=":
+			return lr.SHIFT, 28, nil // SHIFT 28
+		}
+
+	case 80:
+		switch a {
+		case "@left":
+			return lr.SHIFT, 119, nil // SHIFT 119
+		case "@right":
+			return lr.SHIFT, 122, nil // SHIFT 122
+		case "@none":
+			return lr.SHIFT, 120, nil // SHIFT 120
+		case "@mode":
+			return lr.SHIFT, 133, nil // SHIFT 133
+		case "@skip":
+			return lr.SHIFT, 123, nil // SHIFT 123
+		case "@whitespace":
+			return lr.SHIFT, 125, nil // SHIFT 125
+		case "@indent":
+			return lr.SHIFT, 126, nil // SHIFT 126
+		case "@priority":
+			return lr.SHIFT, 121, nil // SHIFT 121
+		case "@fragment":
+			return lr.SHIFT, 128, nil // SHIFT 128
+		case "@start":
+			return lr.SHIFT, 124, nil // SHIFT 124
+		case "import":
+			return lr.SHIFT, 114, nil // SHIFT 114
+		case "@override":
+			return lr.SHIFT, 108, nil // SHIFT 108
+		case "@remove":
+			return lr.SHIFT, 109, nil // SHIFT 109
+		case "@external":
+			return lr.SHIFT, 118, nil // SHIFT 118
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 153, nil // SHIFT 153
+		case "CODE":
+			return lr.SHIFT, 115, nil // SHIFT 115
+		case grammar.Endmarker:
+			return lr.REDUCE, 0, nil // REDUCE grammar → name decls
+		}
+
+	case 81:
+		switch a {
+		case ";":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "<":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@left":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@right":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@none":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@mode":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@skip":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@whitespace":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@indent":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@priority":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@fragment":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@start":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "import":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@override":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@remove":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "@external":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "IDENT":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "TOKEN":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "STRING":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "ISTRING":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case "CODE":
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		case grammar.Endmarker:
+			return lr.REDUCE, 16, nil // REDUCE handles → handles rule_handle
+		}
+
+	case 82:
+		switch a {
+		case ";":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "<":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@left":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@right":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@none":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@mode":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@skip":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@whitespace":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@indent":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@priority":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@fragment":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@start":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "import":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@override":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@remove":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "@external":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "IDENT":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "TOKEN":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "STRING":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "ISTRING":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case "CODE":
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		case grammar.Endmarker:
+			return lr.REDUCE, 15, nil // REDUCE handles → handles term
+		}
+
+	case 83:
+		switch a {
+		case "TOKEN":
+			return lr.SHIFT, 136, nil // SHIFT 136
+		}
+
+	case 84:
+		switch a {
+		case "{":
+			return lr.SHIFT, 30, nil // SHIFT 30
+		}
+
+	case 85:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "}":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 86:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 87:
+		switch a {
+		case "TOKEN":
+			return lr.SHIFT, 33, nil // SHIFT 33
+		}
+
+	case 88:
+		switch a {
+		case ";":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@left":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@right":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@none":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@start":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "import":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@override":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "@external":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "IDENT":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "TOKEN":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		case grammar.Endmarker:
+			return lr.REDUCE, 56, nil // REDUCE priorities → priorities "TOKEN"
+		}
+
+	case 89:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "}":
+			return lr.SHIFT, 43, nil // SHIFT 43
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "%":
+			return lr.SHIFT, 34, nil // SHIFT 34
+		case "%%":
+			return lr.SHIFT, 35, nil // SHIFT 35
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 90:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "}}":
+			return lr.SHIFT, 44, nil // SHIFT 44
+		case "%":
+			return lr.SHIFT, 36, nil // SHIFT 36
+		case "%%":
+			return lr.SHIFT, 37, nil // SHIFT 37
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 91:
+		switch a {
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		}
+
+	case 92:
+		switch a {
+		case ";":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "|":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case ")":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "]":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "}":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "}}":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case ">":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "%":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "%%":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case ",":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.REDUCE, 29, nil // REDUCE rhs → rhs "|"
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 42, nil // SHIFT 42
+		}
+
+	case 93:
+		switch a {
+		case ";":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "|":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "(":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case ")":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "[":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "]":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "{":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "}":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "{{":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "}}":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case ">":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "%":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "%%":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case ",":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "IDENT":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "TOKEN":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "STRING":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "ISTRING":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "LABEL":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "FIELD":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		case "CODE":
+			return lr.REDUCE, 23, nil // REDUCE rhs → rhs rhs
+		}
+
+	case 94:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case ")":
+			return lr.SHIFT, 40, nil // SHIFT 40
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 95:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "]":
+			return lr.SHIFT, 41, nil // SHIFT 41
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 96:
+		switch a {
+		case ";":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "|":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "(":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case ")":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "[":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "]":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "{":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "}":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "{{":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "}}":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case ">":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "%":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "%%":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case ",":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "IDENT":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "TOKEN":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "STRING":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "ISTRING":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "LABEL":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "FIELD":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		case "CODE":
+			return lr.REDUCE, 61, nil // REDUCE rhs → "FIELD" rhs
+		}
+
+	case 97:
+		switch a {
+		case ";":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "|":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "(":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case ")":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "[":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "]":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "{":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "}":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "{{":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "}}":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case ">":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "%":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "%%":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case ",":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "IDENT":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "TOKEN":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "STRING":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "ISTRING":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "LABEL":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "FIELD":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		case "CODE":
+			return lr.REDUCE, 75, nil // REDUCE rhs → rhs "CODE"
+		}
+
+	case 98:
+		switch a {
+		case ";":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "|":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "(":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case ")":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "[":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "]":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "{":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "}":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "{{":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "}}":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case ">":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "%":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "%%":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case ",":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "IDENT":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "TOKEN":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "STRING":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "ISTRING":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "LABEL":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "FIELD":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		case "CODE":
+			return lr.REDUCE, 60, nil // REDUCE rhs → rhs "LABEL"
+		}
+
+	case 99:
 		switch a {
 		case ";":
 			return lr.REDUCE, 21, nil // REDUCE rule → lhs "="
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case ">":
 			return lr.REDUCE, 21, nil // REDUCE rule → lhs "="
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 46, nil // SHIFT 46
 		}
 
-	case 31:
+	case 100:
 		switch a {
 		case ">":
-			return lr.SHIFT, 9, nil // SHIFT 9
+			return lr.SHIFT, 47, nil // SHIFT 47
 		}
 
-	case 32:
+	case 101:
+		switch a {
+		case ";":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@left":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@right":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@none":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@start":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "import":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@override":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "@external":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "IDENT":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "TOKEN":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		case grammar.Endmarker:
+			return lr.REDUCE, 46, nil // REDUCE skips → skips "TOKEN"
+		}
+
+	case 102:
+		switch a {
+		case ";":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@left":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@right":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@none":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@mode":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@skip":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@whitespace":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@indent":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@priority":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@fragment":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@start":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "import":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@override":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@remove":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "@external":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "IDENT":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "TOKEN":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case "CODE":
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		case grammar.Endmarker:
+			return lr.REDUCE, 63, nil // REDUCE starts → starts "IDENT"
+		}
+
+	case 103:
 		switch a {
 		case "STRING":
-			return lr.SHIFT, 12, nil // SHIFT 12
+			return lr.SHIFT, 51, nil // SHIFT 51
+		case "ISTRING":
+			return lr.SHIFT, 48, nil // SHIFT 48
 		case "REGEX":
-			return lr.SHIFT, 11, nil // SHIFT 11
+			return lr.SHIFT, 50, nil // SHIFT 50
 		case "PREDEF":
-			return lr.SHIFT, 10, nil // SHIFT 10
+			return lr.SHIFT, 49, nil // SHIFT 49
 		}
 
-	case 33:
+	case 104:
 		switch a {
-		case ";":
+		case "IDENT":
+			return lr.SHIFT, 52, nil // SHIFT 52
+		}
+
+	case 105:
+		switch a {
+		case "IDENT":
 			return lr.SHIFT, 53, nil // SHIFT 53
+		}
+
+	case 106:
+		switch a {
+		case ";":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "}":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@left":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@right":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@none":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@mode":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@skip":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@whitespace":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@indent":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@priority":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@fragment":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@start":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "import":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@override":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@remove":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "@external":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "IDENT":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "TOKEN":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case "CODE":
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		case grammar.Endmarker:
+			return lr.REDUCE, 43, nil // REDUCE action → "@pop"
+		}
+
+	case 107:
+		switch a {
+		case "|":
+			return lr.SHIFT, 92, nil // SHIFT 92
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case ">":
+			return lr.REDUCE, 74, nil // REDUCE args → rhs
+		case ",":
+			return lr.REDUCE, 74, nil // REDUCE args → rhs
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "LABEL":
+			return lr.SHIFT, 98, nil // SHIFT 98
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		case "CODE":
+			return lr.SHIFT, 97, nil // SHIFT 97
+		}
+
+	case 108:
+		switch a {
+		case "@left":
+			return lr.SHIFT, 119, nil // SHIFT 119
+		case "@right":
+			return lr.SHIFT, 122, nil // SHIFT 122
+		case "@none":
+			return lr.SHIFT, 120, nil // SHIFT 120
+		case "@skip":
+			return lr.SHIFT, 123, nil // SHIFT 123
+		case "@whitespace":
+			return lr.SHIFT, 125, nil // SHIFT 125
+		case "@indent":
+			return lr.SHIFT, 126, nil // SHIFT 126
+		case "@priority":
+			return lr.SHIFT, 121, nil // SHIFT 121
+		case "@start":
+			return lr.SHIFT, 124, nil // SHIFT 124
+		case "@external":
+			return lr.SHIFT, 118, nil // SHIFT 118
+		case "IDENT":
+			return lr.REDUCE, 81, nil // REDUCE override → "@override"
+		case "TOKEN":
+			return lr.SHIFT, 153, nil // SHIFT 153
+		}
+
+	case 109:
+		switch a {
+		case "@left":
+			return lr.SHIFT, 119, nil // SHIFT 119
+		case "@right":
+			return lr.SHIFT, 122, nil // SHIFT 122
+		case "@none":
+			return lr.SHIFT, 120, nil // SHIFT 120
+		case "@skip":
+			return lr.SHIFT, 123, nil // SHIFT 123
+		case "@whitespace":
+			return lr.SHIFT, 125, nil // SHIFT 125
+		case "@indent":
+			return lr.SHIFT, 126, nil // SHIFT 126
+		case "@priority":
+			return lr.SHIFT, 121, nil // SHIFT 121
+		case "@start":
+			return lr.SHIFT, 124, nil // SHIFT 124
+		case "@external":
+			return lr.SHIFT, 118, nil // SHIFT 118
+		case "IDENT":
+			return lr.SHIFT, 63, nil // SHIFT 63
+		case "TOKEN":
+			return lr.SHIFT, 64, nil // SHIFT 64
+		}
+
+	case 110:
+		switch a {
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		}
+
+	case 111:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
 		case "@left":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "@right":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "@none":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "IDENT":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "TOKEN":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case grammar.Endmarker:
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		}
 
-	case 34:
+	case 112:
 		switch a {
 		case ";":
-			return lr.SHIFT, 53, nil // SHIFT 53
+			return lr.SHIFT, 147, nil // SHIFT 147
 		case "@left":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "@right":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "@none":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "IDENT":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case "TOKEN":
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		case grammar.Endmarker:
 			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
 		}
 
-	case 35:
+	case 113:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 114:
+		switch a {
+		case "STRING":
+			return lr.SHIFT, 66, nil // SHIFT 66
+		}
+
+	case 115:
+		switch a {
+		case ";":
+			return lr.SHIFT, 147, nil // SHIFT 147
+		case "@left":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@right":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@none":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@mode":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@skip":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@whitespace":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@indent":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@priority":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@fragment":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@start":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "import":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@override":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@remove":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "@external":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "IDENT":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "TOKEN":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case "CODE":
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 8, nil // REDUCE semi_opt → ε
+		}
+
+	case 116:
 		switch a {
 		case ";":
-			return lr.SHIFT, 15, nil // SHIFT 15
+			return lr.SHIFT, 68, nil // SHIFT 68
 		}
 
-	case 36:
+	case 117:
+		switch a {
+		case "@left":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@right":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@none":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@mode":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@skip":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@whitespace":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@indent":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@priority":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@fragment":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@start":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "import":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@override":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@remove":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "@external":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "IDENT":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "TOKEN":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case "CODE":
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		case grammar.Endmarker:
+			return lr.REDUCE, 35, nil // REDUCE decl → mode
+		}
+
+	case 118:
+		switch a {
+		case "TOKEN":
+			return lr.SHIFT, 127, nil // SHIFT 127
+		}
+
+	case 119:
 		switch a {
 		case "<":
-			return lr.SHIFT, 52, nil // SHIFT 52
+			return lr.SHIFT, 146, nil // SHIFT 146
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
 		}
 
-	case 37:
+	case 120:
 		switch a {
 		case "<":
-			return lr.SHIFT, 52, nil // SHIFT 52
+			return lr.SHIFT, 146, nil // SHIFT 146
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
 		}
 
-	case 38:
+	case 121:
+		switch a {
+		case "TOKEN":
+			return lr.SHIFT, 137, nil // SHIFT 137
+		}
+
+	case 122:
+		switch a {
+		case "<":
+			return lr.SHIFT, 146, nil // SHIFT 146
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		}
+
+	case 123:
+		switch a {
+		case "TOKEN":
+			return lr.SHIFT, 148, nil // SHIFT 148
+		}
+
+	case 124:
+		switch a {
+		case "IDENT":
+			return lr.SHIFT, 149, nil // SHIFT 149
+		}
+
+	case 125:
+		switch a {
+		case ";":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@left":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@right":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@none":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@mode":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@skip":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@whitespace":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@indent":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@priority":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@fragment":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@start":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "import":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@override":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@remove":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "@external":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "IDENT":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "TOKEN":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "STRING":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case "CODE":
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		case grammar.Endmarker:
+			return lr.REDUCE, 50, nil // REDUCE chars → ε
+		}
+
+	case 126:
+		switch a {
+		case ";":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@left":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@right":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@none":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@mode":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@skip":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@whitespace":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@indent":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@priority":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@fragment":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@start":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "import":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@override":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@remove":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "@external":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "IDENT":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "TOKEN":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case "CODE":
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		case grammar.Endmarker:
+			return lr.REDUCE, 51, nil // REDUCE directive → "@indent"
+		}
+
+	case 127:
+		switch a {
+		case ";":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@left":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@right":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@none":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@start":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "import":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@override":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "@external":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "IDENT":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "TOKEN":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		case grammar.Endmarker:
+			return lr.REDUCE, 90, nil // REDUCE externals → "TOKEN"
+		}
+
+	case 128:
 		switch a {
-		case "<":
-			return lr.SHIFT, 52, nil // SHIFT 52
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
-		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 79, nil // SHIFT 79
 		}
 
-	case 39:
+	case 129:
 		switch a {
 		case "@left":
 			return lr.REDUCE, 3, nil // REDUCE decls → ε
@@ -885,15 +4308,39 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 3, nil // REDUCE decls → ε
 		case "@none":
 			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@mode":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@skip":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@whitespace":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@indent":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@priority":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@fragment":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@start":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "import":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@override":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@remove":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "@external":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
 		case "IDENT":
 			return lr.REDUCE, 3, nil // REDUCE decls → ε
 		case "TOKEN":
 			return lr.REDUCE, 3, nil // REDUCE decls → ε
+		case "CODE":
+			return lr.REDUCE, 3, nil // REDUCE decls → ε
 		case grammar.Endmarker:
 			return lr.REDUCE, 3, nil // REDUCE decls → ε
 		}
 
-	case 40:
+	case 130:
 		switch a {
 		case ";":
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
@@ -905,17 +4352,43 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
 		case "@none":
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@mode":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@skip":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@whitespace":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@indent":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@priority":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@fragment":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@start":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "import":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@override":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@remove":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "@external":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
 		case "IDENT":
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
 		case "TOKEN":
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
 		case "STRING":
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "ISTRING":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
+		case "CODE":
+			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
 		case grammar.Endmarker:
 			return lr.REDUCE, 18, nil // REDUCE handles → rule_handle
 		}
 
-	case 41:
+	case 131:
 		switch a {
 		case ";":
 			return lr.REDUCE, 17, nil // REDUCE handles → term
@@ -927,29 +4400,63 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 17, nil // REDUCE handles → term
 		case "@none":
 			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@mode":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@skip":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@whitespace":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@indent":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@priority":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@fragment":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@start":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "import":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@override":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@remove":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "@external":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
 		case "IDENT":
 			return lr.REDUCE, 17, nil // REDUCE handles → term
 		case "TOKEN":
 			return lr.REDUCE, 17, nil // REDUCE handles → term
 		case "STRING":
 			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "ISTRING":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
+		case "CODE":
+			return lr.REDUCE, 17, nil // REDUCE handles → term
 		case grammar.Endmarker:
 			return lr.REDUCE, 17, nil // REDUCE handles → term
 		}
 
-	case 42:
+	case 132:
 		switch a {
 		case "=":
 			return lr.REDUCE, 22, nil // REDUCE lhs → nonterm
+		case "<":
+			return lr.SHIFT, 83, nil // SHIFT 83
 		}
 
-	case 43:
+	case 133:
 		switch a {
 		case "IDENT":
-			return lr.SHIFT, 23, nil // SHIFT 23
+			return lr.SHIFT, 84, nil // SHIFT 84
 		}
 
-	case 44:
+	case 134:
+		switch a {
+		case "IDENT":
+			return lr.SHIFT, 86, nil // SHIFT 86
+		}
+
+	case 135:
 		switch a {
 		case "=":
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
@@ -973,89 +4480,127 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
 		case "}}":
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "<":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
 		case ">":
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "%":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "%%":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case ",":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
 		case "IDENT":
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
 		case "TOKEN":
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
 		case "STRING":
 			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "ISTRING":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "LABEL":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "FIELD":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
+		case "CODE":
+			return lr.REDUCE, 32, nil // REDUCE nonterm → "IDENT"
 		}
 
-	case 45:
+	case 136:
 		switch a {
-		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
-		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
-		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
-		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
-		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
-		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
-		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+		case ">":
+			return lr.REDUCE, 71, nil // REDUCE params → "TOKEN"
+		case ",":
+			return lr.REDUCE, 71, nil // REDUCE params → "TOKEN"
 		}
 
-	case 46:
+	case 137:
 		switch a {
-		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
-		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
-		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
-		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+		case ";":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@left":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@right":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@none":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@start":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "import":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@override":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "@external":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
-		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
+		case grammar.Endmarker:
+			return lr.REDUCE, 57, nil // REDUCE priorities → "TOKEN"
 		}
 
-	case 47:
+	case 138:
 		switch a {
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
 		}
 
-	case 48:
+	case 139:
 		switch a {
 		case "(":
-			return lr.SHIFT, 45, nil // SHIFT 45
+			return lr.SHIFT, 141, nil // SHIFT 141
 		case "[":
-			return lr.SHIFT, 46, nil // SHIFT 46
+			return lr.SHIFT, 142, nil // SHIFT 142
 		case "{":
-			return lr.SHIFT, 47, nil // SHIFT 47
+			return lr.SHIFT, 138, nil // SHIFT 138
 		case "{{":
-			return lr.SHIFT, 48, nil // SHIFT 48
+			return lr.SHIFT, 139, nil // SHIFT 139
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		case "TOKEN":
-			return lr.SHIFT, 55, nil // SHIFT 55
+			return lr.SHIFT, 152, nil // SHIFT 152
 		case "STRING":
-			return lr.SHIFT, 54, nil // SHIFT 54
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
 		}
 
-	case 49:
+	case 140:
 		switch a {
 		case ";":
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
@@ -1077,17 +4622,99 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
 		case "}}":
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "<":
+			return lr.SHIFT, 91, nil // SHIFT 91
 		case ">":
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "%":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "%%":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case ",":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
 		case "IDENT":
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
 		case "TOKEN":
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
 		case "STRING":
 			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "ISTRING":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "LABEL":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "FIELD":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
+		case "CODE":
+			return lr.REDUCE, 30, nil // REDUCE rhs → nonterm
 		}
 
-	case 50:
+	case 141:
+		switch a {
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		}
+
+	case 142:
+		switch a {
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		}
+
+	case 143:
+		switch a {
+		case "(":
+			return lr.SHIFT, 141, nil // SHIFT 141
+		case "[":
+			return lr.SHIFT, 142, nil // SHIFT 142
+		case "{":
+			return lr.SHIFT, 138, nil // SHIFT 138
+		case "{{":
+			return lr.SHIFT, 139, nil // SHIFT 139
+		case "IDENT":
+			return lr.SHIFT, 135, nil // SHIFT 135
+		case "TOKEN":
+			return lr.SHIFT, 152, nil // SHIFT 152
+		case "STRING":
+			return lr.SHIFT, 151, nil // SHIFT 151
+		case "ISTRING":
+			return lr.SHIFT, 150, nil // SHIFT 150
+		case "FIELD":
+			return lr.SHIFT, 143, nil // SHIFT 143
+		}
+
+	case 144:
 		switch a {
 		case ";":
 			return lr.REDUCE, 31, nil // REDUCE rhs → term
@@ -1111,43 +4738,245 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 31, nil // REDUCE rhs → term
 		case ">":
 			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case "%":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case "%%":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case ",":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
 		case "IDENT":
 			return lr.REDUCE, 31, nil // REDUCE rhs → term
 		case "TOKEN":
 			return lr.REDUCE, 31, nil // REDUCE rhs → term
 		case "STRING":
 			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case "ISTRING":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case "LABEL":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case "FIELD":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
+		case "CODE":
+			return lr.REDUCE, 31, nil // REDUCE rhs → term
 		}
 
-	case 51:
+	case 145:
 		switch a {
 		case "=":
-			return lr.SHIFT, 30, nil // SHIFT 30
+			return lr.SHIFT, 99, nil // SHIFT 99
 		}
 
-	case 52:
+	case 146:
 		switch a {
 		case "IDENT":
-			return lr.SHIFT, 44, nil // SHIFT 44
+			return lr.SHIFT, 135, nil // SHIFT 135
 		}
 
-	case 53:
+	case 147:
 		switch a {
+		case "}":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		case "@left":
 			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		case "@right":
 			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		case "@none":
 			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@mode":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@skip":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@whitespace":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@indent":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@priority":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@fragment":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@start":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "import":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@override":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@remove":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "@external":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		case "IDENT":
 			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		case "TOKEN":
 			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
+		case "CODE":
+			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		case grammar.Endmarker:
 			return lr.REDUCE, 7, nil // REDUCE semi_opt → ";"
 		}
 
-	case 54:
+	case 148:
+		switch a {
+		case ";":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@left":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@right":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@none":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@start":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "import":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@override":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "@external":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "IDENT":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "TOKEN":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		case grammar.Endmarker:
+			return lr.REDUCE, 47, nil // REDUCE skips → "TOKEN"
+		}
+
+	case 149:
+		switch a {
+		case ";":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@left":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@right":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@none":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@mode":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@skip":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@whitespace":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@indent":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@priority":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@fragment":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@start":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "import":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@override":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@remove":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "@external":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "IDENT":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "TOKEN":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case "CODE":
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		case grammar.Endmarker:
+			return lr.REDUCE, 64, nil // REDUCE starts → "IDENT"
+		}
+
+	case 150:
+		switch a {
+		case ";":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "|":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "(":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case ")":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "[":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "]":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "{":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "}":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "{{":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "}}":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "<":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case ">":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "%":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "%%":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case ",":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@left":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@right":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@none":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@mode":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@skip":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@whitespace":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@indent":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@priority":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@fragment":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@start":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "import":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@override":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@remove":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "@external":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "IDENT":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "TOKEN":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "STRING":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "ISTRING":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "LABEL":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "FIELD":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case "CODE":
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		case grammar.Endmarker:
+			return lr.REDUCE, 54, nil // REDUCE term → "ISTRING"
+		}
+
+	case 151:
 		switch a {
 		case ";":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
@@ -1173,23 +5002,59 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case ">":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "%":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "%%":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case ",":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case "@left":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case "@right":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case "@none":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@mode":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@skip":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@whitespace":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@indent":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@priority":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@fragment":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@start":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "import":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@override":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@remove":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "@external":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case "IDENT":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case "TOKEN":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case "STRING":
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "ISTRING":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "LABEL":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "FIELD":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
+		case "CODE":
+			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		case grammar.Endmarker:
 			return lr.REDUCE, 34, nil // REDUCE term → "STRING"
 		}
 
-	case 55:
+	case 152:
 		switch a {
 		case ";":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
@@ -1215,26 +5080,62 @@ func ACTION(s int, a grammar.Terminal) (lr.ActionType, int, error) {
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case ">":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "%":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "%%":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case ",":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case "@left":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case "@right":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case "@none":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@mode":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@skip":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@whitespace":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@indent":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@priority":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@fragment":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@start":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "import":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@override":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@remove":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "@external":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case "IDENT":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case "TOKEN":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case "STRING":
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "ISTRING":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "LABEL":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "FIELD":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
+		case "CODE":
+			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		case grammar.Endmarker:
 			return lr.REDUCE, 33, nil // REDUCE term → "TOKEN"
 		}
 
-	case 56:
+	case 153:
 		switch a {
 		case "=":
-			return lr.SHIFT, 32, nil // SHIFT 32
+			return lr.SHIFT, 103, nil // SHIFT 103
 		}
 
 	}
@@ -1250,241 +5151,545 @@ func GOTO(s int, A grammar.NonTerminal) int {
 		case "grammar":
 			return 1
 		case "name":
-			return 39
+			return 129
 		}
 
-	case 3:
+	case 9:
+		switch A {
+		case "token":
+			return 85
+		}
+
+	case 10:
 		switch A {
 		case "rhs":
-			return 25
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 8:
+	case 11:
 		switch A {
 		case "rhs":
-			return 25
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 17:
+	case 12:
 		switch A {
-		case "rule_handle":
-			return 21
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 13:
+		switch A {
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 19:
+		switch A {
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 30:
+		switch A {
+		case "mode_decls":
+			return 9
+		}
+
+	case 34:
+		switch A {
+		case "rhs":
+			return 10
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 35:
+		switch A {
+		case "rhs":
+			return 11
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 36:
+		switch A {
+		case "rhs":
+			return 12
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 37:
+		switch A {
+		case "rhs":
+			return 13
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 39:
+		switch A {
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 45:
+		switch A {
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 48:
+		switch A {
+		case "action":
+			return 15
+		}
+
+	case 49:
+		switch A {
+		case "action":
+			return 16
+		}
+
+	case 50:
+		switch A {
+		case "action":
+			return 17
+		}
+
+	case 51:
+		switch A {
+		case "action":
+			return 18
+		}
+
+	case 54:
+		switch A {
+		case "rhs":
+			return 19
+		case "nonterm":
+			return 140
 		case "term":
+			return 144
+		}
+
+	case 56:
+		switch A {
+		case "semi_opt":
+			return 20
+		}
+
+	case 57:
+		switch A {
+		case "semi_opt":
+			return 21
+		}
+
+	case 58:
+		switch A {
+		case "semi_opt":
 			return 22
 		}
 
-	case 18:
+	case 63:
+		switch A {
+		case "semi_opt":
+			return 24
+		}
+
+	case 64:
+		switch A {
+		case "semi_opt":
+			return 25
+		}
+
+	case 66:
+		switch A {
+		case "semi_opt":
+			return 27
+		}
+
+	case 71:
 		switch A {
 		case "rule_handle":
-			return 21
+			return 81
 		case "term":
-			return 22
+			return 82
 		}
 
-	case 19:
+	case 72:
 		switch A {
 		case "rule_handle":
-			return 21
+			return 81
 		case "term":
-			return 22
+			return 82
 		}
 
-	case 20:
+	case 74:
+		switch A {
+		case "rule_handle":
+			return 81
+		case "term":
+			return 82
+		}
+
+	case 80:
 		switch A {
 		case "decl":
-			return 16
+			return 69
 		case "token":
-			return 34
+			return 113
+		case "mode":
+			return 117
 		case "directive":
-			return 33
+			return 111
+		case "fragment":
+			return 112
+		case "override":
+			return 110
 		case "rule":
-			return 35
+			return 116
 		case "lhs":
-			return 51
+			return 145
 		case "nonterm":
-			return 42
+			return 132
 		}
 
-	case 23:
+	case 83:
+		switch A {
+		case "params":
+			return 29
+		}
+
+	case 85:
 		switch A {
 		case "semi_opt":
-			return 2
+			return 31
 		}
 
-	case 24:
+	case 86:
+		switch A {
+		case "semi_opt":
+			return 32
+		}
+
+	case 89:
 		switch A {
 		case "rhs":
-			return 3
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 25:
+	case 90:
 		switch A {
 		case "rhs":
-			return 25
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 26:
+	case 91:
 		switch A {
+		case "args":
+			return 38
 		case "rhs":
-			return 25
+			return 107
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 27:
+	case 92:
 		switch A {
 		case "rhs":
-			return 25
+			return 39
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 28:
+	case 93:
 		switch A {
 		case "rhs":
-			return 25
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 29:
+	case 94:
 		switch A {
 		case "rhs":
-			return 25
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 30:
+	case 95:
 		switch A {
 		case "rhs":
-			return 8
+			return 93
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 33:
+	case 96:
+		switch A {
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 99:
+		switch A {
+		case "rhs":
+			return 45
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 107:
+		switch A {
+		case "rhs":
+			return 93
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 108:
+		switch A {
+		case "token":
+			return 57
+		case "directive":
+			return 56
+		}
+
+	case 109:
+		switch A {
+		case "directive":
+			return 58
+		case "rule":
+			return 65
+		case "lhs":
+			return 145
+		case "nonterm":
+			return 132
+		}
+
+	case 110:
+		switch A {
+		case "rule":
+			return 59
+		case "lhs":
+			return 145
+		case "nonterm":
+			return 132
+		}
+
+	case 111:
 		switch A {
 		case "semi_opt":
-			return 13
+			return 60
 		}
 
-	case 34:
+	case 112:
 		switch A {
 		case "semi_opt":
-			return 14
+			return 61
 		}
 
-	case 36:
+	case 113:
+		switch A {
+		case "semi_opt":
+			return 62
+		}
+
+	case 115:
+		switch A {
+		case "semi_opt":
+			return 67
+		}
+
+	case 118:
+		switch A {
+		case "externals":
+			return 70
+		}
+
+	case 119:
 		switch A {
 		case "handles":
-			return 17
+			return 71
 		case "rule_handle":
-			return 40
+			return 130
 		case "term":
-			return 41
+			return 131
 		}
 
-	case 37:
+	case 120:
 		switch A {
 		case "handles":
-			return 18
+			return 72
 		case "rule_handle":
-			return 40
+			return 130
 		case "term":
-			return 41
+			return 131
 		}
 
-	case 38:
+	case 121:
+		switch A {
+		case "priorities":
+			return 73
+		}
+
+	case 122:
 		switch A {
 		case "handles":
-			return 19
+			return 74
 		case "rule_handle":
-			return 40
+			return 130
 		case "term":
-			return 41
+			return 131
 		}
 
-	case 39:
+	case 123:
+		switch A {
+		case "skips":
+			return 75
+		}
+
+	case 124:
+		switch A {
+		case "starts":
+			return 76
+		}
+
+	case 125:
+		switch A {
+		case "chars":
+			return 77
+		}
+
+	case 129:
 		switch A {
 		case "decls":
-			return 20
+			return 80
 		}
 
-	case 45:
+	case 138:
 		switch A {
 		case "rhs":
-			return 26
+			return 89
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 46:
+	case 139:
 		switch A {
 		case "rhs":
-			return 27
+			return 90
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 47:
+	case 141:
 		switch A {
 		case "rhs":
-			return 28
+			return 94
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 48:
+	case 142:
 		switch A {
 		case "rhs":
-			return 29
+			return 95
 		case "nonterm":
-			return 49
+			return 140
 		case "term":
-			return 50
+			return 144
 		}
 
-	case 52:
+	case 143:
+		switch A {
+		case "rhs":
+			return 96
+		case "nonterm":
+			return 140
+		case "term":
+			return 144
+		}
+
+	case 146:
 		switch A {
 		case "rule":
-			return 31
+			return 100
 		case "lhs":
-			return 51
+			return 145
 		case "nonterm":
-			return 42
+			return 132
 		}
 
 	}
@@ -0,0 +1,270 @@
+package spec
+
+import (
+	"fmt"
+	goparser "go/parser"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+)
+
+// CodeBlock is a block of Go code declared at the top level of a grammar.
+// It is copied verbatim into the generated code ahead of the semantic actions,
+// e.g., for the import declarations and the helper functions that the actions use.
+type CodeBlock struct {
+	Code string
+	Pos  *lexer.Position
+}
+
+// SemanticAction is a block of Go code attached to an alternative of a rule.
+// It is executed every time the production rule of the alternative is reduced.
+//
+// The code refers to the value of the left-hand side of the production rule as $$,
+// and to the values of the symbols in the body as $1, $2, and so on, or by their field names as $name.
+// Refs are the references in the code, resolved to the positions of the symbols and kept in the order they appear.
+type SemanticAction struct {
+	Code string
+	Pos  *lexer.Position
+	Refs []*ActionRef
+}
+
+// ActionRef is a reference to a value in the code of a semantic action.
+// Offset and Length locate the reference in the code.
+// Index is zero for the left-hand side of the production rule and i for the i-th symbol in its body.
+type ActionRef struct {
+	Offset int
+	Length int
+	Index  int
+}
+
+// Expand returns the code of a semantic action with each reference replaced by the name of its value.
+func (a *SemanticAction) Expand(name func(int) string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, ref := range a.Refs {
+		b.WriteString(a.Code[last:ref.Offset])
+		b.WriteString(name(ref.Index))
+		last = ref.Offset + ref.Length
+	}
+
+	b.WriteString(a.Code[last:])
+
+	return b.String()
+}
+
+// rawRef is a reference in the code of a semantic action before it is resolved.
+// The name is $ for $$, a number for $1, $2, and so on, or a field name.
+type rawRef struct {
+	offset int
+	length int
+	name   string
+}
+
+// parseAction resolves the references in the code of a semantic action attached to a production body
+// whose symbols are named by the given fields, and verifies the syntax of the code as the body of a Go function.
+// The code starts right after the opening {% of the CODE token at pos, so all reported errors point into the grammar.
+func parseAction(code string, pos *lexer.Position, α grammar.String[grammar.Symbol], fields []string) (*SemanticAction, error) {
+	var errs error
+
+	a := &SemanticAction{
+		Code: code,
+		Pos:  pos,
+	}
+
+	for _, r := range scanRefs(code) {
+		ref := &ActionRef{
+			Offset: r.offset,
+			Length: r.length,
+			Index:  -1,
+		}
+
+		if r.name == "$" {
+			ref.Index = 0
+		} else if n, err := strconv.Atoi(r.name); err == nil {
+			if n < 1 || n > len(α) {
+				errs = errors.Append(errs, fmt.Errorf("reference $%s out of range for %d symbols in action: %s", r.name, len(α), codePosition(pos, code, r.offset)))
+				continue
+			}
+			ref.Index = n
+		} else {
+			for i, name := range fields {
+				if name == r.name {
+					ref.Index = i + 1
+					break
+				}
+			}
+
+			if ref.Index == -1 {
+				errs = errors.Append(errs, fmt.Errorf("undefined reference $%s in action: %s", r.name, codePosition(pos, code, r.offset)))
+				continue
+			}
+		}
+
+		a.Refs = append(a.Refs, ref)
+	}
+
+	// References are replaced by placeholder identifiers of the same length, so the offsets of syntax errors are preserved.
+	placeholder := func(ref rawRef) string {
+		return strings.Repeat("_", ref.length)
+	}
+
+	if err := checkSyntax("package p\nfunc _() {\n", replaceRefs(code, placeholder), "\n}\n", pos); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return a, nil
+}
+
+// parseCodeBlock verifies the syntax of the code of a top-level code block as Go declarations.
+func parseCodeBlock(code string, pos *lexer.Position) (*CodeBlock, error) {
+	if err := checkSyntax("package p\n", code, "\n", pos); err != nil {
+		return nil, err
+	}
+
+	return &CodeBlock{
+		Code: code,
+		Pos:  pos,
+	}, nil
+}
+
+// checkSyntax parses the code of a CODE token at pos, wrapped in a header and a trailer, as a Go source file.
+// The positions of syntax errors are translated back into the grammar.
+func checkSyntax(header, code, trailer string, pos *lexer.Position) error {
+	src := header + code + trailer
+
+	_, err := goparser.ParseFile(token.NewFileSet(), "", src, goparser.SkipObjectResolution)
+	if err == nil {
+		return nil
+	}
+
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err
+	}
+
+	var errs error
+	for _, e := range list {
+		p := codePosition(pos, code, e.Pos.Offset-len(header))
+		errs = errors.Append(errs, fmt.Errorf("syntax error in Go code: %s: %s", e.Msg, p))
+	}
+
+	return errs
+}
+
+// codePosition returns the position in the grammar for an offset in the code of a CODE token at pos.
+// Offsets outside of the code, e.g., in a wrapper around it, are clamped to the code.
+func codePosition(pos *lexer.Position, code string, offset int) *lexer.Position {
+	offset = min(max(offset, 0), len(code))
+	before := code[:offset]
+
+	// The code starts right after the opening {%.
+	p := &lexer.Position{
+		Filename: pos.Filename,
+		Offset:   pos.Offset + 2 + offset,
+		Line:     pos.Line,
+		Column:   pos.Column + 2,
+	}
+
+	if i := strings.LastIndexByte(before, '\n'); i >= 0 {
+		p.Line += strings.Count(before, "\n")
+		p.Column = 1 + utf8.RuneCountInString(before[i+1:])
+	} else {
+		p.Column += utf8.RuneCountInString(before)
+	}
+
+	return p
+}
+
+// replaceRefs returns the code of a semantic action with each reference replaced by the given function.
+func replaceRefs(code string, replace func(rawRef) string) string {
+	var b strings.Builder
+
+	last := 0
+	for _, ref := range scanRefs(code) {
+		b.WriteString(code[last:ref.offset])
+		b.WriteString(replace(ref))
+		last = ref.offset + ref.length
+	}
+
+	b.WriteString(code[last:])
+
+	return b.String()
+}
+
+// scanRefs finds the references in the code of a semantic action.
+// Comments, string literals, and rune literals are skipped, since a $ in them is not a reference.
+// A $ that is not followed by $, a number, or a name is left alone and reported by the Go parser as an illegal character.
+func scanRefs(code string) []rawRef {
+	var refs []rawRef
+
+	// skipTo returns the index right after the first occurrence of the terminator, or the end of the code.
+	// A backslash escapes the character following it if escapes are allowed.
+	skipTo := func(i int, terminator string, escapes bool) int {
+		for i < len(code) {
+			switch {
+			case escapes && code[i] == '\\':
+				i += 2
+			case strings.HasPrefix(code[i:], terminator):
+				return i + len(terminator)
+			default:
+				i++
+			}
+		}
+
+		return len(code)
+	}
+
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	isLetter := func(c byte) bool { return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+
+	for i := 0; i < len(code); {
+		switch {
+		case strings.HasPrefix(code[i:], "//"):
+			i = skipTo(i+2, "\n", false)
+		case strings.HasPrefix(code[i:], "/*"):
+			i = skipTo(i+2, "*/", false)
+		case code[i] == '"':
+			i = skipTo(i+1, `"`, true)
+		case code[i] == '\'':
+			i = skipTo(i+1, `'`, true)
+		case code[i] == '`':
+			i = skipTo(i+1, "`", false)
+
+		case code[i] == '$' && i+1 < len(code) && code[i+1] == '$':
+			refs = append(refs, rawRef{i, 2, "$"})
+			i += 2
+
+		case code[i] == '$' && i+1 < len(code) && isDigit(code[i+1]):
+			j := i + 1
+			for j < len(code) && isDigit(code[j]) {
+				j++
+			}
+			refs = append(refs, rawRef{i, j - i, code[i+1 : j]})
+			i = j
+
+		case code[i] == '$' && i+1 < len(code) && isLetter(code[i+1]):
+			j := i + 1
+			for j < len(code) && (isLetter(code[j]) || isDigit(code[j])) {
+				j++
+			}
+			refs = append(refs, rawRef{i, j - i, code[i+1 : j]})
+			i = j
+
+		default:
+			i++
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,229 @@
+package spec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemanticAction_Expand(t *testing.T) {
+	tests := []struct {
+		name         string
+		a            *SemanticAction
+		expectedCode string
+	}{
+		{
+			name: "NoRef",
+			a: &SemanticAction{
+				Code: " return nil, nil ",
+			},
+			expectedCode: " return nil, nil ",
+		},
+		{
+			name: "OK",
+			a: &SemanticAction{
+				Code: " $$ = $lhs.(int) + $3.(int) ",
+				Refs: []*ActionRef{
+					{Offset: 1, Length: 2, Index: 0},
+					{Offset: 6, Length: 4, Index: 1},
+					{Offset: 19, Length: 2, Index: 3},
+				},
+			},
+			expectedCode: " _0 = _1.(int) + _3.(int) ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			code := tc.a.Expand(func(i int) string {
+				return fmt.Sprintf("_%d", i)
+			})
+
+			assert.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	pos := &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 10}
+	α := grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}
+
+	tests := []struct {
+		name                 string
+		code                 string
+		fields               []string
+		expectedRefs         []*ActionRef
+		expectedErrorStrings []string
+	}{
+		{
+			name:   "Success",
+			code:   " $$ = $lhs.(int) + $3.(int) ",
+			fields: []string{"lhs", "", "rhs"},
+			expectedRefs: []*ActionRef{
+				{Offset: 1, Length: 2, Index: 0},
+				{Offset: 6, Length: 4, Index: 1},
+				{Offset: 19, Length: 2, Index: 3},
+			},
+		},
+		{
+			name: "SuccessWithLiterals",
+			code: " s := \"$1\" // $2\n $$ = s ",
+			expectedRefs: []*ActionRef{
+				{Offset: 18, Length: 2, Index: 0},
+			},
+		},
+		{
+			name: "OutOfRange",
+			code: " $$ = $4 ",
+			expectedErrorStrings: []string{
+				`reference $4 out of range for 3 symbols in action: test:3:18`,
+			},
+		},
+		{
+			name: "Undefined",
+			code: " $$ = $rhs ",
+			expectedErrorStrings: []string{
+				`undefined reference $rhs in action: test:3:18`,
+			},
+		},
+		{
+			name: "SyntaxError",
+			code: "\n  $$ = $1 +\n  ",
+			expectedErrorStrings: []string{
+				`syntax error in Go code: expected operand, found '}': test:5:3`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := parseAction(tc.code, pos, α, tc.fields)
+
+			if len(tc.expectedErrorStrings) > 0 {
+				assert.Nil(t, a)
+				assert.Error(t, err)
+
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.code, a.Code)
+				assert.Equal(t, pos, a.Pos)
+				assert.Equal(t, tc.expectedRefs, a.Refs)
+			}
+		})
+	}
+}
+
+func TestParseCodeBlock(t *testing.T) {
+	pos := &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 10}
+
+	tests := []struct {
+		name                 string
+		code                 string
+		expectedErrorStrings []string
+	}{
+		{
+			name: "Success",
+			code: ` import "strconv" `,
+		},
+		{
+			name: "SyntaxError",
+			code: ` import strconv `,
+			expectedErrorStrings: []string{
+				`syntax error in Go code: missing import path: test:3:28`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			block, err := parseCodeBlock(tc.code, pos)
+
+			if len(tc.expectedErrorStrings) > 0 {
+				assert.Nil(t, block)
+				assert.Error(t, err)
+
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.code, block.Code)
+				assert.Equal(t, pos, block.Pos)
+			}
+		})
+	}
+}
+
+func TestCodePosition(t *testing.T) {
+	pos := &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 10}
+
+	tests := []struct {
+		name        string
+		code        string
+		offset      int
+		expectedPos string
+	}{
+		{
+			name:        "SameLine",
+			code:        "ab\ncd",
+			offset:      1,
+			expectedPos: "test:3:13",
+		},
+		{
+			name:        "NextLine",
+			code:        "ab\ncd",
+			offset:      4,
+			expectedPos: "test:4:2",
+		},
+		{
+			name:        "OutOfCode",
+			code:        "ab",
+			offset:      10,
+			expectedPos: "test:3:14",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := codePosition(pos, tc.code, tc.offset)
+			assert.Equal(t, tc.expectedPos, p.String())
+		})
+	}
+}
+
+func TestScanRefs(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		expectedRefs []rawRef
+	}{
+		{
+			name:         "None",
+			code:         " return nil, nil ",
+			expectedRefs: nil,
+		},
+		{
+			name: "OK",
+			code: "$$ $1 $foo '$' `$2` /* $3 */ $ x",
+			expectedRefs: []rawRef{
+				{offset: 0, length: 2, name: "$"},
+				{offset: 3, length: 2, name: "1"},
+				{offset: 6, length: 4, name: "foo"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedRefs, scanRefs(tc.code))
+		})
+	}
+}
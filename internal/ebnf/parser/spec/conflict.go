@@ -0,0 +1,974 @@
+package spec
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/sort"
+)
+
+// maxSearchNodes bounds the number of nodes visited when searching for a lookahead-sensitive derivation.
+// When the bound is reached, the search is repeated without taking lookaheads into account.
+const maxSearchNodes = 100000
+
+// ConflictKind indicates the kind of an LR parsing conflict.
+type ConflictKind int
+
+const (
+	ShiftReduce ConflictKind = iota
+	ReduceReduce
+)
+
+// String returns a string representation of a conflict kind.
+func (k ConflictKind) String() string {
+	switch k {
+	case ShiftReduce:
+		return "Shift/Reduce"
+	case ReduceReduce:
+		return "Reduce/Reduce"
+	default:
+		return fmt.Sprintf("ConflictKind(%d)", int(k))
+	}
+}
+
+// Conflict describes an unresolved LR parsing conflict with a counterexample.
+// The counterexample consists of an example input and two competing derivations for it.
+// The state is numbered the same as in the parsing table (see parser.txt).
+type Conflict struct {
+	Kind     ConflictKind
+	State    int
+	Terminal grammar.Terminal
+	Example  string
+	First    *Derivation
+	Second   *Derivation
+}
+
+// String returns a human-readable explanation of a conflict.
+func (c *Conflict) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "%s conflict on %s:\n", c.Kind, c.Terminal)
+
+	if c.First == nil {
+		fmt.Fprintf(&b, "  No counterexample found in state %d.\n", c.State)
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "  Example: %s\n", c.Example)
+	fmt.Fprintf(&b, "  %s", c.First)
+
+	if c.Second != nil {
+		fmt.Fprintf(&b, "  %s", c.Second)
+	}
+
+	return b.String()
+}
+
+// Derivation is a chain of partially applied production rules, from the start symbol down to a conflicting item.
+type Derivation struct {
+	Action string
+	Steps  []*DerivationStep
+}
+
+// String returns a string representation of a derivation, one production rule per line.
+func (d *Derivation) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "%s derivation:\n", d.Action)
+	for i, step := range d.Steps {
+		fmt.Fprintf(&b, "    %s%s\n", strings.Repeat("  ", i), step)
+	}
+
+	return b.String()
+}
+
+// DerivationStep is a production rule with a marker (•) on the position where the derivation continues.
+type DerivationStep struct {
+	Production *grammar.Production
+	Dot        int
+	Pos        *lexer.Position
+
+	// describe returns a string representation of a grammar symbol.
+	// If not set, the name of the symbol is used.
+	describe func(grammar.Symbol) string
+}
+
+// String returns a string representation of a derivation step.
+func (s *DerivationStep) String() string {
+	var b bytes.Buffer
+
+	describe := s.describe
+	if describe == nil {
+		describe = grammar.Symbol.String
+	}
+
+	fmt.Fprintf(&b, "%s →", describe(s.Production.Head))
+	for i, X := range s.Production.Body {
+		if i == s.Dot {
+			b.WriteString(" •")
+		}
+		fmt.Fprintf(&b, " %s", describe(X))
+	}
+
+	if s.Dot == len(s.Production.Body) {
+		b.WriteString(" •")
+	}
+
+	if s.Pos != nil {
+		fmt.Fprintf(&b, "  <%s>", s.Pos)
+	}
+
+	return b.String()
+}
+
+// conflictRegex matches a conflict in the error returned by the parsing table construction,
+// e.g., Shift/Reduce conflict in ACTION[2, "+"].
+var conflictRegex = regexp.MustCompile(`((?:Shift|Reduce)(?:/Reduce)+) conflict in ACTION\[(\d+), (.+)\]`)
+
+// Conflicts finds the conflicts in the parsing table of the given kind that are not resolved by the precedences.
+// For each conflict, it searches for the shortest example input leading to the conflict
+// and two derivations explaining each of the competing actions.
+func (s *Spec) Conflicts(kind TableKind) []*Conflict {
+	_, err := s.buildParsingTable(kind)
+	return s.conflicts(kind, err)
+}
+
+// conflicts explains the conflicts reported by the parsing table construction in an error.
+// Only the conflicts left unresolved by the parsing table construction are explained,
+// and the states are numbered the same as the states in the parsing table.
+// If the conflicting items cannot be found in a state, the conflict is returned without a counterexample.
+func (s *Spec) conflicts(kind TableKind, err error) []*Conflict {
+	if err == nil {
+		return nil
+	}
+
+	matches := conflictRegex.FindAllStringSubmatch(err.Error(), -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	a := newAutomaton(kind, s.Grammar.Start, s.Productions(), s.Grammar.OrderTerminals())
+
+	var conflicts []*Conflict
+
+	for _, m := range matches {
+		i, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+
+		t, ok := a.lookup(m[3])
+		if !ok {
+			continue
+		}
+
+		ck := ReduceReduce
+		if strings.HasPrefix(m[1], "Shift") {
+			ck = ShiftReduce
+		}
+
+		term := a.terminals[t]
+
+		// A conflict that cannot be located in the automaton is reported without a counterexample
+		// rather than being explained with the items of an unrelated state.
+		if i >= len(a.states) {
+			conflicts = append(conflicts, &Conflict{Kind: ck, State: i, Terminal: term})
+			continue
+		}
+
+		I := a.states[i]
+
+		var shift *item
+		var reduces []item
+
+		for _, it := range I.items {
+			p := a.prods[it.prod]
+			if it.dot < len(p.Body) {
+				if X, ok := p.Body[it.dot].(grammar.Terminal); ok && X == term && shift == nil {
+					shift = &item{it.prod, it.dot}
+				}
+			} else if it.prod != 0 && I.lookaheads[it].has(t) {
+				reduces = append(reduces, it)
+			}
+		}
+
+		explained := false
+
+		if ck == ShiftReduce && shift != nil {
+			for _, r := range reduces {
+				conflicts = append(conflicts, a.explain(ShiftReduce, i, t, r, *shift, s))
+				explained = true
+			}
+		}
+
+		if strings.Count(m[1], "Reduce") > 1 {
+			for j := 0; j < len(reduces); j++ {
+				for k := j + 1; k < len(reduces); k++ {
+					conflicts = append(conflicts, a.explain(ReduceReduce, i, t, reduces[j], reduces[k], s))
+					explained = true
+				}
+			}
+		}
+
+		if !explained {
+			conflicts = append(conflicts, &Conflict{Kind: ck, State: i, Terminal: term})
+		}
+	}
+
+	return conflicts
+}
+
+// explainConflicts returns the text of an error returned by the parsing table construction,
+// followed by the explanations of the conflicts reported in it.
+// Synthesized non-terminals are represented by the EBNF constructs they are generated from.
+func (s *Spec) explainConflicts(kind TableKind, err error) string {
+	var b strings.Builder
+
+	b.WriteString(s.DescribeText(err.Error()))
+
+	conflicts := s.conflicts(kind, err)
+	if len(conflicts) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	for _, c := range conflicts {
+		fmt.Fprintf(&b, "\n%s", c)
+	}
+
+	return b.String()
+}
+
+// productionPos returns the position of a production rule in the EBNF source if known.
+func (s *Spec) productionPos(p *grammar.Production) *lexer.Position {
+	if s.Positions == nil {
+		return nil
+	}
+
+	pos, _ := s.Positions.Get(p)
+	return pos
+}
+
+/* ------------------------------------------------------------------------------------------------------------------------ */
+
+// bitset is a fixed-size set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) add(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(i%64)) != 0
+}
+
+func (b bitset) union(c bitset) bool {
+	changed := false
+	for i := range b {
+		if u := b[i] | c[i]; u != b[i] {
+			b[i], changed = u, true
+		}
+	}
+
+	return changed
+}
+
+func (b bitset) clone() bitset {
+	c := make(bitset, len(b))
+	copy(c, b)
+	return c
+}
+
+func (b bitset) key() string {
+	return fmt.Sprintf("%x", []uint64(b))
+}
+
+// item is an LR(0) item, a production rule with a dot at some position of its body.
+type item struct {
+	prod, dot int
+}
+
+// lrState is a state in the LR automaton together with the lookaheads of its items.
+type lrState struct {
+	kernel     []item
+	items      []item
+	next       map[grammar.Symbol]int
+	lookaheads map[item]bitset
+}
+
+// automaton is the LR automaton of a grammar for one of the parsing table construction algorithms.
+// It is built independently of the parsing table so conflicts can be analyzed without being resolved.
+// The states are numbered the same as the states in the parsing table.
+type automaton struct {
+	prods     []*grammar.Production
+	heads     map[grammar.NonTerminal][]int
+	terminals []grammar.Terminal
+	index     map[grammar.Terminal]int
+	nullable  map[grammar.NonTerminal]bool
+	first     map[grammar.NonTerminal]bitset
+	states    []*lrState
+}
+
+// newAutomaton builds the LR automaton of the given kind for a start symbol and a list of production rules.
+// The first production rule of the automaton is the augmented one for the start symbol.
+//
+// The SLR(1) and LALR(1) automata share the LR(0) states and only differ in the lookaheads of their items.
+// The canonical LR(1) automaton splits the states further by the lookaheads of their items.
+func newAutomaton(kind TableKind, S grammar.NonTerminal, prods []*grammar.Production, terminals []grammar.Terminal) *automaton {
+	aug := &grammar.Production{
+		Head: S + "′",
+		Body: grammar.String[grammar.Symbol]{S},
+	}
+
+	a := &automaton{
+		prods:     append([]*grammar.Production{aug}, prods...),
+		heads:     make(map[grammar.NonTerminal][]int),
+		terminals: append(append([]grammar.Terminal{}, terminals...), grammar.Endmarker),
+		index:     make(map[grammar.Terminal]int),
+		nullable:  make(map[grammar.NonTerminal]bool),
+		first:     make(map[grammar.NonTerminal]bitset),
+	}
+
+	for i, p := range a.prods {
+		a.heads[p.Head] = append(a.heads[p.Head], i)
+	}
+
+	for i, t := range a.terminals {
+		a.index[t] = i
+	}
+
+	a.computeFirst()
+
+	switch kind {
+	case SLR:
+		a.buildStates(false)
+		a.computeFollows()
+	case LR1:
+		a.buildStates(true)
+	default:
+		a.buildStates(false)
+		a.computeLookaheads()
+	}
+
+	a.numberStates(kind == LR1)
+
+	return a
+}
+
+// lookup returns the index of a terminal by its string representation.
+func (a *automaton) lookup(name string) (int, bool) {
+	for i, t := range a.terminals {
+		if t.String() == name {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// size returns the size of lookahead sets, including an extra element used as a propagation marker.
+func (a *automaton) size() int {
+	return len(a.terminals) + 1
+}
+
+// marker returns the element used as the propagation marker in lookahead sets.
+func (a *automaton) marker() int {
+	return len(a.terminals)
+}
+
+func (a *automaton) computeFirst() {
+	for A := range a.heads {
+		a.first[A] = newBitset(a.size())
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, p := range a.prods {
+			f, nullable := a.firstOf(p.Body)
+			if a.first[p.Head].union(f) {
+				changed = true
+			}
+
+			if nullable && !a.nullable[p.Head] {
+				a.nullable[p.Head], changed = true, true
+			}
+		}
+	}
+}
+
+// firstOf returns the set of terminals that begin the strings derived from α,
+// and whether or not α can derive the empty string.
+func (a *automaton) firstOf(α grammar.String[grammar.Symbol]) (bitset, bool) {
+	f := newBitset(a.size())
+
+	for _, X := range α {
+		switch X := X.(type) {
+		case grammar.Terminal:
+			if i, ok := a.index[X]; ok {
+				f.add(i)
+			}
+			return f, false
+
+		case grammar.NonTerminal:
+			if first, ok := a.first[X]; ok {
+				f.union(first)
+			}
+
+			if !a.nullable[X] {
+				return f, false
+			}
+		}
+	}
+
+	return f, true
+}
+
+func (a *automaton) closure(kernel []item) []item {
+	items := append([]item{}, kernel...)
+	visited := make(map[item]bool)
+	for _, it := range kernel {
+		visited[it] = true
+	}
+
+	for i := 0; i < len(items); i++ {
+		p := a.prods[items[i].prod]
+		if items[i].dot == len(p.Body) {
+			continue
+		}
+
+		if B, ok := p.Body[items[i].dot].(grammar.NonTerminal); ok {
+			for _, q := range a.heads[B] {
+				if it := (item{q, 0}); !visited[it] {
+					visited[it] = true
+					items = append(items, it)
+				}
+			}
+		}
+	}
+
+	return items
+}
+
+// buildStates builds the states of the automaton starting from the augmented item.
+// If canonical is true, states with the same items but different lookaheads are kept apart,
+// and the lookaheads are computed along with the states.
+func (a *automaton) buildStates(canonical bool) {
+	index := make(map[string]int)
+
+	add := func(kernel []item, lookaheads map[item]bitset) int {
+		sort.Quick(kernel, a.cmpItem)
+		key := fmt.Sprint(kernel)
+		if canonical {
+			for _, it := range kernel {
+				key += "/" + lookaheads[it].key()
+			}
+		}
+
+		if i, ok := index[key]; ok {
+			return i
+		}
+
+		I := &lrState{
+			kernel: kernel,
+			items:  a.closure(kernel),
+			next:   make(map[grammar.Symbol]int),
+		}
+
+		if canonical {
+			I.lookaheads = a.closure1(kernel, lookaheads)
+		}
+
+		index[key] = len(a.states)
+		a.states = append(a.states, I)
+
+		return len(a.states) - 1
+	}
+
+	L := newBitset(a.size())
+	L.add(a.index[grammar.Endmarker])
+	add([]item{{0, 0}}, map[item]bitset{{0, 0}: L})
+
+	for i := 0; i < len(a.states); i++ {
+		I := a.states[i]
+
+		// Group the items by the symbol after the dot, preserving the order of first appearance.
+		var symbols []grammar.Symbol
+		kernels := make(map[grammar.Symbol][]item)
+		lookaheads := make(map[grammar.Symbol]map[item]bitset)
+
+		for _, it := range I.items {
+			p := a.prods[it.prod]
+			if it.dot == len(p.Body) {
+				continue
+			}
+
+			X := p.Body[it.dot]
+			if _, ok := kernels[X]; !ok {
+				symbols = append(symbols, X)
+				lookaheads[X] = make(map[item]bitset)
+			}
+
+			next := item{it.prod, it.dot + 1}
+			kernels[X] = append(kernels[X], next)
+			if canonical {
+				lookaheads[X][next] = I.lookaheads[it].clone()
+			}
+		}
+
+		for _, X := range symbols {
+			I.next[X] = add(kernels[X], lookaheads[X])
+		}
+	}
+}
+
+// cmpItem compares two LR(0) items in the order used by the parsing table construction for numbering the states.
+// The augmented item comes first, then items with the dot further to the right, then items ordered by their production rules.
+func (a *automaton) cmpItem(lhs, rhs item) int {
+	if (lhs.prod == 0) != (rhs.prod == 0) {
+		if lhs.prod == 0 {
+			return -1
+		}
+		return 1
+	}
+
+	if lhs.dot != rhs.dot {
+		return rhs.dot - lhs.dot
+	}
+
+	return grammar.CmpProduction(a.prods[lhs.prod], a.prods[rhs.prod])
+}
+
+// cmpState compares two states by their kernel items,
+// and for the canonical LR(1) states, by the lookaheads of their kernel items.
+func (a *automaton) cmpState(lhs, rhs *lrState, canonical bool) int {
+	for i := 0; i < len(lhs.kernel) && i < len(rhs.kernel); i++ {
+		if c := a.cmpItem(lhs.kernel[i], rhs.kernel[i]); c != 0 {
+			return c
+		}
+
+		if canonical {
+			if c := a.cmpLookaheads(lhs.lookaheads[lhs.kernel[i]], rhs.lookaheads[rhs.kernel[i]]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return len(lhs.kernel) - len(rhs.kernel)
+}
+
+// cmpLookaheads compares two sets of lookaheads by their terminals in order.
+func (a *automaton) cmpLookaheads(lhs, rhs bitset) int {
+	var l, r []string
+	for i, t := range a.terminals {
+		if lhs.has(i) {
+			l = append(l, string(t))
+		}
+		if rhs.has(i) {
+			r = append(r, string(t))
+		}
+	}
+
+	sort.Quick(l, cmp.Compare[string])
+	sort.Quick(r, cmp.Compare[string])
+
+	for i := 0; i < len(l) && i < len(r); i++ {
+		if c := cmp.Compare(l[i], r[i]); c != 0 {
+			return c
+		}
+	}
+
+	return len(l) - len(r)
+}
+
+// numberStates renumbers the states the same way as the parsing table construction does.
+// The initial state remains the first state and the other states are sorted by their kernel items.
+func (a *automaton) numberStates(canonical bool) {
+	order := make([]int, len(a.states))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Quick(order[1:], func(i, j int) int {
+		return a.cmpState(a.states[i], a.states[j], canonical)
+	})
+
+	number := make([]int, len(a.states))
+	states := make([]*lrState, len(a.states))
+
+	for n, i := range order {
+		number[i] = n
+		states[n] = a.states[i]
+	}
+
+	for _, I := range states {
+		for X, next := range I.next {
+			I.next[X] = number[next]
+		}
+	}
+
+	a.states = states
+}
+
+// closure1 computes the closure of a set of LR(1) items, where each item has a set of lookaheads.
+func (a *automaton) closure1(items []item, lookaheads map[item]bitset) map[item]bitset {
+	res := make(map[item]bitset)
+	for _, it := range items {
+		res[it] = lookaheads[it].clone()
+	}
+
+	queue := append([]item{}, items...)
+
+	for len(queue) > 0 {
+		it := queue[0]
+		queue = queue[1:]
+
+		p := a.prods[it.prod]
+		if it.dot == len(p.Body) {
+			continue
+		}
+
+		B, ok := p.Body[it.dot].(grammar.NonTerminal)
+		if !ok {
+			continue
+		}
+
+		L, nullable := a.firstOf(p.Body[it.dot+1:])
+		if nullable {
+			L.union(res[it])
+		}
+
+		for _, q := range a.heads[B] {
+			next := item{q, 0}
+			if cur, ok := res[next]; !ok {
+				res[next] = L.clone()
+				queue = append(queue, next)
+			} else if cur.union(L) {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return res
+}
+
+// computeLookaheads computes the LALR(1) lookaheads by discovering spontaneously generated lookaheads
+// and propagating them through the LR(0) automaton until no more lookaheads can be added.
+func (a *automaton) computeLookaheads() {
+	type ref struct {
+		state int
+		it    item
+	}
+
+	for _, I := range a.states {
+		I.lookaheads = make(map[item]bitset)
+		for _, it := range I.kernel {
+			I.lookaheads[it] = newBitset(a.size())
+		}
+	}
+
+	a.states[0].lookaheads[item{0, 0}].add(a.index[grammar.Endmarker])
+
+	propagate := make(map[ref][]ref)
+
+	for i, I := range a.states {
+		for _, k := range I.kernel {
+			L := newBitset(a.size())
+			L.add(a.marker())
+
+			J := a.closure1([]item{k}, map[item]bitset{k: L})
+			for it, L := range J {
+				p := a.prods[it.prod]
+				if it.dot == len(p.Body) {
+					continue
+				}
+
+				to := ref{I.next[p.Body[it.dot]], item{it.prod, it.dot + 1}}
+
+				if L.has(a.marker()) {
+					propagate[ref{i, k}] = append(propagate[ref{i, k}], to)
+				}
+
+				spontaneous := L.clone()
+				spontaneous[a.marker()/64] &^= 1 << (a.marker() % 64)
+				a.states[to.state].lookaheads[to.it].union(spontaneous)
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for from, tos := range propagate {
+			L := a.states[from.state].lookaheads[from.it]
+			for _, to := range tos {
+				if a.states[to.state].lookaheads[to.it].union(L) {
+					changed = true
+				}
+			}
+		}
+	}
+
+	// Compute the lookaheads for the non-kernel items.
+	for _, I := range a.states {
+		I.lookaheads = a.closure1(I.kernel, I.lookaheads)
+	}
+}
+
+// computeFollows computes the SLR(1) lookaheads, where the lookaheads of an item are the FOLLOW set of its head.
+func (a *automaton) computeFollows() {
+	follow := make(map[grammar.NonTerminal]bitset)
+	for A := range a.heads {
+		follow[A] = newBitset(a.size())
+	}
+
+	follow[a.prods[0].Head].add(a.index[grammar.Endmarker])
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, p := range a.prods {
+			for i, X := range p.Body {
+				B, ok := X.(grammar.NonTerminal)
+				if !ok {
+					continue
+				}
+
+				f, nullable := a.firstOf(p.Body[i+1:])
+				if follow[B].union(f) {
+					changed = true
+				}
+
+				if nullable && follow[B].union(follow[p.Head]) {
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, I := range a.states {
+		I.lookaheads = make(map[item]bitset)
+		for _, it := range I.items {
+			I.lookaheads[it] = follow[a.prods[it.prod].Head].clone()
+		}
+	}
+}
+
+/* ------------------------------------------------------------------------------------------------------------------------ */
+
+// searchNode is a node in the search for a derivation.
+// A node is an item in a state (or a position in a sequence of states), with the precise set of terminals that can follow it.
+type searchNode struct {
+	state  int
+	it     item
+	follow bitset
+	parent *searchNode
+	// symbol is the symbol consumed from the parent node, or nil if the node is derived from the parent node.
+	symbol grammar.Symbol
+}
+
+// search finds the shortest path from the start item to a target item using breadth-first search.
+//
+// If seq is not nil, the path is restricted to the given sequence of symbols, and the state field of nodes
+// represents the number of symbols consumed. Otherwise, the path can be any path in the LR(0) automaton.
+//
+// If lookahead is not negative, the target item must be followed by the given lookahead terminal.
+// In this case, the set of terminals that can follow each node is tracked, otherwise it is left nil.
+func (a *automaton) search(target int, it item, lookahead int, seq []grammar.Symbol) *searchNode {
+	var follow bitset
+	if lookahead >= 0 {
+		follow = newBitset(a.size())
+		follow.add(a.index[grammar.Endmarker])
+	}
+
+	root := &searchNode{state: 0, it: item{0, 0}, follow: follow}
+	queue := []*searchNode{root}
+	visited := map[string]bool{}
+
+	for len(queue) > 0 {
+		if len(visited) > maxSearchNodes {
+			return nil
+		}
+
+		n := queue[0]
+		queue = queue[1:]
+
+		key := fmt.Sprintf("%d/%d/%d", n.state, n.it.prod, n.it.dot)
+		if n.follow != nil {
+			key += "/" + n.follow.key()
+		}
+
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		if n.state == target && n.it == it && (lookahead < 0 || n.follow.has(lookahead)) {
+			return n
+		}
+
+		p := a.prods[n.it.prod]
+		if n.it.dot == len(p.Body) {
+			continue
+		}
+
+		X := p.Body[n.it.dot]
+
+		// Consume the next symbol.
+		if seq == nil {
+			if next, ok := a.states[n.state].next[X]; ok {
+				queue = append(queue, &searchNode{next, item{n.it.prod, n.it.dot + 1}, n.follow, n, X})
+			}
+		} else if n.state < len(seq) && seq[n.state] == X {
+			queue = append(queue, &searchNode{n.state + 1, item{n.it.prod, n.it.dot + 1}, n.follow, n, X})
+		}
+
+		// Derive the next non-terminal.
+		if B, ok := X.(grammar.NonTerminal); ok {
+			var L bitset
+			if n.follow != nil {
+				var nullable bool
+				if L, nullable = a.firstOf(p.Body[n.it.dot+1:]); nullable {
+					L.union(n.follow)
+				}
+			}
+
+			for _, q := range a.heads[B] {
+				queue = append(queue, &searchNode{n.state, item{q, 0}, L, n, nil})
+			}
+		}
+	}
+
+	return nil
+}
+
+// searchAny finds a path to a target item, first with and then without taking the lookahead into account.
+func (a *automaton) searchAny(target int, it item, lookahead int, seq []grammar.Symbol) *searchNode {
+	if n := a.search(target, it, lookahead, seq); n != nil || lookahead < 0 {
+		return n
+	}
+
+	return a.search(target, it, -1, seq)
+}
+
+// path returns the nodes from the root to a node.
+func (n *searchNode) path() []*searchNode {
+	var path []*searchNode
+	for ; n != nil; n = n.parent {
+		path = append([]*searchNode{n}, path...)
+	}
+
+	return path
+}
+
+// symbols returns the sequence of symbols consumed on the path from the root to a node.
+func (n *searchNode) symbols() []grammar.Symbol {
+	var symbols []grammar.Symbol
+	for _, m := range n.path() {
+		if m.symbol != nil {
+			symbols = append(symbols, m.symbol)
+		}
+	}
+
+	return symbols
+}
+
+// derivation builds the chain of production rules from the root to a node.
+func (a *automaton) derivation(action string, n *searchNode, s *Spec) *Derivation {
+	var stack []*DerivationStep
+
+	for _, m := range n.path() {
+		switch {
+		case m.parent == nil || m.symbol == nil:
+			stack = append(stack, &DerivationStep{
+				Production: a.prods[m.it.prod],
+				describe:   s.Describe,
+			})
+		default:
+			stack[len(stack)-1].Dot++
+		}
+	}
+
+	// Skip the augmented production rule.
+	steps := stack[1:]
+	for _, step := range steps {
+		step.Pos = s.productionPos(step.Production)
+	}
+
+	return &Derivation{
+		Action: action,
+		Steps:  steps,
+	}
+}
+
+// example builds an example input for a derivation by combining the consumed symbols with the remaining ones.
+func (a *automaton) example(symbols []grammar.Symbol, d *Derivation, s *Spec) string {
+	parts := make([]string, 0, len(symbols)+1)
+	for _, X := range symbols {
+		parts = append(parts, s.Describe(X))
+	}
+
+	parts = append(parts, "•")
+
+	for i := len(d.Steps) - 1; i >= 0; i-- {
+		step := d.Steps[i]
+
+		// The innermost step continues from its dot, others continue after the derived non-terminal.
+		from := step.Dot
+		if i < len(d.Steps)-1 {
+			from++
+		}
+
+		for _, X := range step.Production.Body[from:] {
+			parts = append(parts, s.Describe(X))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// explain builds a counterexample for a conflict in a state on a terminal between two items.
+// The first item is always a reduce item.
+// The second item is a shift item for shift/reduce conflicts and another reduce item for reduce/reduce conflicts.
+func (a *automaton) explain(kind ConflictKind, state, t int, first, second item, s *Spec) *Conflict {
+	c := &Conflict{
+		Kind:     kind,
+		State:    state,
+		Terminal: a.terminals[t],
+	}
+
+	n1 := a.searchAny(state, first, t, nil)
+	if n1 == nil {
+		return c
+	}
+
+	// The second derivation must consume the same sequence of symbols as the first one.
+	seq := n1.symbols()
+
+	lookahead := t
+	action := "Reduce"
+	if kind == ShiftReduce {
+		lookahead, action = -1, "Shift"
+	}
+
+	n2 := a.searchAny(len(seq), second, lookahead, seq)
+
+	c.First = a.derivation("Reduce", n1, s)
+	c.Example = a.example(seq, c.First, s)
+
+	if n2 != nil {
+		c.Second = a.derivation(action, n2, s)
+	}
+
+	return c
+}
@@ -0,0 +1,401 @@
+package spec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser/lr"
+	"github.com/moorara/algo/symboltable"
+	"github.com/stretchr/testify/assert"
+)
+
+// parseSpec parses a grammar for a test and fails the test if the grammar is invalid.
+func parseSpec(t *testing.T, src string) *Spec {
+	s, err := Parse("test.grammar", strings.NewReader(src))
+	assert.NoError(t, err)
+
+	return s
+}
+
+func TestConflictKind_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		k              ConflictKind
+		expectedString string
+	}{
+		{
+			name:           "ShiftReduce",
+			k:              ShiftReduce,
+			expectedString: "Shift/Reduce",
+		},
+		{
+			name:           "ReduceReduce",
+			k:              ReduceReduce,
+			expectedString: "Reduce/Reduce",
+		},
+		{
+			name:           "Invalid",
+			k:              ConflictKind(9),
+			expectedString: "ConflictKind(9)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.k.String())
+		})
+	}
+}
+
+func TestDerivationStep_String(t *testing.T) {
+	prod := &grammar.Production{
+		Head: "E",
+		Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")},
+	}
+
+	tests := []struct {
+		name           string
+		s              *DerivationStep
+		expectedString string
+	}{
+		{
+			name: "DotAtStart",
+			s: &DerivationStep{
+				Production: prod,
+				Dot:        0,
+			},
+			expectedString: `E → • E "+" E`,
+		},
+		{
+			name: "DotAtEnd",
+			s: &DerivationStep{
+				Production: prod,
+				Dot:        3,
+			},
+			expectedString: `E → E "+" E •`,
+		},
+		{
+			name: "WithPosition",
+			s: &DerivationStep{
+				Production: prod,
+				Dot:        1,
+				Pos:        &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1},
+			},
+			expectedString: `E → E • "+" E  <test:2:1>`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.s.String())
+		})
+	}
+}
+
+func TestSpec_Conflicts(t *testing.T) {
+	tests := []struct {
+		name            string
+		s               *Spec
+		kind            TableKind
+		expectedKinds   []ConflictKind
+		expectedStates  []int
+		expectedStrings []string
+	}{
+		{
+			name: "SLR",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind:           SLR,
+			expectedKinds:  []ConflictKind{ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce},
+			expectedStates: []int{2, 2, 3, 3},
+			expectedStrings: []string{
+				`Shift/Reduce conflict on "+":`,
+				`Shift/Reduce conflict on "*":`,
+				`Example: E "+" E • "+" E`,
+				`Example: E "*" E • "*" E`,
+			},
+		},
+		{
+			name: "LALR",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind:           LALR,
+			expectedKinds:  []ConflictKind{ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce},
+			expectedStates: []int{2, 2, 3, 3},
+			expectedStrings: []string{
+				`Shift/Reduce conflict on "+":`,
+				`Shift/Reduce conflict on "*":`,
+				`Example: E "+" E • "+" E`,
+				`Example: E "*" E • "*" E`,
+				`Reduce derivation:`,
+				`Shift derivation:`,
+				`E → E "+" E •`,
+				`E → E • "+" E`,
+			},
+		},
+		{
+			name: "LR1",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind:           LR1,
+			expectedKinds:  []ConflictKind{ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce, ShiftReduce},
+			expectedStates: []int{2, 2, 3, 3, 4, 4, 5, 5},
+			expectedStrings: []string{
+				`Shift/Reduce conflict on "+":`,
+				`Shift/Reduce conflict on "*":`,
+				`Example: E "+" E • "+" E`,
+				`Example: "(" E "+" E • "+" E ")"`,
+			},
+		},
+		{
+			name:           "ReduceReduce",
+			s:              parseSpec(t, reduceReduceGrammar),
+			kind:           LALR,
+			expectedKinds:  []ConflictKind{ReduceReduce},
+			expectedStates: []int{2},
+			expectedStrings: []string{
+				`Reduce/Reduce conflict on ";":`,
+				`Example: "x" "y" "z" • ";"`,
+				`stmt → • a ";"  <test.grammar:3:1>`,
+				`a → "x" "y" "z" •  <test.grammar:4:1>`,
+				`stmt → • b ";"  <test.grammar:3:1>`,
+				`b → "x" "y" "z" •  <test.grammar:5:1>`,
+			},
+		},
+		{
+			name: "NoConflict",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: precedences[0],
+			},
+			kind:            LALR,
+			expectedKinds:   nil,
+			expectedStates:  nil,
+			expectedStrings: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conflicts := tc.s.Conflicts(tc.kind)
+
+			assert.Len(t, conflicts, len(tc.expectedKinds))
+
+			var s string
+			for i, c := range conflicts {
+				assert.Equal(t, tc.expectedKinds[i], c.Kind)
+				assert.Equal(t, tc.expectedStates[i], c.State)
+				assert.NotNil(t, c.First)
+				assert.NotNil(t, c.Second)
+				s += c.String()
+			}
+
+			for _, expectedString := range tc.expectedStrings {
+				assert.Contains(t, s, expectedString)
+			}
+		})
+	}
+}
+
+func TestSpec_explainConflicts(t *testing.T) {
+	origins := symboltable.NewQuadraticHashTable[grammar.NonTerminal, *Origin](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		symboltable.HashOpts{},
+	)
+
+	origins.Put("E", &Origin{
+		Text: `{ expr }`,
+		Pos:  &lexer.Position{Filename: "test", Offset: 40, Line: 4, Column: 12},
+	})
+
+	tests := []struct {
+		name              string
+		s                 *Spec
+		kind              TableKind
+		err               error
+		expectedStrings   []string
+		unexpectedStrings []string
+	}{
+		{
+			name: "NoConflict",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: LALR,
+			err:  errors.New("cannot build the parsing table"),
+			expectedStrings: []string{
+				`cannot build the parsing table`,
+			},
+		},
+		{
+			name: "OnlyReportedConflicts",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: LALR,
+			err:  errors.New(`1. Shift/Reduce conflict in ACTION[3, "+"]`),
+			expectedStrings: []string{
+				`1. Shift/Reduce conflict in ACTION[3, "+"]`,
+				`Shift/Reduce conflict on "+":`,
+				`Example: E "+" E • "+" E`,
+			},
+		},
+		{
+			name: "UnknownItems",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: LALR,
+			err:  errors.New(`1. Shift/Reduce conflict in ACTION[0, "+"]`),
+			expectedStrings: []string{
+				`1. Shift/Reduce conflict in ACTION[0, "+"]`,
+				`Shift/Reduce conflict on "+":`,
+				`No counterexample found in state 0.`,
+			},
+			unexpectedStrings: []string{
+				`Example:`,
+				`derivation:`,
+			},
+		},
+		{
+			name: "UnknownState",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: LALR,
+			err:  errors.New(`1. Shift/Reduce conflict in ACTION[99, "+"]`),
+			expectedStrings: []string{
+				`1. Shift/Reduce conflict in ACTION[99, "+"]`,
+				`Shift/Reduce conflict on "+":`,
+				`No counterexample found in state 99.`,
+			},
+			unexpectedStrings: []string{
+				`Example:`,
+				`derivation:`,
+			},
+		},
+		{
+			name: "SynthesizedNonTerminals",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+				Origins:     origins,
+			},
+			kind: LALR,
+			err:  errors.New("1. Shift/Reduce conflict in ACTION[3, \"+\"]\n   E → E \"+\" E •, E → E • \"+\" E"),
+			expectedStrings: []string{
+				`1. Shift/Reduce conflict in ACTION[3, "+"]`,
+				`{ expr }@test:4:12 → { expr }@test:4:12 "+" { expr }@test:4:12 •, { expr }@test:4:12 → { expr }@test:4:12 • "+" { expr }@test:4:12`,
+				`Example: { expr }@test:4:12 "+" { expr }@test:4:12 • "+" { expr }@test:4:12`,
+			},
+			unexpectedStrings: []string{
+				`E → E`,
+				`Synthesized Non-Terminals:`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			text := tc.s.explainConflicts(tc.kind, tc.err)
+
+			for _, expectedString := range tc.expectedStrings {
+				assert.Contains(t, text, expectedString)
+			}
+
+			for _, unexpectedString := range tc.unexpectedStrings {
+				assert.NotContains(t, text, unexpectedString)
+			}
+
+			assert.Equal(t, strings.Count(tc.err.Error(), "conflict in ACTION"), strings.Count(text, "conflict on"))
+		})
+	}
+}
+
+func TestNewAutomaton(t *testing.T) {
+	tests := []struct {
+		name string
+		G    *grammar.CFG
+		kind TableKind
+	}{
+		{name: "SLR", G: grammars[2], kind: SLR},
+		{name: "LALR", G: grammars[2], kind: LALR},
+		{name: "LR1", G: grammars[2], kind: LR1},
+		{name: "Indentation_SLR", G: grammars[5], kind: SLR},
+		{name: "Indentation_LALR", G: grammars[5], kind: LALR},
+		{name: "Indentation_LR1", G: grammars[5], kind: LR1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Spec{
+				Grammar:     tc.G,
+				Precedences: lr.PrecedenceLevels{},
+			}
+
+			T, err := s.buildParsingTable(tc.kind)
+			assert.NoError(t, err)
+
+			a := newAutomaton(tc.kind, tc.G.Start, s.Productions(), tc.G.OrderTerminals())
+			assert.Len(t, a.states, len(T.States))
+
+			for i, I := range a.states {
+				state := lr.State(i)
+
+				for X, next := range I.next {
+					switch X := X.(type) {
+					case grammar.Terminal:
+						action, err := T.ACTION(state, X)
+						if assert.NoError(t, err) {
+							assert.Equal(t, lr.SHIFT, action.Type)
+							assert.Equal(t, lr.State(next), action.State)
+						}
+
+					case grammar.NonTerminal:
+						n, err := T.GOTO(state, X)
+						if assert.NoError(t, err) {
+							assert.Equal(t, lr.State(next), n)
+						}
+					}
+				}
+
+				for _, it := range I.items {
+					if p := a.prods[it.prod]; it.dot == len(p.Body) {
+						for k, term := range a.terminals {
+							if !I.lookaheads[it].has(k) {
+								continue
+							}
+
+							action, err := T.ACTION(state, term)
+							if !assert.NoError(t, err) {
+								continue
+							}
+
+							if it.prod == 0 {
+								assert.Equal(t, lr.ACCEPT, action.Type)
+							} else {
+								assert.Equal(t, lr.REDUCE, action.Type)
+								assert.True(t, p.Equal(action.Production))
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
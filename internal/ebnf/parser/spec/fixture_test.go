@@ -70,6 +70,300 @@ var grammars = []*grammar.CFG{
 		},
 		"start",
 	),
+	// G2
+	grammar.NewCFG(
+		[]grammar.Terminal{"OPEN", "CLOSE", "ID", "NUM", "CHARS", "INTERP", "RBRACE"},
+		[]grammar.NonTerminal{"start", "value", "part", "gen_value_star", "gen_part_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star")}},
+			{Head: "gen_value_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star"), grammar.NonTerminal("value")}},
+			{Head: "gen_value_star", Body: grammar.E},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OPEN"), grammar.NonTerminal("gen_part_star"), grammar.Terminal("CLOSE")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "gen_part_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_part_star"), grammar.NonTerminal("part")}},
+			{Head: "gen_part_star", Body: grammar.E},
+			{Head: "part", Body: grammar.String[grammar.Symbol]{grammar.Terminal("CHARS")}},
+			{Head: "part", Body: grammar.String[grammar.Symbol]{grammar.Terminal("INTERP"), grammar.Terminal("ID"), grammar.Terminal("RBRACE")}},
+		},
+		"start",
+	),
+	// G3
+	grammar.NewCFG(
+		[]grammar.Terminal{"COMMENT", "SHEBANG", "ID", ";"},
+		[]grammar.NonTerminal{"start", "stmt", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal(";")}},
+		},
+		"start",
+	),
+	// G4
+	grammar.NewCFG(
+		[]grammar.Terminal{"EOL", "ID"},
+		[]grammar.NonTerminal{"start", "line", "gen_line_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_line_star")}},
+			{Head: "gen_line_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_line_star"), grammar.NonTerminal("line")}},
+			{Head: "gen_line_star", Body: grammar.E},
+			{Head: "line", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("EOL")}},
+		},
+		"start",
+	),
+	// G5
+	grammar.NewCFG(
+		[]grammar.Terminal{"ID", "NEWLINE", "INDENT", "DEDENT"},
+		[]grammar.NonTerminal{"start", "stmt", "gen_stmt_star", "gen_stmt_plus"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("NEWLINE")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("NEWLINE"), grammar.Terminal("INDENT"), grammar.NonTerminal("gen_stmt_plus"), grammar.Terminal("DEDENT")}},
+			{Head: "gen_stmt_plus", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_plus"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_plus", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("stmt")}},
+		},
+		"start",
+	),
+	// G6
+	grammar.NewCFG(
+		[]grammar.Terminal{"then", "ID", "BEGIN", "END"},
+		[]grammar.NonTerminal{"start", "stmt", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("BEGIN"), grammar.NonTerminal("gen_stmt_star"), grammar.Terminal("END")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("then")}},
+		},
+		"start",
+	),
+	// G7
+	grammar.NewCFG(
+		[]grammar.Terminal{"ID", "INT", "BUILTIN", "OCT_INT"},
+		[]grammar.NonTerminal{"start", "value", "gen_value_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star")}},
+			{Head: "gen_value_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star"), grammar.NonTerminal("value")}},
+			{Head: "gen_value_star", Body: grammar.E},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OCT_INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("BUILTIN")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+		},
+		"start",
+	),
+	// G8
+	grammar.NewCFG(
+		[]grammar.Terminal{"INT", "FLOAT"},
+		[]grammar.NonTerminal{"start", "value", "gen_value_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star")}},
+			{Head: "gen_value_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star"), grammar.NonTerminal("value")}},
+			{Head: "gen_value_star", Body: grammar.E},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("FLOAT")}},
+		},
+		"start",
+	),
+	// G9
+	grammar.NewCFG(
+		[]grammar.Terminal{"ID", "HEX_INT", "OCT_INT", "BIN_INT", "INT", "FLOAT", "STRING", "CHAR", "RAW", "LINE_COMMENT", "BLOCK_COMMENT"},
+		[]grammar.NonTerminal{"start", "value", "gen_value_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star")}},
+			{Head: "gen_value_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_value_star"), grammar.NonTerminal("value")}},
+			{Head: "gen_value_star", Body: grammar.E},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("HEX_INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OCT_INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("BIN_INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("INT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("FLOAT")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("STRING")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("CHAR")}},
+			{Head: "value", Body: grammar.String[grammar.Symbol]{grammar.Terminal("RAW")}},
+		},
+		"start",
+	),
+	// G10
+	grammar.NewCFG(
+		[]grammar.Terminal{"+", "-", "*", "/", "(", ")", "NUM"},
+		[]grammar.NonTerminal{"start", "expr"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("-"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("*"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("/"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("expr"), grammar.Terminal(")")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		},
+		"start",
+	),
+	// G11
+	grammar.NewCFG(
+		[]grammar.Terminal{"let", "=", ";", "print", "+", "-", "ID", "NUM"},
+		[]grammar.NonTerminal{"start", "stmt", "expr", "gen_stmt_star", "gen_expr_plus"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("let"), grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr"), grammar.Terminal(";")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("let"), grammar.Terminal("ID"), grammar.Terminal(";")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("print"), grammar.NonTerminal("gen_expr_plus"), grammar.Terminal(";")}},
+			{Head: "gen_expr_plus", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_expr_plus"), grammar.NonTerminal("expr")}},
+			{Head: "gen_expr_plus", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("-"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+		},
+		"start",
+	),
+	// G12
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "+", "-", "*", "/", "(", ")", "ID", "NUM", "$stmt", "$expr"},
+		[]grammar.NonTerminal{"stmt", "expr", "gen_entry"},
+		[]*grammar.Production{
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr"), grammar.Terminal(";")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("-"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("*"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("/"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("expr"), grammar.Terminal(")")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+			{Head: "gen_entry", Body: grammar.String[grammar.Symbol]{grammar.Terminal("$stmt"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_entry", Body: grammar.String[grammar.Symbol]{grammar.Terminal("$expr"), grammar.NonTerminal("expr")}},
+		},
+		"gen_entry",
+	),
+	// G13
+	grammar.NewCFG(
+		[]grammar.Terminal{";", "call", "(", ")", ",", "list", "[", "]", "ID"},
+		[]grammar.NonTerminal{"start", "stmt", "arg", "gen_stmt_list", "gen_arg_list", "gen1_list"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_list")}},
+			{Head: "gen_stmt_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_list"), grammar.Terminal(";"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("stmt")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("call"), grammar.Terminal("ID"), grammar.Terminal("("), grammar.NonTerminal("gen_arg_list"), grammar.Terminal(")")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("call"), grammar.Terminal("ID"), grammar.Terminal("("), grammar.Terminal(")")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("list"), grammar.Terminal("["), grammar.NonTerminal("gen1_list"), grammar.Terminal("]")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("list"), grammar.Terminal("["), grammar.NonTerminal("gen1_list"), grammar.Terminal(","), grammar.Terminal("]")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("list"), grammar.Terminal("["), grammar.Terminal("]")}},
+			{Head: "gen_arg_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_arg_list"), grammar.Terminal(","), grammar.NonTerminal("arg")}},
+			{Head: "gen_arg_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("arg")}},
+			{Head: "gen1_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen1_list"), grammar.Terminal(","), grammar.Terminal("ID")}},
+			{Head: "gen1_list", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+			{Head: "arg", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+		},
+		"start",
+	),
+	// G14
+	grammar.NewCFG(
+		[]grammar.Terminal{"let", ";", "call", "(", ")", ",", "{", "}", "=", "ID", "NUM"},
+		[]grammar.NonTerminal{"start", "binding", "expr", "gen_sep_binding_semi", "gen_sep_expr_comma", "gen_opt_gen_sep_expr_comma", "gen_block_start", "gen_start_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("let"), grammar.NonTerminal("gen_sep_binding_semi")}},
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("call"), grammar.Terminal("ID"), grammar.Terminal("("), grammar.NonTerminal("gen_opt_gen_sep_expr_comma"), grammar.Terminal(")")}},
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_block_start")}},
+			{Head: "binding", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "gen_sep_binding_semi", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("binding")}},
+			{Head: "gen_sep_binding_semi", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_sep_binding_semi"), grammar.Terminal(";"), grammar.NonTerminal("binding")}},
+			{Head: "gen_sep_expr_comma", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr")}},
+			{Head: "gen_sep_expr_comma", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_sep_expr_comma"), grammar.Terminal(","), grammar.NonTerminal("expr")}},
+			{Head: "gen_opt_gen_sep_expr_comma", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_sep_expr_comma")}},
+			{Head: "gen_opt_gen_sep_expr_comma", Body: grammar.E},
+			{Head: "gen_block_start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("{"), grammar.NonTerminal("gen_start_star"), grammar.Terminal("}")}},
+			{Head: "gen_start_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_start_star"), grammar.NonTerminal("start")}},
+			{Head: "gen_start_star", Body: grammar.E},
+		},
+		"start",
+	),
+	// G15
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "(", ")", "ID", "NUM", "error"},
+		[]grammar.NonTerminal{"start", "stmt", "expr", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr"), grammar.Terminal(";")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("error"), grammar.Terminal(";")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.Terminal("error"), grammar.Terminal(")")}},
+		},
+		"start",
+	),
+	// G16
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "+", "-", "*", "/", "(", ")", "ID", "NUM"},
+		[]grammar.NonTerminal{"start", "stmt", "expr", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr"), grammar.Terminal(";")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("-"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("*"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("/"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("expr"), grammar.Terminal(")")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+		},
+		"start",
+	),
+	// G17
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "+=", "+", "-", "*", "%", "<", "(", ")", "ID", "NUM"},
+		[]grammar.NonTerminal{"start", "stmt", "expr", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr"), grammar.Terminal(";")}},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("+="), grammar.NonTerminal("expr"), grammar.Terminal(";")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("-"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("*"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("%"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("<"), grammar.NonTerminal("expr")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("expr"), grammar.Terminal(")")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+		},
+		"start",
+	),
+	// G18
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "ID", "RAW_STRING", "COMMENT"},
+		[]grammar.NonTerminal{"start", "stmt", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.Terminal("RAW_STRING"), grammar.Terminal(";")}},
+		},
+		"start",
+	),
+	// G19
+	grammar.NewCFG(
+		[]grammar.Terminal{"ID", "NUM", "ASSIGN", "SEMI"},
+		[]grammar.NonTerminal{"start", "stmt", "gen_stmt_star"},
+		[]*grammar.Production{
+			{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star")}},
+			{Head: "gen_stmt_star", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_stmt_star"), grammar.NonTerminal("stmt")}},
+			{Head: "gen_stmt_star", Body: grammar.E},
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("ASSIGN"), grammar.Terminal("NUM"), grammar.Terminal("SEMI")}},
+		},
+		"start",
+	),
 }
 
 var precedences = []lr.PrecedenceLevels{
@@ -124,4 +418,34 @@ var precedences = []lr.PrecedenceLevels{
 			),
 		},
 	},
+	{ // 2
+		{
+			Associativity: lr.LEFT,
+			Handles: lr.NewPrecedenceHandles(
+				lr.PrecedenceHandleForTerminal("*"),
+				lr.PrecedenceHandleForTerminal("%"),
+			),
+		},
+		{
+			Associativity: lr.LEFT,
+			Handles: lr.NewPrecedenceHandles(
+				lr.PrecedenceHandleForTerminal("+"),
+				lr.PrecedenceHandleForTerminal("-"),
+			),
+		},
+		{
+			Associativity: lr.NONE,
+			Handles: lr.NewPrecedenceHandles(
+				lr.PrecedenceHandleForTerminal("<"),
+			),
+		},
+	},
 }
+
+// reduceReduceGrammar has a Reduce/Reduce conflict between a and b on ";".
+const reduceReduceGrammar = `grammar test;
+
+stmt = a ";" | b ";";
+a    = "x" "y" "z";
+b    = "x" "y" "z";
+`
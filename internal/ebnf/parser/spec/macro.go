@@ -0,0 +1,690 @@
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/sort"
+)
+
+// AddMacro records a definition of a parameterized rule and begins its body.
+// Until EndMacro is called, the parameters are recognized by IsParam
+// and the production rules of the body are recorded by AddMacroProduction.
+func (t *SymbolTable) AddMacro(A grammar.NonTerminal, params []string, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	def := &macroDef{
+		name:   A,
+		params: params,
+		pos:    pos,
+	}
+
+	if e, ok := t.macros.table.Get(A); ok {
+		e.definitions = append(e.definitions, def)
+	} else {
+		t.macros.table.Put(A, &macroEntry{
+			definitions: []*macroDef{def},
+		})
+	}
+
+	t.macros.current = def
+}
+
+// EndMacro ends the body of the parameterized rule that is being defined.
+func (t *SymbolTable) EndMacro() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.macros.current = nil
+}
+
+// InMacro returns true if the body of a parameterized rule is being defined.
+func (t *SymbolTable) InMacro() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.macros.current != nil
+}
+
+// IsParam returns true if a token name is a parameter of the parameterized rule that is being defined.
+func (t *SymbolTable) IsParam(a grammar.Terminal) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.macros.current != nil && slices.Contains(t.macros.current.params, string(a))
+}
+
+// AddMacroProduction records a production rule in the body of the parameterized rule that is being defined.
+// The production rule is a template; it is not added to the grammar until the parameterized rule is expanded.
+func (t *SymbolTable) AddMacroProduction(p *grammar.Production, fields []string, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	if def := t.macros.current; def != nil {
+		def.productions = append(def.productions, p)
+		def.fields = append(def.fields, fields)
+		def.occurrences = append(def.occurrences, pos)
+	}
+}
+
+// AddInstance records an instantiation of a parameterized rule with a list of arguments.
+// It returns the non-terminal symbol generated for the instantiation.
+// If the same parameterized rule was previously instantiated with the same arguments, the same non-terminal is reused.
+func (t *SymbolTable) AddInstance(A grammar.NonTerminal, args []Strings, text string, pos *lexer.Position) grammar.NonTerminal {
+	t.Lock()
+	defer t.Unlock()
+
+	var scope grammar.NonTerminal
+	if t.macros.current != nil {
+		scope = t.macros.current.name
+	}
+
+	return t.addInstance(A, args, text, scope, pos).NonTerminal
+}
+
+func (t *SymbolTable) addInstance(A grammar.NonTerminal, args []Strings, text string, scope grammar.NonTerminal, pos *lexer.Position) *instanceEntry {
+	for _, e := range t.macros.instances {
+		if e.Macro.Equal(A) && eqArgs(e.Args, args) {
+			e.scopes = append(e.scopes, scope)
+			e.occurrences = append(e.occurrences, pos)
+			return e
+		}
+	}
+
+	e := &instanceEntry{
+		Macro:       A,
+		Args:        args,
+		NonTerminal: t.mapArgsToNonTerminal(A, args),
+		Text:        text,
+		scopes:      []grammar.NonTerminal{scope},
+		occurrences: []*lexer.Position{pos},
+	}
+
+	t.macros.instances = append(t.macros.instances, e)
+
+	return e
+}
+
+// mapArgsToNonTerminal generates a non-terminal name for an instantiation of a parameterized rule.
+// If every argument is a single grammar symbol with a name, the names are used as part of the generated name.
+// Otherwise, a generic name with a unique index is created.
+func (t *SymbolTable) mapArgsToNonTerminal(A grammar.NonTerminal, args []Strings) grammar.NonTerminal {
+	names := make([]string, len(args))
+	for i, s := range args {
+		if len(s) == 1 && len(s[0]) == 1 {
+			switch v := s[0][0].(type) {
+			case grammar.NonTerminal:
+				names[i] = string(v)
+			case grammar.Terminal:
+				if alias, ok := terminalAliases[v]; ok {
+					names[i] = alias
+				} else if isName(string(v)) {
+					names[i] = string(v)
+				}
+			}
+		}
+	}
+
+	if !slices.Contains(names, "") {
+		name := grammar.NonTerminal(fmt.Sprintf("gen_%s_%s", A, strings.Join(names, "_")))
+
+		// The same name may be generated for distinct arguments, e.g., a token and a string with the same name.
+		taken := generic.AnyMatch(t.macros.instances, func(e *instanceEntry) bool {
+			return e.NonTerminal.Equal(name)
+		})
+
+		if !taken {
+			return name
+		}
+	}
+
+	t.strings.counter++
+	return grammar.NonTerminal(fmt.Sprintf("gen%d_%s", t.strings.counter, A))
+}
+
+// ExpandMacros replaces the instantiations of parameterized rules with concrete non-terminals and production rules.
+// Each parameter in the body of a parameterized rule is substituted with the corresponding argument,
+// and the non-terminals synthesized inside the body are generated anew for each instantiation.
+// The synthesized non-terminals record the instantiation or the EBNF construct they originate from.
+//
+// It reports an error if an instantiation does not match a parameterized rule,
+// or if the expansion does not terminate because an instantiation leads to ever larger arguments.
+func (t *SymbolTable) ExpandMacros() error {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.macros.table.Size() == 0 && len(t.macros.instances) == 0 {
+		return nil
+	}
+
+	var errs error
+
+	if err := t.ensureValidMacros(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	mentions := t.mentionedParams()
+
+	if err := t.ensureTerminatingMacros(mentions); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if errs != nil {
+		return errs
+	}
+
+	// New instantiations are appended while expanding, so the length is evaluated in every iteration.
+	for i := 0; i < len(t.macros.instances); i++ {
+		if e := t.macros.instances[i]; len(mentions[e.NonTerminal]) == 0 {
+			t.expandInstance(e, mentions)
+		}
+	}
+
+	t.removeTemplates(mentions)
+
+	return nil
+}
+
+// ensureValidMacros verifies that each parameterized rule is defined once,
+// is not defined or used as a regular non-terminal, and is instantiated with the right number of arguments.
+func (t *SymbolTable) ensureValidMacros() error {
+	var errs error
+
+	for A, e := range t.macros.table.All() {
+		if len(e.definitions) > 1 {
+			poses := generic.Transform(e.definitions, func(def *macroDef) string {
+				return fmt.Sprintf("  %s", def.pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple definitions for parameterized rule %s:\n%s", A, strings.Join(poses, "\n")),
+			)
+		}
+
+		var regular bool
+		for p, pe := range t.productions.table.All() {
+			if p.Head.Equal(A) && !regular {
+				regular = true
+				errs = errors.Append(errs,
+					fmt.Errorf("parameterized rule %s is also defined without parameters: %s", A, pe.occurrences[0]),
+				)
+			}
+
+			if containsNonTerminal(p.Body, A) {
+				errs = errors.Append(errs,
+					fmt.Errorf("parameterized rule %s is used without arguments: %s", A, pe.occurrences[0]),
+				)
+			}
+		}
+
+		for _, def := range e.definitions {
+			for i, p := range def.productions {
+				if containsNonTerminal(p.Body, A) {
+					errs = errors.Append(errs,
+						fmt.Errorf("parameterized rule %s is used without arguments: %s", A, def.occurrences[i]),
+					)
+				}
+			}
+		}
+	}
+
+	for _, e := range t.macros.instances {
+		m, ok := t.macros.table.Get(e.Macro)
+		for _, pos := range e.occurrences {
+			if !ok {
+				errs = errors.Append(errs, fmt.Errorf("undefined parameterized rule %s: %s", e.Macro, pos))
+			} else if n := len(m.definitions[0].params); n != len(e.Args) {
+				errs = errors.Append(errs,
+					fmt.Errorf("parameterized rule %s expects %d arguments, but %d are given: %s", e.Macro, n, len(e.Args), pos),
+				)
+			}
+		}
+	}
+
+	return errs
+}
+
+// mentionedParams finds the non-terminals that depend on the parameters of parameterized rules.
+// It returns, for each such non-terminal, the names of the parameters it mentions directly or indirectly.
+// These non-terminals are templates, i.e., they are generated anew for every instantiation.
+func (t *SymbolTable) mentionedParams() map[grammar.NonTerminal][]string {
+	mentions := map[grammar.NonTerminal][]string{}
+
+	for _, e := range t.macros.table.All() {
+		for _, def := range e.definitions {
+			for _, X := range def.params {
+				mentions[grammar.NonTerminal(X)] = []string{X}
+			}
+		}
+	}
+
+	mention := func(A grammar.NonTerminal, α grammar.String[grammar.Symbol]) bool {
+		var changed bool
+		for _, X := range α {
+			if B, ok := X.(grammar.NonTerminal); ok {
+				for _, name := range mentions[B] {
+					if !slices.Contains(mentions[A], name) {
+						mentions[A] = append(mentions[A], name)
+						changed = true
+					}
+				}
+			}
+		}
+
+		return changed
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for p := range t.productions.table.All() {
+			changed = mention(p.Head, p.Body) || changed
+		}
+
+		for _, e := range t.macros.instances {
+			for _, s := range e.Args {
+				for _, α := range s {
+					changed = mention(e.NonTerminal, α) || changed
+				}
+			}
+		}
+	}
+
+	return mentions
+}
+
+// ensureTerminatingMacros verifies that expanding the parameterized rules terminates.
+//
+// A graph is built whose nodes are the parameters of the parameterized rules.
+// An instantiation inside a parameterized rule adds an edge from each parameter of the enclosing rule
+// to each parameter of the instantiated rule whose argument mentions the former.
+// The edge is growing if the argument is anything other than the parameter itself.
+// The expansion does not terminate if a growing edge is part of a cycle,
+// since every round of the cycle instantiates a rule with a larger argument.
+func (t *SymbolTable) ensureTerminatingMacros(mentions map[grammar.NonTerminal][]string) error {
+	type node struct {
+		macro grammar.NonTerminal
+		param int
+	}
+
+	type edge struct {
+		to      node
+		growing bool
+		inst    *instanceEntry
+		pos     *lexer.Position
+	}
+
+	graph := map[node][]edge{}
+	var nodes []node
+
+	for _, e := range t.macros.instances {
+		for k, scope := range e.scopes {
+			m, ok := t.macros.table.Get(scope)
+			if !ok {
+				continue
+			}
+
+			params := m.definitions[0].params
+			for j, s := range e.Args {
+				for i, X := range params {
+					if !mentionsParam(s, X, mentions) {
+						continue
+					}
+
+					from := node{scope, i}
+					if _, ok := graph[from]; !ok {
+						nodes = append(nodes, from)
+					}
+
+					exact := len(s) == 1 && len(s[0]) == 1 && s[0][0] == grammar.Symbol(grammar.NonTerminal(X))
+					graph[from] = append(graph[from], edge{node{e.Macro, j}, !exact, e, e.occurrences[k]})
+				}
+			}
+		}
+	}
+
+	reachable := func(from, to node) bool {
+		visited := map[node]bool{from: true}
+		for queue := []node{from}; len(queue) > 0; queue = queue[1:] {
+			if queue[0] == to {
+				return true
+			}
+
+			for _, e := range graph[queue[0]] {
+				if !visited[e.to] {
+					visited[e.to] = true
+					queue = append(queue, e.to)
+				}
+			}
+		}
+
+		return false
+	}
+
+	var errs error
+
+	for _, from := range nodes {
+		for _, e := range graph[from] {
+			if e.growing && reachable(e.to, from) {
+				errs = errors.Append(errs,
+					fmt.Errorf("non-terminating expansion of parameterized rule %s in %s: %s", e.inst.Text, from.macro, e.pos),
+				)
+			}
+		}
+	}
+
+	return errs
+}
+
+// expandInstance adds the production rules of an instantiation of a parameterized rule with concrete arguments.
+func (t *SymbolTable) expandInstance(e *instanceEntry, mentions map[grammar.NonTerminal][]string) {
+	m, _ := t.macros.table.Get(e.Macro)
+	def := m.definitions[0]
+
+	s := &substitution{
+		t:         t,
+		mentions:  mentions,
+		args:      map[grammar.NonTerminal]Strings{},
+		generated: map[grammar.NonTerminal]grammar.NonTerminal{},
+	}
+
+	for i, X := range def.params {
+		s.args[grammar.NonTerminal(X)] = e.Args[i]
+	}
+
+	for i, p := range def.productions {
+		bodies, fields := s.string(p.Body, def.fields[i])
+		for j, β := range bodies {
+			q := &grammar.Production{Head: e.NonTerminal, Body: β}
+			t.addProduction(q, def.occurrences[i])
+
+			if fields[j] != nil {
+				t.addFields(q, fields[j], def.occurrences[i])
+			}
+		}
+	}
+}
+
+// removeTemplates removes the parameterized rules and the non-terminals that depend on their parameters,
+// along with their production rules and everything recorded for them.
+func (t *SymbolTable) removeTemplates(mentions map[grammar.NonTerminal][]string) {
+	isTemplate := func(A grammar.NonTerminal) bool {
+		_, ok := t.macros.table.Get(A)
+		return ok || len(mentions[A]) > 0
+	}
+
+	var prods []*grammar.Production
+	for p := range t.productions.table.All() {
+		if isTemplate(p.Head) {
+			prods = append(prods, p)
+		}
+	}
+
+	for _, p := range prods {
+		t.productions.table.Delete(p)
+		t.fields.table.Delete(p)
+	}
+
+	var nonTerms []grammar.NonTerminal
+	for A := range t.nonTerminals.table.All() {
+		if isTemplate(A) {
+			nonTerms = append(nonTerms, A)
+		}
+	}
+
+	for _, A := range nonTerms {
+		t.nonTerminals.table.Delete(A)
+		t.origins.table.Delete(A)
+	}
+
+	t.lists.nonTerminals = generic.SelectMatch(t.lists.nonTerminals, func(A grammar.NonTerminal) bool {
+		return !isTemplate(A)
+	})
+}
+
+// substitution substitutes the parameters of a parameterized rule with the arguments of an instantiation.
+// The non-terminals that depend on the parameters are generated anew and memoized for the instantiation.
+type substitution struct {
+	t         *SymbolTable
+	mentions  map[grammar.NonTerminal][]string
+	args      map[grammar.NonTerminal]Strings
+	generated map[grammar.NonTerminal]grammar.NonTerminal
+}
+
+// string substitutes the parameters in a grammar string along with the field names of its symbols.
+// An argument with more than one alternative results in more than one string.
+// A field name is kept for a parameter only if the argument substituted for it is a single symbol.
+func (s *substitution) string(α grammar.String[grammar.Symbol], fα []string) (Strings, [][]string) {
+	all := Strings{grammar.E}
+	fields := [][]string{nil}
+	if fα != nil {
+		fields[0] = []string{}
+	}
+
+	for i, X := range α {
+		var alts Strings
+		if A, ok := X.(grammar.NonTerminal); ok && s.args[A] != nil {
+			alts = s.args[A]
+		} else if ok && len(s.mentions[A]) > 0 {
+			alts = Strings{{s.nonTerminal(A)}}
+		} else {
+			alts = Strings{{X}}
+		}
+
+		nextAll := make(Strings, 0, len(all)*len(alts))
+		nextFields := make([][]string, 0, len(all)*len(alts))
+
+		for j, β := range all {
+			for _, γ := range alts {
+				nextAll = append(nextAll, β.Concat(γ))
+
+				if fα == nil {
+					nextFields = append(nextFields, nil)
+					continue
+				}
+
+				fγ := make([]string, len(γ))
+				if len(γ) == 1 {
+					fγ[0] = fα[i]
+				}
+
+				nextFields = append(nextFields, append(slices.Clone(fields[j]), fγ...))
+			}
+		}
+
+		all, fields = nextAll, nextFields
+	}
+
+	return all, fields
+}
+
+// strings substitutes the parameters in a list of grammar strings.
+func (s *substitution) strings(ss Strings) Strings {
+	var all Strings
+	for _, α := range ss {
+		bodies, _ := s.string(α, nil)
+		for _, β := range bodies {
+			if !all.Contains(β) {
+				all = append(all, β)
+			}
+		}
+	}
+
+	return all
+}
+
+// nonTerminal generates the concrete non-terminal for a non-terminal that depends on the parameters.
+// A nested instantiation becomes an instantiation with concrete arguments, which is expanded later.
+// A non-terminal synthesized for an EBNF construct is synthesized again for the substituted strings.
+func (s *substitution) nonTerminal(A grammar.NonTerminal) grammar.NonTerminal {
+	if B, ok := s.generated[A]; ok {
+		return B
+	}
+
+	t := s.t
+
+	for _, e := range t.macros.instances {
+		if e.NonTerminal.Equal(A) {
+			args := make([]Strings, len(e.Args))
+			texts := make([]string, len(e.Args))
+			for i, arg := range e.Args {
+				args[i] = s.strings(arg)
+				texts[i] = formatStrings(args[i])
+			}
+
+			text := fmt.Sprintf("%s<%s>", e.Macro, strings.Join(texts, ", "))
+			inst := t.addInstance(e.Macro, args, text, "", e.occurrences[0])
+			t.addNonTerminal(inst.NonTerminal, e.occurrences[0])
+			t.addOrigin(inst.NonTerminal, text, e.occurrences[0])
+			s.generated[A] = inst.NonTerminal
+
+			return inst.NonTerminal
+		}
+	}
+
+	// The strings are found first, since synthesizing the new non-terminal updates the table of strings.
+	var kind string
+	var ss, sep Strings
+
+	for k, e := range t.strings.table.All() {
+		if e.Star.Equal(A) {
+			kind, ss = "star", k
+		} else if e.Plus.Equal(A) {
+			kind, ss = "plus", k
+		}
+
+		for _, l := range e.Lists {
+			if l.List.Equal(A) {
+				kind, ss, sep = "list", k, l.Sep
+			}
+		}
+	}
+
+	var B grammar.NonTerminal
+	switch kind {
+	case "star":
+		B = t.getStar(s.strings(ss))
+	case "plus":
+		B = t.getPlus(s.strings(ss))
+	case "list":
+		B = t.getList(s.strings(ss), s.strings(sep))
+	}
+
+	s.generated[A] = B
+
+	if o, ok := t.origins.table.Get(A); ok {
+		t.addOrigin(B, o.Text, o.Pos)
+	}
+
+	if e, ok := t.nonTerminals.table.Get(A); ok {
+		t.addNonTerminal(B, e.occurrences[0])
+	}
+
+	// The production rules are substituted in the order they are added, so the generated names are deterministic.
+	type entry struct {
+		p *grammar.Production
+		e *productionEntry
+	}
+
+	var prods []entry
+	for p, e := range t.productions.table.All() {
+		if p.Head.Equal(A) {
+			prods = append(prods, entry{p, e})
+		}
+	}
+
+	sort.Quick(prods, func(lhs, rhs entry) int {
+		return lhs.e.index - rhs.e.index
+	})
+
+	for _, prod := range prods {
+		var fields []string
+		if e, ok := t.fields.table.Get(prod.p); ok {
+			fields = e.fields[0]
+		}
+
+		bodies, fieldsList := s.string(prod.p.Body, fields)
+		for i, β := range bodies {
+			q := &grammar.Production{Head: B, Body: β}
+			t.addProduction(q, prod.e.occurrences[0])
+
+			if fieldsList[i] != nil {
+				t.addFields(q, fieldsList[i], prod.e.occurrences[0])
+			}
+		}
+	}
+
+	return B
+}
+
+// mentionsParam returns true if a list of grammar strings mentions a parameter directly or indirectly.
+func mentionsParam(s Strings, param string, mentions map[grammar.NonTerminal][]string) bool {
+	for _, α := range s {
+		for _, X := range α {
+			if A, ok := X.(grammar.NonTerminal); ok && slices.Contains(mentions[A], param) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// containsNonTerminal returns true if a grammar string contains a non-terminal symbol.
+func containsNonTerminal(α grammar.String[grammar.Symbol], A grammar.NonTerminal) bool {
+	for _, X := range α {
+		if B, ok := X.(grammar.NonTerminal); ok && B.Equal(A) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eqArgs determines whether or not two lists of arguments are the same.
+func eqArgs(lhs, rhs []Strings) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i := range lhs {
+		if !eqStrings(lhs[i], rhs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatStrings returns the EBNF representation of a list of grammar strings as alternatives.
+func formatStrings(s Strings) string {
+	alts := generic.Transform(s, func(α grammar.String[grammar.Symbol]) string {
+		syms := generic.Transform(α, func(X grammar.Symbol) string {
+			return X.String()
+		})
+
+		return strings.Join(syms, " ")
+	})
+
+	return strings.Join(alts, " | ")
+}
+
+// isName returns true if a string can be used as part of a generated non-terminal name.
+func isName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return s != ""
+}
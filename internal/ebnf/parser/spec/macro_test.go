@@ -0,0 +1,243 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+)
+
+func TestSymbolTable_AddMacro(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		st := NewSymbolTable()
+		assert.False(t, st.InMacro())
+		assert.False(t, st.IsParam("X"))
+
+		st.AddMacro("list", []string{"X", "S"}, &lexer.Position{Line: 8, Column: 1})
+		assert.True(t, st.InMacro())
+		assert.True(t, st.IsParam("X"))
+		assert.True(t, st.IsParam("S"))
+		assert.False(t, st.IsParam("ID"))
+
+		st.AddMacroProduction(&grammar.Production{
+			Head: "list",
+			Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X")},
+		}, nil, &lexer.Position{Line: 8, Column: 1})
+
+		st.EndMacro()
+		assert.False(t, st.InMacro())
+		assert.False(t, st.IsParam("X"))
+
+		st.AddMacro("list", []string{"X"}, &lexer.Position{Line: 9, Column: 1})
+		st.EndMacro()
+
+		e, ok := st.macros.table.Get("list")
+		assert.True(t, ok)
+		assert.Len(t, e.definitions, 2)
+		assert.Len(t, e.definitions[0].productions, 1)
+		assert.Len(t, e.definitions[0].occurrences, 1)
+		assert.Len(t, e.definitions[1].productions, 0)
+	})
+}
+
+func TestSymbolTable_AddInstance(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name                string
+		st                  *SymbolTable
+		A                   grammar.NonTerminal
+		args                []Strings
+		expectedNonTerminal grammar.NonTerminal
+		expectedCount       int
+	}{
+		{
+			name: "New",
+			st:   st,
+			A:    "list",
+			args: []Strings{
+				{{grammar.NonTerminal("expr")}},
+				{{grammar.Terminal(",")}},
+			},
+			expectedNonTerminal: "gen_list_expr_comma",
+			expectedCount:       1,
+		},
+		{
+			name: "Existent",
+			st:   st,
+			A:    "list",
+			args: []Strings{
+				{{grammar.NonTerminal("expr")}},
+				{{grammar.Terminal(",")}},
+			},
+			expectedNonTerminal: "gen_list_expr_comma",
+			expectedCount:       1,
+		},
+		{
+			name: "TokenArgument",
+			st:   st,
+			A:    "opt",
+			args: []Strings{
+				{{grammar.Terminal("ID")}},
+			},
+			expectedNonTerminal: "gen_opt_ID",
+			expectedCount:       2,
+		},
+		{
+			name: "MultipleAlternatives",
+			st:   st,
+			A:    "opt",
+			args: []Strings{
+				{{grammar.Terminal("ID")}, {grammar.Terminal("NUM")}},
+			},
+			expectedNonTerminal: "gen1_opt",
+			expectedCount:       3,
+		},
+		{
+			name: "SameGeneratedName",
+			st:   st,
+			A:    "list",
+			args: []Strings{
+				{{grammar.NonTerminal("expr")}},
+				{{grammar.NonTerminal("comma")}},
+			},
+			expectedNonTerminal: "gen2_list",
+			expectedCount:       4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			A := tc.st.AddInstance(tc.A, tc.args, "", &lexer.Position{})
+
+			assert.Equal(t, tc.expectedNonTerminal, A)
+			assert.Len(t, tc.st.macros.instances, tc.expectedCount)
+		})
+	}
+}
+
+func TestSymbolTable_ExpandMacros(t *testing.T) {
+	pos := func(line, col int) *lexer.Position {
+		return &lexer.Position{Filename: "test", Line: line, Column: col}
+	}
+
+	// start = opt<ID> opt<list<ID>>;
+	// list<X> = X | list<X> "," X;
+	// opt<X> = X |;
+	st0 := NewSymbolTable()
+	st0.AddProduction(&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_opt_ID"), grammar.NonTerminal("gen_opt_gen_list_ID")}}, pos(1, 1))
+	st0.AddInstance("opt", []Strings{{{grammar.Terminal("ID")}}}, "opt<ID>", pos(1, 9))
+	st0.AddInstance("list", []Strings{{{grammar.Terminal("ID")}}}, "list<ID>", pos(1, 21))
+	st0.AddInstance("opt", []Strings{{{grammar.NonTerminal("gen_list_ID")}}}, "opt<list<ID>>", pos(1, 17))
+	st0.AddMacro("list", []string{"X"}, pos(2, 1))
+	st0.AddInstance("list", []Strings{{{grammar.NonTerminal("X")}}}, "list<X>", pos(2, 15))
+	st0.AddMacroProduction(&grammar.Production{Head: "list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X")}}, nil, pos(2, 1))
+	st0.AddMacroProduction(&grammar.Production{Head: "list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_list_X"), grammar.Terminal(","), grammar.NonTerminal("X")}}, nil, pos(2, 1))
+	st0.EndMacro()
+	st0.AddMacro("opt", []string{"X"}, pos(3, 1))
+	st0.AddMacroProduction(&grammar.Production{Head: "opt", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X")}}, nil, pos(3, 1))
+	st0.AddMacroProduction(&grammar.Production{Head: "opt", Body: grammar.E}, nil, pos(3, 1))
+	st0.EndMacro()
+
+	// start = list<ID> | pair<ID> | tuple<ID> | list;
+	// list<X> = X;
+	// list<X> = X X;
+	// pair<X, Y> = X Y;
+	st1 := NewSymbolTable()
+	st1.AddProduction(&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_list_ID")}}, pos(1, 1))
+	st1.AddProduction(&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_pair_ID")}}, pos(1, 1))
+	st1.AddProduction(&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_tuple_ID")}}, pos(1, 1))
+	st1.AddProduction(&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("list")}}, pos(1, 1))
+	st1.AddInstance("list", []Strings{{{grammar.Terminal("ID")}}}, "list<ID>", pos(1, 9))
+	st1.AddInstance("pair", []Strings{{{grammar.Terminal("ID")}}}, "pair<ID>", pos(1, 20))
+	st1.AddInstance("tuple", []Strings{{{grammar.Terminal("ID")}}}, "tuple<ID>", pos(1, 31))
+	st1.AddMacro("list", []string{"X"}, pos(2, 1))
+	st1.AddMacroProduction(&grammar.Production{Head: "list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X")}}, nil, pos(2, 1))
+	st1.EndMacro()
+	st1.AddMacro("list", []string{"X"}, pos(3, 1))
+	st1.AddMacroProduction(&grammar.Production{Head: "list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X"), grammar.NonTerminal("X")}}, nil, pos(3, 1))
+	st1.EndMacro()
+	st1.AddMacro("pair", []string{"X", "Y"}, pos(4, 1))
+	st1.AddMacroProduction(&grammar.Production{Head: "pair", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X"), grammar.NonTerminal("Y")}}, nil, pos(4, 1))
+	st1.EndMacro()
+
+	// start = nest<ID>;
+	// nest<X> = X | nest<pair<X, X>>;
+	// pair<X, Y> = X Y;
+	st2 := NewSymbolTable()
+	st2.AddProduction(&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_nest_ID")}}, pos(1, 1))
+	st2.AddInstance("nest", []Strings{{{grammar.Terminal("ID")}}}, "nest<ID>", pos(1, 9))
+	st2.AddMacro("nest", []string{"X"}, pos(2, 1))
+	st2.AddInstance("pair", []Strings{{{grammar.NonTerminal("X")}}, {{grammar.NonTerminal("X")}}}, "pair<X, X>", pos(2, 20))
+	st2.AddInstance("nest", []Strings{{{grammar.NonTerminal("gen_pair_X_X")}}}, "nest<pair<X, X>>", pos(2, 15))
+	st2.AddMacroProduction(&grammar.Production{Head: "nest", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X")}}, nil, pos(2, 1))
+	st2.AddMacroProduction(&grammar.Production{Head: "nest", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_nest_gen_pair_X_X")}}, nil, pos(2, 1))
+	st2.EndMacro()
+	st2.AddMacro("pair", []string{"X", "Y"}, pos(3, 1))
+	st2.AddMacroProduction(&grammar.Production{Head: "pair", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("X"), grammar.NonTerminal("Y")}}, nil, pos(3, 1))
+	st2.EndMacro()
+
+	tests := []struct {
+		name                 string
+		st                   *SymbolTable
+		expectedProductions  []string
+		expectedErrorStrings []string
+	}{
+		{
+			name: "Success",
+			st:   st0,
+			expectedProductions: []string{
+				`start → gen_opt_ID gen_opt_gen_list_ID`,
+				`gen_opt_ID → "ID"`,
+				`gen_opt_ID → ε`,
+				`gen_list_ID → "ID"`,
+				`gen_list_ID → gen_list_ID "," "ID"`,
+				`gen_opt_gen_list_ID → gen_list_ID`,
+				`gen_opt_gen_list_ID → ε`,
+			},
+		},
+		{
+			name: "InvalidMacros",
+			st:   st1,
+			expectedErrorStrings: []string{
+				"multiple definitions for parameterized rule list:\n  test:2:1\n  test:3:1",
+				`parameterized rule list is used without arguments: test:1:1`,
+				`parameterized rule pair expects 2 arguments, but 1 are given: test:1:20`,
+				`undefined parameterized rule tuple: test:1:31`,
+			},
+		},
+		{
+			name: "NonTerminating",
+			st:   st2,
+			expectedErrorStrings: []string{
+				`non-terminating expansion of parameterized rule nest<pair<X, X>> in nest: test:2:15`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.st.ExpandMacros()
+
+			if len(tc.expectedErrorStrings) > 0 {
+				assert.Error(t, err)
+
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			} else {
+				assert.NoError(t, err)
+
+				prods := []string{}
+				for _, p := range tc.st.Productions() {
+					prods = append(prods, p.String())
+				}
+
+				assert.ElementsMatch(t, tc.expectedProductions, prods)
+			}
+		})
+	}
+}
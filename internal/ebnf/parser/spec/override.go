@@ -0,0 +1,379 @@
+package spec
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser/lr"
+)
+
+// BeginOverride begins overriding a rule.
+// The existing production rules of the non-terminal being defined next are removed by RemoveRule,
+// so the production rules that follow replace them.
+func (t *SymbolTable) BeginOverride() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.overrides.active = true
+}
+
+// EndOverride ends overriding a rule.
+func (t *SymbolTable) EndOverride() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.overrides.active = false
+}
+
+// InOverride returns true if a rule is being overridden.
+func (t *SymbolTable) InOverride() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.overrides.active
+}
+
+// RemoveRule removes all production rules of a non-terminal from the symbol table,
+// along with their labels, field names, and semantic actions.
+// It returns the number of production rules removed.
+func (t *SymbolTable) RemoveRule(A grammar.NonTerminal) int {
+	t.Lock()
+	defer t.Unlock()
+
+	var prods []*grammar.Production
+	for p := range t.productions.table.All() {
+		if p.Head.Equal(A) {
+			prods = append(prods, p)
+		}
+	}
+
+	for _, p := range prods {
+		t.removeProduction(p)
+	}
+
+	t.removals.nonTerminals = append(t.removals.nonTerminals, A)
+
+	return len(prods)
+}
+
+// RemoveProduction removes a production rule from the symbol table,
+// along with its label, field names, and semantic action.
+//
+// The production rule is expected to be added right before by the removal declaration itself.
+// It returns false if the production rule did not exist before, i.e., the removal declaration is its only occurrence.
+func (t *SymbolTable) RemoveProduction(p *grammar.Production) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	e, ok := t.productions.table.Get(p)
+	existed := ok && len(e.occurrences) > 1
+
+	t.removeProduction(p)
+
+	if !t.productions.table.AnyMatch(func(q *grammar.Production, _ *productionEntry) bool {
+		return q.Head.Equal(p.Head)
+	}) {
+		t.removals.nonTerminals = append(t.removals.nonTerminals, p.Head)
+	}
+
+	return existed
+}
+
+// removeProduction removes a production rule along with everything recorded for it.
+// The symbols in the body of the production rule are kept for ApplyRemovals to delete if they are left unused.
+func (t *SymbolTable) removeProduction(p *grammar.Production) {
+	t.productions.table.Delete(p)
+	t.fields.table.Delete(p)
+	t.actions.table.Delete(p)
+
+	var empty []string
+	for name, e := range t.labels.table.All() {
+		var prods []*grammar.Production
+		var poses []*lexer.Position
+
+		for i, q := range e.productions {
+			if !q.Equal(p) {
+				prods = append(prods, q)
+				poses = append(poses, e.occurrences[i])
+			}
+		}
+
+		e.productions, e.occurrences = prods, poses
+
+		if len(e.productions) == 0 {
+			empty = append(empty, name)
+		}
+	}
+
+	for _, name := range empty {
+		t.labels.table.Delete(name)
+	}
+
+	t.removals.symbols = append(t.removals.symbols, p.Body...)
+}
+
+// OverrideTokenDef replaces the existing definitions of a terminal in the default mode with a new definition.
+// The new definition is expected to be added right before by the override declaration itself.
+// It returns the number of definitions replaced.
+func (t *SymbolTable) OverrideTokenDef(def *TerminalDef) int {
+	t.Lock()
+	defer t.Unlock()
+
+	e, ok := t.terminals.table.Get(def.Terminal)
+	if !ok {
+		return 0
+	}
+
+	n := len(e.definitions)
+	e.definitions = generic.SelectMatch(e.definitions, func(d *TerminalDef) bool {
+		return d == def || d.Mode != ""
+	})
+
+	return n - len(e.definitions)
+}
+
+// RemoveTokenDefs removes all definitions of a terminal from the symbol table.
+// It returns the number of definitions removed.
+func (t *SymbolTable) RemoveTokenDefs(a grammar.Terminal) int {
+	t.Lock()
+	defer t.Unlock()
+
+	t.removals.terminals = append(t.removals.terminals, a)
+
+	e, ok := t.terminals.table.Get(a)
+	if !ok {
+		return 0
+	}
+
+	n := len(e.definitions)
+	e.definitions = nil
+
+	return n
+}
+
+// OverridePrecedence replaces the existing precedence level that shares a handle with a new precedence level.
+// The new precedence level is expected to be added right before by the override declaration itself.
+// The new precedence level takes the place of the existing one, so the relative order of the levels is preserved.
+//
+// It returns the number of existing precedence levels sharing a handle with the new one.
+// The existing precedence level is replaced only if there is exactly one.
+func (t *SymbolTable) OverridePrecedence(p *lr.PrecedenceLevel) int {
+	t.Lock()
+	defer t.Unlock()
+
+	matches := t.overlappingPrecedences(p)
+	if len(matches) != 1 {
+		return len(matches)
+	}
+
+	var list lr.PrecedenceLevels
+	for i, level := range t.precedences.list {
+		if i == matches[0] {
+			list = append(list, p)
+		} else if level != p {
+			list = append(list, level)
+		}
+	}
+
+	t.precedences.list = list
+
+	return 1
+}
+
+// RemovePrecedence removes the existing precedence levels that share a handle with a precedence level.
+// The precedence level is expected to be added right before by the removal declaration itself, and it is removed too.
+// It returns the number of existing precedence levels removed.
+func (t *SymbolTable) RemovePrecedence(p *lr.PrecedenceLevel) int {
+	t.Lock()
+	defer t.Unlock()
+
+	matches := t.overlappingPrecedences(p)
+
+	var list lr.PrecedenceLevels
+	for i, level := range t.precedences.list {
+		if level != p && !slices.Contains(matches, i) {
+			list = append(list, level)
+		}
+	}
+
+	t.precedences.list = list
+
+	return len(matches)
+}
+
+// overlappingPrecedences returns the indices of the precedence levels, other than a given one, that share a handle with it.
+func (t *SymbolTable) overlappingPrecedences(p *lr.PrecedenceLevel) []int {
+	var indices []int
+	for i, level := range t.precedences.list {
+		if level == p {
+			continue
+		}
+
+		for h := range p.Handles.All() {
+			if level.Handles.Contains(h) {
+				indices = append(indices, i)
+				break
+			}
+		}
+	}
+
+	return indices
+}
+
+// ApplyRemovals is called after parsing is complete and the macros are expanded.
+// It deletes the removed symbols that are not redefined, along with the symbols left unused by the removals.
+// The symbols left unused are the synthesized non-terminals, and the terminals referenced only by their string values.
+// If a removed symbol is still used in a production rule, it returns an error with a descriptive message.
+func (t *SymbolTable) ApplyRemovals() error {
+	t.Lock()
+	defer t.Unlock()
+
+	var errs error
+
+	// usedBy returns the first production rule, other than the production rules of the symbol itself, using a symbol.
+	usedBy := func(X grammar.Symbol) (*grammar.Production, *productionEntry) {
+		var first *grammar.Production
+		var entry *productionEntry
+
+		for p, e := range t.productions.table.All() {
+			if A, ok := X.(grammar.NonTerminal); ok && p.Head.Equal(A) {
+				continue
+			}
+
+			if slices.Contains(p.Body, X) && (entry == nil || e.index < entry.index) {
+				first, entry = p, e
+			}
+		}
+
+		return first, entry
+	}
+
+	hasProductions := func(A grammar.NonTerminal) bool {
+		return t.productions.table.AnyMatch(func(p *grammar.Production, _ *productionEntry) bool {
+			return p.Head.Equal(A)
+		})
+	}
+
+	hasPrecedence := func(a grammar.Terminal) bool {
+		h := lr.PrecedenceHandleForTerminal(a)
+		return generic.AnyMatch(t.precedences.list, func(level *lr.PrecedenceLevel) bool {
+			return level.Handles.Contains(h)
+		})
+	}
+
+	isRemovedTerminal := func(a grammar.Terminal) bool {
+		return generic.Contains(t.removals.terminals, grammar.EqTerminal, a)
+	}
+
+	isRemovedNonTerminal := func(A grammar.NonTerminal) bool {
+		return generic.Contains(t.removals.nonTerminals, grammar.EqNonTerminal, A)
+	}
+
+	// isStringTerminal returns true if a terminal is only referenced by its string value and has no explicit definition.
+	isStringTerminal := func(e *terminalEntry) bool {
+		return len(e.definitions) > 0 && len(e.skips) == 0 && len(e.priorities) == 0 &&
+			!generic.AnyMatch(e.definitions, func(def *TerminalDef) bool {
+				return def.Pos != nil
+			})
+	}
+
+	candidates := make([]grammar.Symbol, 0, len(t.removals.symbols)+len(t.removals.terminals)+len(t.removals.nonTerminals))
+	candidates = append(candidates, t.removals.symbols...)
+	for _, a := range t.removals.terminals {
+		candidates = append(candidates, a)
+	}
+	for _, A := range t.removals.nonTerminals {
+		candidates = append(candidates, A)
+	}
+
+	// Deleting a synthesized non-terminal may leave more symbols unused.
+	for len(candidates) > 0 {
+		X := candidates[0]
+		candidates = candidates[1:]
+
+		if p, _ := usedBy(X); p != nil {
+			continue
+		}
+
+		switch X := X.(type) {
+		case grammar.Terminal:
+			e, ok := t.terminals.table.Get(X)
+			if !ok {
+				continue
+			}
+
+			if (isRemovedTerminal(X) && len(e.definitions) == 0) || (isStringTerminal(e) && !hasPrecedence(X)) {
+				t.terminals.table.Delete(X)
+			}
+
+		case grammar.NonTerminal:
+			if _, ok := t.nonTerminals.table.Get(X); !ok {
+				continue
+			}
+
+			if _, synthesized := t.origins.table.Get(X); synthesized {
+				for _, α := range t.productionsOf(X) {
+					candidates = append(candidates, α.Body...)
+					t.removeProduction(α)
+				}
+
+				t.nonTerminals.table.Delete(X)
+				t.origins.table.Delete(X)
+				t.lists.nonTerminals = generic.SelectMatch(t.lists.nonTerminals, func(A grammar.NonTerminal) bool {
+					return !A.Equal(X)
+				})
+			} else if isRemovedNonTerminal(X) && !hasProductions(X) {
+				t.nonTerminals.table.Delete(X)
+			}
+		}
+	}
+
+	for _, a := range t.removals.terminals {
+		if e, ok := t.terminals.table.Get(a); ok && len(e.definitions) == 0 {
+			if p, pe := usedBy(a); p != nil {
+				errs = errors.Append(errs, fmt.Errorf("terminal %s is removed but still used in %s: %s", a, p, pe.occurrences[0]))
+			}
+		}
+	}
+
+	for _, A := range t.removals.nonTerminals {
+		if _, ok := t.nonTerminals.table.Get(A); ok && !hasProductions(A) {
+			if p, pe := usedBy(A); p != nil {
+				errs = errors.Append(errs, fmt.Errorf("non-terminal %s is removed but still used in %s: %s", A, p, pe.occurrences[0]))
+			}
+		}
+	}
+
+	t.removals.terminals = nil
+	t.removals.nonTerminals = nil
+	t.removals.symbols = nil
+
+	return errs
+}
+
+// productionsOf returns the production rules of a non-terminal in the order they are added to the symbol table.
+func (t *SymbolTable) productionsOf(A grammar.NonTerminal) []*grammar.Production {
+	type indexed struct {
+		p     *grammar.Production
+		index int
+	}
+
+	var list []indexed
+	for p, e := range t.productions.table.All() {
+		if p.Head.Equal(A) {
+			list = append(list, indexed{p, e.index})
+		}
+	}
+
+	slices.SortFunc(list, func(a, b indexed) int {
+		return a.index - b.index
+	})
+
+	return generic.Transform(list, func(x indexed) *grammar.Production {
+		return x.p
+	})
+}
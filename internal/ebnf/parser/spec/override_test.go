@@ -0,0 +1,363 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser/lr"
+)
+
+func TestSymbolTable_BeginOverride(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		st := NewSymbolTable()
+		assert.False(t, st.InOverride())
+
+		st.BeginOverride()
+		assert.True(t, st.InOverride())
+
+		st.EndOverride()
+		assert.False(t, st.InOverride())
+	})
+}
+
+func TestSymbolTable_RemoveRule(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		p1 := &grammar.Production{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr")}}
+		p2 := &grammar.Production{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("print"), grammar.NonTerminal("expr")}}
+		p3 := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}}
+
+		st := NewSymbolTable()
+		st.AddProduction(p1, &lexer.Position{Line: 4, Column: 1})
+		st.AddProduction(p2, &lexer.Position{Line: 4, Column: 1})
+		st.AddProduction(p3, &lexer.Position{Line: 5, Column: 1})
+		st.AddLabel("Print", p2, &lexer.Position{Line: 4, Column: 30})
+		st.AddFields(p1, []string{"name", "", "value"}, &lexer.Position{Line: 4, Column: 1})
+		st.AddAction(p2, &SemanticAction{Code: " return nil, nil ", Pos: &lexer.Position{Line: 4, Column: 40}})
+
+		assert.Equal(t, 2, st.RemoveRule("stmt"))
+		assert.Equal(t, 0, st.RemoveRule("term"))
+
+		assert.Equal(t, []*grammar.Production{p3}, st.Productions())
+		assert.Equal(t, 0, st.labels.table.Size())
+		assert.Equal(t, 0, st.fields.table.Size())
+		assert.Equal(t, 0, st.actions.table.Size())
+		assert.Equal(t, []grammar.NonTerminal{"stmt", "term"}, st.removals.nonTerminals)
+		assert.Len(t, st.removals.symbols, 5)
+	})
+}
+
+func TestSymbolTable_RemoveProduction(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		p1 := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}}
+		p2 := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}}
+		p3 := &grammar.Production{Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}}
+
+		st := NewSymbolTable()
+		st.AddProduction(p1, &lexer.Position{Line: 4, Column: 1})
+		st.AddProduction(p2, &lexer.Position{Line: 4, Column: 1})
+		st.AddLabel("Add", p1, &lexer.Position{Line: 4, Column: 20})
+
+		// Added again by a removal declaration
+		st.AddProduction(p1, &lexer.Position{Line: 8, Column: 9})
+		assert.True(t, st.RemoveProduction(p1))
+		assert.Equal(t, []*grammar.Production{p2}, st.Productions())
+		assert.Equal(t, 0, st.labels.table.Size())
+		assert.Nil(t, st.removals.nonTerminals)
+
+		st.AddProduction(p2, &lexer.Position{Line: 9, Column: 9})
+		assert.True(t, st.RemoveProduction(p2))
+		assert.Empty(t, st.Productions())
+		assert.Equal(t, []grammar.NonTerminal{"expr"}, st.removals.nonTerminals)
+
+		st.AddProduction(p3, &lexer.Position{Line: 10, Column: 9})
+		assert.False(t, st.RemoveProduction(p3))
+		assert.Equal(t, []grammar.NonTerminal{"expr", "term"}, st.removals.nonTerminals)
+	})
+}
+
+func TestSymbolTable_OverrideTokenDef(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		st := NewSymbolTable()
+		st.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Line: 4, Column: 1})
+		inMode := st.AddRegexTokenDef("NUM", "[0-9a-f]+", &lexer.Position{Line: 8, Column: 3})
+		inMode.Mode = "hex"
+
+		assert.Equal(t, 0, st.OverrideTokenDef(&TerminalDef{Terminal: "ID"}))
+
+		def := st.AddRegexTokenDef("NUM", "[0-9][0-9_]*", &lexer.Position{Line: 12, Column: 11})
+		assert.Equal(t, 1, st.OverrideTokenDef(def))
+
+		e, ok := st.terminals.table.Get("NUM")
+		assert.True(t, ok)
+		assert.Equal(t, []*TerminalDef{inMode, def}, e.definitions)
+
+		def = st.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Line: 13, Column: 11})
+		assert.Equal(t, 0, st.OverrideTokenDef(def))
+	})
+}
+
+func TestSymbolTable_RemoveTokenDefs(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		st := NewSymbolTable()
+		st.AddRegexTokenDef("STR", `"[^"]*"`, &lexer.Position{Line: 4, Column: 1})
+
+		assert.Equal(t, 1, st.RemoveTokenDefs("STR"))
+		assert.Equal(t, 0, st.RemoveTokenDefs("CHAR"))
+
+		e, ok := st.terminals.table.Get("STR")
+		assert.True(t, ok)
+		assert.Empty(t, e.definitions)
+		assert.Equal(t, []grammar.Terminal{"STR", "CHAR"}, st.removals.terminals)
+	})
+}
+
+func TestSymbolTable_OverridePrecedence(t *testing.T) {
+	mul := &lr.PrecedenceLevel{
+		Associativity: lr.LEFT,
+		Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*"), lr.PrecedenceHandleForTerminal("/")),
+	}
+
+	add := &lr.PrecedenceLevel{
+		Associativity: lr.LEFT,
+		Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+"), lr.PrecedenceHandleForTerminal("-")),
+	}
+
+	tests := []struct {
+		name          string
+		p             *lr.PrecedenceLevel
+		expectedCount int
+		expectedList  func(p *lr.PrecedenceLevel) lr.PrecedenceLevels
+	}{
+		{
+			name: "Replaced",
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.LEFT,
+				Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*"), lr.PrecedenceHandleForTerminal("%")),
+			},
+			expectedCount: 1,
+			expectedList: func(p *lr.PrecedenceLevel) lr.PrecedenceLevels {
+				return lr.PrecedenceLevels{p, add}
+			},
+		},
+		{
+			name: "NoMatch",
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.NONE,
+				Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("<")),
+			},
+			expectedCount: 0,
+			expectedList: func(p *lr.PrecedenceLevel) lr.PrecedenceLevels {
+				return lr.PrecedenceLevels{mul, add, p}
+			},
+		},
+		{
+			name: "MultipleMatches",
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.LEFT,
+				Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*"), lr.PrecedenceHandleForTerminal("+")),
+			},
+			expectedCount: 2,
+			expectedList: func(p *lr.PrecedenceLevel) lr.PrecedenceLevels {
+				return lr.PrecedenceLevels{mul, add, p}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st := NewSymbolTable()
+			st.AddPrecedence(mul)
+			st.AddPrecedence(add)
+			st.AddPrecedence(tc.p)
+
+			assert.Equal(t, tc.expectedCount, st.OverridePrecedence(tc.p))
+			assert.Equal(t, tc.expectedList(tc.p), st.Precedences())
+		})
+	}
+}
+
+func TestSymbolTable_RemovePrecedence(t *testing.T) {
+	mul := &lr.PrecedenceLevel{
+		Associativity: lr.LEFT,
+		Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*"), lr.PrecedenceHandleForTerminal("/")),
+	}
+
+	add := &lr.PrecedenceLevel{
+		Associativity: lr.LEFT,
+		Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+"), lr.PrecedenceHandleForTerminal("-")),
+	}
+
+	tests := []struct {
+		name          string
+		p             *lr.PrecedenceLevel
+		expectedCount int
+		expectedList  lr.PrecedenceLevels
+	}{
+		{
+			name: "Removed",
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.LEFT,
+				Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("/")),
+			},
+			expectedCount: 1,
+			expectedList:  lr.PrecedenceLevels{add},
+		},
+		{
+			name: "NoMatch",
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.NONE,
+				Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("<")),
+			},
+			expectedCount: 0,
+			expectedList:  lr.PrecedenceLevels{mul, add},
+		},
+		{
+			name: "MultipleMatches",
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.LEFT,
+				Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*"), lr.PrecedenceHandleForTerminal("+")),
+			},
+			expectedCount: 2,
+			expectedList:  nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st := NewSymbolTable()
+			st.AddPrecedence(mul)
+			st.AddPrecedence(add)
+			st.AddPrecedence(tc.p)
+
+			assert.Equal(t, tc.expectedCount, st.RemovePrecedence(tc.p))
+			assert.Equal(t, tc.expectedList, st.Precedences())
+		})
+	}
+}
+
+func TestSymbolTable_ApplyRemovals(t *testing.T) {
+	stmt := &grammar.Production{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("="), grammar.NonTerminal("expr")}}
+	printStmt := &grammar.Production{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("print"), grammar.NonTerminal("gen_expr_plus")}}
+	plus1 := &grammar.Production{Head: "gen_expr_plus", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_expr_plus"), grammar.NonTerminal("expr")}}
+	plus2 := &grammar.Production{Head: "gen_expr_plus", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr")}}
+	num := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}}
+	str := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("STR")}}
+	term := &grammar.Production{Head: "term", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}}
+
+	newSymbolTable := func() *SymbolTable {
+		st := NewSymbolTable()
+
+		st.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Line: 3, Column: 1})
+		st.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Line: 4, Column: 1})
+		st.AddRegexTokenDef("STR", `"[^"]*"`, &lexer.Position{Line: 5, Column: 1})
+		st.AddTokenTerminal("ID", &lexer.Position{Line: 7, Column: 8})
+		st.AddStringTerminal("=", &lexer.Position{Line: 7, Column: 11})
+		st.AddStringTerminal("print", &lexer.Position{Line: 7, Column: 22})
+		st.AddTokenTerminal("NUM", &lexer.Position{Line: 8, Column: 8})
+		st.AddTokenTerminal("STR", &lexer.Position{Line: 8, Column: 14})
+		st.AddTokenTerminal("NUM", &lexer.Position{Line: 9, Column: 8})
+
+		for _, A := range []grammar.NonTerminal{"stmt", "gen_expr_plus", "expr", "term"} {
+			st.AddNonTerminal(A, &lexer.Position{})
+		}
+
+		st.AddOrigin("gen_expr_plus", "{{ expr }}", &lexer.Position{Line: 7, Column: 30})
+
+		st.AddProduction(stmt, &lexer.Position{Line: 7, Column: 1})
+		st.AddProduction(printStmt, &lexer.Position{Line: 7, Column: 1})
+		st.AddProduction(plus1, &lexer.Position{Line: 7, Column: 1})
+		st.AddProduction(plus2, &lexer.Position{Line: 7, Column: 1})
+		st.AddProduction(num, &lexer.Position{Line: 8, Column: 1})
+		st.AddProduction(str, &lexer.Position{Line: 8, Column: 1})
+		st.AddProduction(term, &lexer.Position{Line: 9, Column: 1})
+
+		return st
+	}
+
+	tests := []struct {
+		name                 string
+		remove               func(st *SymbolTable)
+		expectedTerminals    []grammar.Terminal
+		expectedNonTerminals []grammar.NonTerminal
+		expectedProductions  []*grammar.Production
+		expectedErrorStrings []string
+	}{
+		{
+			name: "UnusedRemoved",
+			remove: func(st *SymbolTable) {
+				st.RemoveTokenDefs("STR")
+				st.AddProduction(str, &lexer.Position{Line: 12, Column: 9})
+				st.RemoveProduction(str)
+				st.RemoveRule("term")
+			},
+			expectedTerminals:    []grammar.Terminal{"ID", "NUM", "=", "print"},
+			expectedNonTerminals: []grammar.NonTerminal{"stmt", "gen_expr_plus", "expr"},
+			expectedProductions:  []*grammar.Production{stmt, printStmt, plus1, plus2, num},
+			expectedErrorStrings: nil,
+		},
+		{
+			name: "UnusedLeftOver",
+			remove: func(st *SymbolTable) {
+				st.AddProduction(printStmt, &lexer.Position{Line: 12, Column: 9})
+				st.RemoveProduction(printStmt)
+			},
+			expectedTerminals:    []grammar.Terminal{"ID", "NUM", "STR", "="},
+			expectedNonTerminals: []grammar.NonTerminal{"stmt", "expr", "term"},
+			expectedProductions:  []*grammar.Production{stmt, num, str, term},
+			expectedErrorStrings: nil,
+		},
+		{
+			name: "StillUsed",
+			remove: func(st *SymbolTable) {
+				st.RemoveTokenDefs("STR")
+				st.RemoveRule("expr")
+			},
+			expectedTerminals:    []grammar.Terminal{"ID", "NUM", "=", "print"},
+			expectedNonTerminals: []grammar.NonTerminal{"stmt", "gen_expr_plus", "expr", "term"},
+			expectedProductions:  []*grammar.Production{stmt, printStmt, plus1, plus2, term},
+			expectedErrorStrings: []string{
+				`non-terminal expr is removed but still used in stmt → ID "=" expr`,
+			},
+		},
+		{
+			name: "Redefined",
+			remove: func(st *SymbolTable) {
+				st.RemoveTokenDefs("STR")
+				st.AddRegexTokenDef("STR", `'[^']*'`, &lexer.Position{Line: 12, Column: 1})
+				st.RemoveRule("term")
+				st.AddProduction(term, &lexer.Position{Line: 13, Column: 1})
+			},
+			expectedTerminals:    []grammar.Terminal{"ID", "NUM", "STR", "=", "print"},
+			expectedNonTerminals: []grammar.NonTerminal{"stmt", "gen_expr_plus", "expr", "term"},
+			expectedProductions:  []*grammar.Production{stmt, printStmt, plus1, plus2, num, str, term},
+			expectedErrorStrings: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st := newSymbolTable()
+			tc.remove(st)
+
+			err := st.ApplyRemovals()
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			}
+
+			assert.ElementsMatch(t, tc.expectedTerminals, st.Terminals())
+			assert.ElementsMatch(t, tc.expectedNonTerminals, st.NonTerminals())
+			assert.ElementsMatch(t, tc.expectedProductions, st.Productions())
+			assert.Nil(t, st.removals.symbols)
+		})
+	}
+}
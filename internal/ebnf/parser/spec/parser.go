@@ -3,30 +3,829 @@ package spec
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
 	"github.com/moorara/algo/parser/lr"
 
 	"github.com/gardenbed/emerge/internal/ebnf/parser"
 )
 
+// fragment is the evaluated right-hand side of a rule together with its EBNF text.
+// The text is normalized, i.e., symbols and operators are separated by a single space.
+// Labels is either nil or holds the label of each string, which is nil if the string is not labeled.
+// Fields is either nil or holds the field names of the symbols in each string, which are empty for unnamed symbols.
+// Actions is either nil or holds the semantic action of each string, which is nil if the string has no action.
+type fragment struct {
+	Strings Strings
+	Text    string
+	Labels  []*label
+	Fields  [][]string
+	Actions []*SemanticAction
+}
+
+// label is the name given to an alternative of a rule.
+type label struct {
+	Name string
+	Pos  *lexer.Position
+}
+
+// labels returns the labels of the labeled strings in a fragment.
+func (f fragment) labels() []*label {
+	return generic.SelectMatch(f.Labels, func(l *label) bool {
+		return l != nil
+	})
+}
+
+// labelAt returns the label of the i-th string in a fragment, or nil if the string is not labeled.
+func (f fragment) labelAt(i int) *label {
+	if f.Labels == nil {
+		return nil
+	}
+
+	return f.Labels[i]
+}
+
+// actions returns the semantic actions of the strings in a fragment that have one.
+func (f fragment) actions() []*SemanticAction {
+	return generic.SelectMatch(f.Actions, func(a *SemanticAction) bool {
+		return a != nil
+	})
+}
+
+// actionAt returns the semantic action of the i-th string in a fragment, or nil if the string has no action.
+func (f fragment) actionAt(i int) *SemanticAction {
+	if f.Actions == nil {
+		return nil
+	}
+
+	return f.Actions[i]
+}
+
+// fieldsAt returns the field names of the symbols in the i-th string of a fragment, or nil if no symbol is named.
+func (f fragment) fieldsAt(i int) []string {
+	if f.Fields == nil {
+		return nil
+	}
+
+	return f.Fields[i]
+}
+
+// joinFields returns the field names for the strings of two fragments that are combined as alternatives.
+func joinFields(f1, f2 fragment) [][]string {
+	if f1.Fields == nil && f2.Fields == nil {
+		return nil
+	}
+
+	fields := make([][]string, 0, len(f1.Strings)+len(f2.Strings))
+	for i := range f1.Strings {
+		fields = append(fields, f1.fieldsAt(i))
+	}
+	for i := range f2.Strings {
+		fields = append(fields, f2.fieldsAt(i))
+	}
+
+	return fields
+}
+
+// concatFields returns the field names for the concatenation of two strings.
+func concatFields(α, β grammar.String[grammar.Symbol], fα, fβ []string) []string {
+	if fα == nil && fβ == nil {
+		return nil
+	}
+
+	fields := make([]string, len(α)+len(β))
+	copy(fields, fα)
+	copy(fields[len(α):], fβ)
+
+	return fields
+}
+
+// joinLabels returns the labels for the strings of two fragments that are combined as alternatives.
+func joinLabels(f1, f2 fragment) []*label {
+	if f1.Labels == nil && f2.Labels == nil {
+		return nil
+	}
+
+	labels := make([]*label, 0, len(f1.Strings)+len(f2.Strings))
+	for i := range f1.Strings {
+		labels = append(labels, f1.labelAt(i))
+	}
+	for i := range f2.Strings {
+		labels = append(labels, f2.labelAt(i))
+	}
+
+	return labels
+}
+
+// joinActions returns the semantic actions for the strings of two fragments that are combined as alternatives.
+func joinActions(f1, f2 fragment) []*SemanticAction {
+	if f1.Actions == nil && f2.Actions == nil {
+		return nil
+	}
+
+	actions := make([]*SemanticAction, 0, len(f1.Strings)+len(f2.Strings))
+	for i := range f1.Strings {
+		actions = append(actions, f1.actionAt(i))
+	}
+	for i := range f2.Strings {
+		actions = append(actions, f2.actionAt(i))
+	}
+
+	return actions
+}
+
 // Parse processes an EBNF input, evaluates it, and returns the result of evaluation.
+// The files imported by the input are resolved relative to the importing file and merged into the same symbol table.
 // It returns the evaluation outcome or an error if parsing fails.
 func Parse(filename string, src io.Reader) (*Spec, error) {
-	table := NewSymbolTable()
+	return ParseDir(filepath.Dir(filename), filename, src)
+}
 
-	p, err := parser.New(filename, src)
-	if err != nil {
-		return nil, err
-	}
+// ParseDir is the same as Parse, except that the input is located in the given directory.
+// The filename is only used for naming the input in positions and errors, so it can differ from the location of the input.
+// The files imported by the input are resolved relative to the location of the importing file,
+// while they are named relative to the name of the importing file.
+func ParseDir(dir, filename string, src io.Reader) (*Spec, error) {
+	table := NewSymbolTable()
 
 	errs := &errors.MultiError{
 		Format: errors.BulletErrorFormat,
 	}
 
-	res, err := p.ParseAndEvaluate(func(i int, rhs []*lr.Value) (any, error) {
+	// files and paths are the names and the locations of the files being parsed,
+	// from the input to the innermost imported file.
+	// imported keeps track of the locations of all files parsed so far, so a file imported more than once is merged only once.
+	var files, paths []string
+	imported := map[string]bool{}
+
+	// parse parses a single file and evaluates its declarations into the symbol table.
+	// It is called recursively for every file imported by the input.
+	var parse func(string, string, io.Reader) (*lr.Value, error)
+
+	// parsers is the chain of parsers for the files being parsed, in the same order as files.
+	// The comments skipped by the innermost parser are the candidates for the doc comments of its declarations.
+	var parsers []*parser.Parser
+
+	// document sets the doc comment of a symbol from the comments directly above its declaration, if any.
+	document := func(X grammar.Symbol, pos *lexer.Position) {
+		if doc := docComment(parsers[len(parsers)-1].Comments(), pos); doc != "" {
+			table.SetDoc(X, doc)
+		}
+	}
+
+	// A label or a semantic action can only be given to a whole alternative of a rule.
+	// Labels and semantic actions nested inside other EBNF constructs are reported as errors.
+	misplaced := func(fs ...fragment) {
+		for _, f := range fs {
+			for _, l := range f.labels() {
+				errs = errors.Append(errs, fmt.Errorf("label #%s is not at the end of an alternative: %s", l.Name, l.Pos))
+			}
+
+			for _, a := range f.actions() {
+				errs = errors.Append(errs, fmt.Errorf("action is not at the end of an alternative: %s", a.Pos))
+			}
+		}
+	}
+
+	// A separated list is desugared into a single left-recursive non-terminal for one or more elements.
+	// No elements and a trailing separator are added as alternatives to the enclosing rule.
+	// The right-hand side of the production rules is: open elements operator separators close.
+	list := func(rhs []*lr.Value, plus, trailing bool) fragment {
+		f1 := rhs[1].Val.(fragment)
+		f2 := rhs[3].Val.(fragment)
+		text := fmt.Sprintf("%s %s %s %s %s", rhs[0].Val, f1.Text, rhs[2].Val, f2.Text, rhs[4].Val)
+
+		misplaced(f1, f2)
+
+		L := table.GetList(f1.Strings, f2.Strings)
+		table.AddNonTerminal(L, rhs[1].Pos)
+		table.AddOrigin(L, text, rhs[0].Pos)
+
+		for i, α := range f1.Strings {
+			p := &grammar.Production{Head: L, Body: α}
+			table.AddProduction(p, rhs[0].Pos)
+
+			if fields := f1.fieldsAt(i); fields != nil {
+				table.AddFields(p, fields, rhs[0].Pos)
+			}
+
+			for j, σ := range f2.Strings {
+				β := σ.Prepend(L)
+				p := &grammar.Production{Head: L, Body: β.Concat(α)}
+				table.AddProduction(p, rhs[0].Pos)
+
+				if fields := concatFields(β, α, concatFields(grammar.String[grammar.Symbol]{L}, σ, nil, f2.fieldsAt(j)), f1.fieldsAt(i)); fields != nil {
+					table.AddFields(p, fields, rhs[0].Pos)
+				}
+			}
+		}
+
+		all := Strings{{L}}
+		if trailing {
+			for _, σ := range f2.Strings {
+				all = append(all, σ.Prepend(L))
+			}
+		}
+		if !plus {
+			all = append(all, grammar.E)
+		}
+
+		return fragment{all, text, nil, nil, nil}
+	}
+
+	eval := func(i int, rhs []*lr.Value) (any, error) {
 		switch i {
+		// externals → TOKEN
+		case 90:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			table.AddExternal(token, rhs[0].Pos)
+
+			return []grammar.Terminal{token}, nil
+
+		// externals → externals TOKEN
+		case 89:
+			tokens := rhs[0].Val.([]grammar.Terminal)
+			token := grammar.Terminal(rhs[1].Val.(string))
+			table.AddExternal(token, rhs[1].Pos)
+
+			return append(tokens, token), nil
+
+		// directive → "@external" externals
+		case 88:
+			return rhs[1].Val, nil
+
+		// decl → "@remove" TOKEN semi_opt
+		case 87:
+			a := grammar.Terminal(rhs[1].Val.(string))
+
+			if n := table.RemoveTokenDefs(a); n == 0 {
+				errs = errors.Append(errs, fmt.Errorf("no definition to remove for terminal %s: %s", a, rhs[1].Pos))
+			}
+
+			return nil, nil
+
+		// decl → "@remove" IDENT semi_opt
+		case 86:
+			A := grammar.NonTerminal(rhs[1].Val.(string))
+
+			if n := table.RemoveRule(A); n == 0 {
+				errs = errors.Append(errs, fmt.Errorf("no production rules to remove for non-terminal %s: %s", A, rhs[1].Pos))
+			}
+
+			return nil, nil
+
+		// decl → "@remove" directive semi_opt
+		case 85:
+			p, ok := rhs[1].Val.(*lr.PrecedenceLevel)
+			if !ok {
+				errs = errors.Append(errs, fmt.Errorf("only precedence directives can be removed: %s", rhs[0].Pos))
+				return nil, nil
+			}
+
+			if n := table.RemovePrecedence(p); n == 0 {
+				errs = errors.Append(errs, fmt.Errorf("no precedence level to remove: %s", rhs[0].Pos))
+			}
+
+			return nil, nil
+
+		// decl → "@remove" rule ";"
+		case 84:
+			prods := rhs[1].Val.([]*grammar.Production)
+
+			// The production rules of a parameterized rule are not recorded until the macros are expanded.
+			if len(prods) == 0 {
+				errs = errors.Append(errs, fmt.Errorf("parameterized rules cannot be removed: %s", rhs[0].Pos))
+				return nil, nil
+			}
+
+			for _, p := range prods {
+				if existed := table.RemoveProduction(p); !existed {
+					errs = errors.Append(errs, fmt.Errorf("no production rule %s to remove: %s", p, rhs[0].Pos))
+				}
+			}
+
+			return nil, nil
+
+		// decl → "@override" directive semi_opt
+		case 83:
+			p, ok := rhs[1].Val.(*lr.PrecedenceLevel)
+			if !ok {
+				errs = errors.Append(errs, fmt.Errorf("only precedence directives can be overridden: %s", rhs[0].Pos))
+				return nil, nil
+			}
+
+			if n := table.OverridePrecedence(p); n == 0 {
+				errs = errors.Append(errs, fmt.Errorf("no precedence level to override: %s", rhs[0].Pos))
+			} else if n > 1 {
+				errs = errors.Append(errs, fmt.Errorf("precedence level overlaps %d precedence levels: %s", n, rhs[0].Pos))
+			}
+
+			return nil, nil
+
+		// decl → "@override" token semi_opt
+		case 82:
+			// The token is nil if its definition is invalid, which has been already reported.
+			def, ok := rhs[1].Val.(*TerminalDef)
+			if !ok {
+				return nil, nil
+			}
+
+			if n := table.OverrideTokenDef(def); n == 0 {
+				errs = errors.Append(errs, fmt.Errorf("no definition to override for terminal %s: %s", def.Terminal, def.Pos))
+			}
+
+			document(def.Terminal, rhs[0].Pos)
+
+			return nil, nil
+
+		// override → "@override"
+		case 81:
+			table.BeginOverride()
+			return nil, nil
+
+		// decl → override rule ";"
+		case 80:
+			if prods, ok := rhs[1].Val.([]*grammar.Production); ok && len(prods) > 0 {
+				document(prods[0].Head, rhs[0].Pos)
+			}
+
+			return nil, nil
+
+		// decl → "import" STRING semi_opt
+		case 79:
+			path, err := unquote(rhs[1].Val.(string))
+			if err != nil {
+				errs = errors.Append(errs, fmt.Errorf("invalid import path %q: %s: %s", rhs[1].Val, err, rhs[1].Pos))
+				return nil, nil
+			}
+
+			// An import path is relative to the directory of the importing file.
+			// The imported file is located relative to the location of the importing file,
+			// and is named relative to the name of the importing file.
+			name := filepath.Join(filepath.Dir(files[len(files)-1]), path)
+			path = absPath(filepath.Join(filepath.Dir(paths[len(paths)-1]), path))
+
+			if j := slices.Index(paths, path); j >= 0 {
+				cycle := append(slices.Clone(files[j:]), name)
+				errs = errors.Append(errs, fmt.Errorf("import cycle %s: %s", strings.Join(cycle, " → "), rhs[1].Pos))
+				return nil, nil
+			}
+
+			// A file imported through more than one path (diamond import) is merged only once.
+			if imported[path] {
+				return nil, nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				if e, ok := err.(*os.PathError); ok {
+					err = e.Err
+				}
+
+				errs = errors.Append(errs, fmt.Errorf("cannot import %s: %s: %s", name, err, rhs[1].Pos))
+				return nil, nil
+			}
+
+			defer func() {
+				_ = f.Close()
+			}()
+
+			if _, err := parse(name, path, f); err != nil {
+				errs = errors.Append(errs, err)
+			}
+
+			return nil, nil
+
+		// decl → CODE semi_opt
+		case 78:
+			block, err := parseCodeBlock(rhs[0].Val.(string), rhs[0].Pos)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				return nil, nil
+			}
+
+			table.AddCodeBlock(block)
+
+			return nil, nil
+
+		// rule → lhs "=" CODE
+		case 77:
+			p := &grammar.Production{
+				Head: rhs[0].Val.(grammar.NonTerminal),
+				Body: grammar.E,
+			}
+
+			if table.InMacro() {
+				errs = errors.Append(errs, fmt.Errorf("action in parameterized rule %s: %s", p.Head, rhs[2].Pos))
+				table.AddMacroProduction(p, nil, rhs[0].Pos)
+				table.EndMacro()
+				return []*grammar.Production{}, nil
+			}
+
+			table.AddProduction(p, rhs[0].Pos)
+
+			a, err := parseAction(rhs[2].Val.(string), rhs[2].Pos, p.Body, nil)
+			if err != nil {
+				errs = errors.Append(errs, err)
+			} else {
+				table.AddAction(p, a)
+			}
+
+			return []*grammar.Production{p}, nil
+
+		// rhs → rhs "|" CODE
+		case 76:
+			f := rhs[0].Val.(fragment)
+
+			all := make(Strings, 0, len(f.Strings)+1)
+			all = append(all, f.Strings...)
+			all = append(all, grammar.E)
+
+			ε := fragment{Strings: Strings{grammar.E}}
+
+			a, err := parseAction(rhs[2].Val.(string), rhs[2].Pos, grammar.E, nil)
+			if err != nil {
+				errs = errors.Append(errs, err)
+			} else {
+				ε.Actions = []*SemanticAction{a}
+			}
+
+			return fragment{all, f.Text + " | {% %}", joinLabels(f, ε), joinFields(f, ε), joinActions(f, ε)}, nil
+
+		// rhs → rhs CODE
+		case 75:
+			f := rhs[0].Val.(fragment)
+			text := f.Text + " {% %}"
+
+			if actions := f.actions(); len(actions) > 0 {
+				errs = errors.Append(errs, fmt.Errorf("multiple actions for the same alternative: %s", rhs[1].Pos))
+				return fragment{f.Strings, text, f.Labels, f.Fields, f.Actions}, nil
+			}
+
+			if len(f.Strings) > 1 {
+				errs = errors.Append(errs, fmt.Errorf("action for %d productions instead of one: %s", len(f.Strings), rhs[1].Pos))
+				return fragment{f.Strings, text, f.Labels, f.Fields, nil}, nil
+			}
+
+			a, err := parseAction(rhs[1].Val.(string), rhs[1].Pos, f.Strings[0], f.fieldsAt(0))
+			if err != nil {
+				errs = errors.Append(errs, err)
+				return fragment{f.Strings, text, f.Labels, f.Fields, nil}, nil
+			}
+
+			return fragment{f.Strings, text, f.Labels, f.Fields, []*SemanticAction{a}}, nil
+
+		// args → rhs
+		case 74:
+			return []fragment{rhs[0].Val.(fragment)}, nil
+
+		// args → args "," rhs
+		case 73:
+			args := rhs[0].Val.([]fragment)
+			return append(args, rhs[2].Val.(fragment)), nil
+
+		// rhs → nonterm "<" args ">"
+		case 72:
+			A := rhs[0].Val.(grammar.NonTerminal)
+			args := rhs[2].Val.([]fragment)
+
+			misplaced(args...)
+
+			texts := make([]string, len(args))
+			strs := make([]Strings, len(args))
+			for i, f := range args {
+				texts[i] = f.Text
+				strs[i] = f.Strings
+			}
+
+			text := fmt.Sprintf("%s<%s>", A, strings.Join(texts, ", "))
+
+			// The instantiation is expanded into concrete production rules once the whole grammar is parsed.
+			I := table.AddInstance(A, strs, text, rhs[0].Pos)
+			table.AddNonTerminal(I, rhs[0].Pos)
+			table.AddOrigin(I, text, rhs[0].Pos)
+
+			return fragment{Strings{{I}}, text, nil, nil, nil}, nil
+
+		// params → TOKEN
+		case 71:
+			return []string{rhs[0].Val.(string)}, nil
+
+		// params → params "," TOKEN
+		case 70:
+			params := rhs[0].Val.([]string)
+			param := rhs[2].Val.(string)
+
+			if slices.Contains(params, param) {
+				errs = errors.Append(errs, fmt.Errorf("duplicate parameter %s: %s", param, rhs[2].Pos))
+				return params, nil
+			}
+
+			return append(params, param), nil
+
+		// lhs → nonterm "<" params ">"
+		case 69:
+			A := rhs[0].Val.(grammar.NonTerminal)
+			params := rhs[2].Val.([]string)
+
+			if A.Name() == ErrorToken.Name() {
+				errs = errors.Append(errs, fmt.Errorf("non-terminal name %s is reserved: %s", A, rhs[0].Pos))
+			}
+
+			if table.InOverride() {
+				table.EndOverride()
+				errs = errors.Append(errs, fmt.Errorf("parameterized rules cannot be overridden: %s", rhs[0].Pos))
+			}
+
+			table.AddMacro(A, params, rhs[0].Pos)
+
+			return A, nil
+
+		// rhs → "{{" rhs "%%" rhs "}}"
+		case 68:
+			return list(rhs, true, true), nil
+
+		// rhs → "{{" rhs "%" rhs "}}"
+		case 67:
+			return list(rhs, true, false), nil
+
+		// rhs → "{" rhs "%%" rhs "}"
+		case 66:
+			return list(rhs, false, true), nil
+
+		// rhs → "{" rhs "%" rhs "}"
+		case 65:
+			return list(rhs, false, false), nil
+
+		// starts → IDENT
+		case 64:
+			A := grammar.NonTerminal(rhs[0].Val.(string))
+			table.AddStart(A, rhs[0].Pos)
+
+			return []grammar.NonTerminal{A}, nil
+
+		// starts → starts IDENT
+		case 63:
+			starts := rhs[0].Val.([]grammar.NonTerminal)
+			A := grammar.NonTerminal(rhs[1].Val.(string))
+			table.AddStart(A, rhs[1].Pos)
+
+			return append(starts, A), nil
+
+		// directive → "@start" starts
+		case 62:
+			return rhs[1].Val, nil
+
+		// rhs → FIELD rhs
+		case 61:
+			name := strings.TrimSuffix(rhs[0].Val.(string), ":")
+			f := rhs[1].Val.(fragment)
+			text := name + ":" + f.Text
+
+			misplaced(f)
+
+			if generic.AnyMatch(f.Strings, func(α grammar.String[grammar.Symbol]) bool { return len(α) > 1 }) {
+				errs = errors.Append(errs, fmt.Errorf("field %s does not name a single symbol: %s", name, rhs[0].Pos))
+				return fragment{f.Strings, text, nil, f.Fields, nil}, nil
+			}
+
+			fields := make([][]string, len(f.Strings))
+			for i, α := range f.Strings {
+				if len(α) == 0 {
+					continue // An empty string has no symbol to name.
+				}
+
+				if prev := f.fieldsAt(i); prev != nil {
+					errs = errors.Append(errs, fmt.Errorf("multiple fields %s and %s for the same symbol: %s", prev[0], name, rhs[0].Pos))
+					return fragment{f.Strings, text, nil, f.Fields, nil}, nil
+				}
+
+				fields[i] = []string{name}
+			}
+
+			return fragment{f.Strings, text, nil, fields, nil}, nil
+
+		// rhs → rhs LABEL
+		case 60:
+			f := rhs[0].Val.(fragment)
+			name := strings.TrimPrefix(rhs[1].Val.(string), "#")
+			text := f.Text + " #" + name
+
+			if labels := f.labels(); len(labels) > 0 {
+				errs = errors.Append(errs, fmt.Errorf("multiple labels #%s and #%s for the same alternative: %s", labels[0].Name, name, rhs[1].Pos))
+				return fragment{f.Strings, text, f.Labels, f.Fields, f.Actions}, nil
+			}
+
+			if len(f.Strings) > 1 {
+				errs = errors.Append(errs, fmt.Errorf("label #%s names %d productions instead of one: %s", name, len(f.Strings), rhs[1].Pos))
+				return fragment{f.Strings, text, nil, f.Fields, f.Actions}, nil
+			}
+
+			return fragment{f.Strings, text, []*label{{name, rhs[1].Pos}}, f.Fields, f.Actions}, nil
+
+		// fragment → "@fragment" TOKEN "=" REGEX
+		case 59:
+			name := rhs[1].Val.(string)
+			regex := rhs[3].Val.(string)
+
+			return table.AddFragment(name, regex, rhs[1].Pos), nil
+
+		// decl → fragment semi_opt
+		case 58:
+			return nil, nil
+
+		// priorities → TOKEN
+		case 57:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			table.AddPriority(token, rhs[0].Pos)
+
+			return []grammar.Terminal{token}, nil
+
+		// priorities → priorities TOKEN
+		case 56:
+			tokens := rhs[0].Val.([]grammar.Terminal)
+			token := grammar.Terminal(rhs[1].Val.(string))
+			table.AddPriority(token, rhs[1].Pos)
+
+			return append(tokens, token), nil
+
+		// directive → "@priority" priorities
+		case 55:
+			return rhs[1].Val, nil
+
+		// term → ISTRING
+		case 54:
+			a := grammar.Terminal(rhs[0].Val.(string))
+			table.AddIgnoreCaseStringTerminal(a, rhs[0].Pos)
+			return a, nil
+
+		// token → TOKEN "=" ISTRING action
+		case 53:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			value := rhs[2].Val.(string)
+
+			def := table.AddStringTokenDef(token, value, rhs[0].Pos)
+			def.IgnoreCase = true
+			def.Action = rhs[3].Val.(*ModeAction)
+
+			return def, nil
+
+		// token → TOKEN "=" ISTRING
+		case 52:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			value := rhs[2].Val.(string)
+
+			def := table.AddStringTokenDef(token, value, rhs[0].Pos)
+			def.IgnoreCase = true
+
+			return def, nil
+
+		// directive → "@indent"
+		case 51:
+			table.SetIndent(rhs[0].Pos)
+			return nil, nil
+
+		// chars → ε
+		case 50:
+			return []rune{}, nil
+
+		// chars → chars STRING
+		case 49:
+			chars := rhs[0].Val.([]rune)
+			value := rhs[1].Val.(string)
+
+			unquoted, err := unquote(value)
+			if err != nil {
+				errs = errors.Append(errs, fmt.Errorf("invalid whitespace string %q: %s", value, err))
+				return chars, nil
+			}
+
+			return append(chars, []rune(unquoted)...), nil
+
+		// directive → "@whitespace" chars
+		case 48:
+			chars := rhs[1].Val.([]rune)
+			table.SetWhitespaces(chars, rhs[0].Pos)
+
+			return chars, nil
+
+		// skips → TOKEN
+		case 47:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			table.AddSkip(token, rhs[0].Pos)
+
+			return []grammar.Terminal{token}, nil
+
+		// skips → skips TOKEN
+		case 46:
+			tokens := rhs[0].Val.([]grammar.Terminal)
+			token := grammar.Terminal(rhs[1].Val.(string))
+			table.AddSkip(token, rhs[1].Pos)
+
+			return append(tokens, token), nil
+
+		// directive → "@skip" skips
+		case 45:
+			return rhs[1].Val, nil
+
+		// action → "@switch" IDENT
+		case 44:
+			return &ModeAction{
+				Kind: SwitchMode,
+				Mode: rhs[1].Val.(string),
+				Pos:  rhs[0].Pos,
+			}, nil
+
+		// action → "@pop"
+		case 43:
+			return &ModeAction{
+				Kind: PopMode,
+				Pos:  rhs[0].Pos,
+			}, nil
+
+		// action → "@push" IDENT
+		case 42:
+			return &ModeAction{
+				Kind: PushMode,
+				Mode: rhs[1].Val.(string),
+				Pos:  rhs[0].Pos,
+			}, nil
+
+		// token → TOKEN "=" PREDEF action
+		case 41:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			value := rhs[2].Val.(string)
+
+			regex, ok := parser.Predefs[value]
+			if !ok {
+				errs = errors.Append(errs, fmt.Errorf("invalid predefined regex: %s", value))
+				return nil, nil
+			}
+
+			def := table.AddRegexTokenDef(token, regex, rhs[0].Pos)
+			def.Action = rhs[3].Val.(*ModeAction)
+
+			return def, nil
+
+		// token → TOKEN "=" REGEX action
+		case 40:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			regex := rhs[2].Val.(string)
+
+			def := table.AddRegexTokenDef(token, regex, rhs[0].Pos)
+			def.Action = rhs[3].Val.(*ModeAction)
+
+			return def, nil
+
+		// token → TOKEN "=" STRING action
+		case 39:
+			token := grammar.Terminal(rhs[0].Val.(string))
+			value := rhs[2].Val.(string)
+
+			def := table.AddStringTokenDef(token, value, rhs[0].Pos)
+			def.Action = rhs[3].Val.(*ModeAction)
+
+			return def, nil
+
+		// mode_decls → ε
+		case 38:
+			return []*TerminalDef{}, nil
+
+		// mode_decls → mode_decls token semi_opt
+		case 37:
+			defs := rhs[0].Val.([]*TerminalDef)
+			if def, ok := rhs[1].Val.(*TerminalDef); ok {
+				document(def.Terminal, rhs[1].Pos)
+				defs = append(defs, def)
+			}
+
+			return defs, nil
+
+		// mode → "@mode" IDENT "{" mode_decls "}"
+		case 36:
+			name := rhs[1].Val.(string)
+			defs := rhs[3].Val.([]*TerminalDef)
+
+			table.AddMode(name, rhs[1].Pos)
+			for _, def := range defs {
+				def.Mode = name
+			}
+
+			return nil, nil
+
+		// decl → mode
+		case 35:
+			return nil, nil
+
 		// term → STRING
 		case 34:
 			a := grammar.Terminal(rhs[0].Val.(string))
@@ -36,79 +835,115 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 		// term → TOKEN
 		case 33:
 			a := grammar.Terminal(rhs[0].Val.(string))
+
+			// A parameter of a parameterized rule is evaluated to a placeholder non-terminal with the same name.
+			if table.IsParam(a) {
+				return grammar.NonTerminal(a), nil
+			}
+
 			table.AddTokenTerminal(a, rhs[0].Pos)
 			return a, nil
 
 		// nonterm → IDENT
 		case 32:
 			A := grammar.NonTerminal(rhs[0].Val.(string))
+
+			// The name of the error token is resolved where it is used, since it is not a non-terminal on the right-hand side.
+			if A.Name() == ErrorToken.Name() {
+				return A, nil
+			}
+
 			table.AddNonTerminal(A, rhs[0].Pos)
 			return A, nil
 
 		// rhs → term
 		case 31:
-			a := rhs[0].Val.(grammar.Terminal)
-			α := grammar.String[grammar.Symbol]{a}
-			return Strings{α}, nil
+			X := rhs[0].Val.(grammar.Symbol)
+			α := grammar.String[grammar.Symbol]{X}
+			return fragment{Strings{α}, X.String(), nil, nil, nil}, nil
 
 		// rhs → nonterm
 		case 30:
 			A := rhs[0].Val.(grammar.NonTerminal)
+
+			if A.Name() == ErrorToken.Name() {
+				table.AddErrorToken(rhs[0].Pos)
+				α := grammar.String[grammar.Symbol]{ErrorToken}
+				return fragment{Strings{α}, A.Name(), nil, nil, nil}, nil
+			}
+
 			α := grammar.String[grammar.Symbol]{A}
-			return Strings{α}, nil
+			return fragment{Strings{α}, string(A), nil, nil, nil}, nil
 
 		// rhs → rhs "|"
 		case 29:
-			s := rhs[0].Val.(Strings)
+			f := rhs[0].Val.(fragment)
 
-			all := make(Strings, 0, len(s)+1)
-			all = append(all, s...)
+			all := make(Strings, 0, len(f.Strings)+1)
+			all = append(all, f.Strings...)
 			all = append(all, grammar.E)
 
-			return all, nil
+			ε := fragment{Strings: Strings{grammar.E}}
+
+			return fragment{all, f.Text + " |", joinLabels(f, ε), joinFields(f, ε), joinActions(f, ε)}, nil
 
 		// rhs → rhs "|" rhs
 		case 28:
-			s1 := rhs[0].Val.(Strings)
-			s2 := rhs[2].Val.(Strings)
+			f1 := rhs[0].Val.(fragment)
+			f2 := rhs[2].Val.(fragment)
 
-			all := make(Strings, 0, len(s1)+len(s2))
-			all = append(all, s1...)
-			all = append(all, s2...)
+			all := make(Strings, 0, len(f1.Strings)+len(f2.Strings))
+			all = append(all, f1.Strings...)
+			all = append(all, f2.Strings...)
 
-			return all, nil
+			return fragment{all, f1.Text + " | " + f2.Text, joinLabels(f1, f2), joinFields(f1, f2), joinActions(f1, f2)}, nil
 
 		// rhs → "{{" rhs "}}"
 		case 27:
-			s := rhs[1].Val.(Strings)
+			f := rhs[1].Val.(fragment)
+			s := f.Strings
+			text := "{{ " + f.Text + " }}"
+
+			misplaced(f)
+
 			plus := table.GetPlus(s)
 			table.AddNonTerminal(plus, rhs[1].Pos)
+			table.AddOrigin(plus, text, rhs[0].Pos)
 
-			for _, α := range s {
-				table.AddProduction(
-					&grammar.Production{Head: plus, Body: α.Prepend(plus)},
-					rhs[0].Pos,
-				)
+			for i, α := range s {
+				p1 := &grammar.Production{Head: plus, Body: α.Prepend(plus)}
+				p2 := &grammar.Production{Head: plus, Body: α}
 
-				table.AddProduction(
-					&grammar.Production{Head: plus, Body: α},
-					rhs[0].Pos,
-				)
+				table.AddProduction(p1, rhs[0].Pos)
+				table.AddProduction(p2, rhs[0].Pos)
+
+				if fields := f.fieldsAt(i); fields != nil {
+					table.AddFields(p1, append([]string{""}, fields...), rhs[0].Pos)
+					table.AddFields(p2, fields, rhs[0].Pos)
+				}
 			}
 
-			return Strings{{plus}}, nil
+			return fragment{Strings{{plus}}, text, nil, nil, nil}, nil
 
 		// rhs → "{" rhs "}"
 		case 26:
-			s := rhs[1].Val.(Strings)
+			f := rhs[1].Val.(fragment)
+			s := f.Strings
+			text := "{ " + f.Text + " }"
+
+			misplaced(f)
+
 			star := table.GetStar(s)
 			table.AddNonTerminal(star, rhs[1].Pos)
+			table.AddOrigin(star, text, rhs[0].Pos)
+
+			for i, α := range s {
+				p := &grammar.Production{Head: star, Body: α.Prepend(star)}
+				table.AddProduction(p, rhs[0].Pos)
 
-			for _, α := range s {
-				table.AddProduction(
-					&grammar.Production{Head: star, Body: α.Prepend(star)},
-					rhs[0].Pos,
-				)
+				if fields := f.fieldsAt(i); fields != nil {
+					table.AddFields(p, append([]string{""}, fields...), rhs[0].Pos)
+				}
 			}
 
 			table.AddProduction(
@@ -116,39 +951,67 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 				rhs[0].Pos,
 			)
 
-			return Strings{{star}}, nil
+			return fragment{Strings{{star}}, text, nil, nil, nil}, nil
 
 		// rhs → "[" rhs "]"
 		case 25:
-			s := rhs[1].Val.(Strings)
+			f := rhs[1].Val.(fragment)
+			misplaced(f)
 
-			all := make(Strings, 0, len(s)+1)
-			all = append(all, s...)
+			all := make(Strings, 0, len(f.Strings)+1)
+			all = append(all, f.Strings...)
 			all = append(all, grammar.E)
 
-			return all, nil
+			return fragment{all, "[ " + f.Text + " ]", nil, joinFields(f, fragment{Strings: Strings{grammar.E}}), nil}, nil
 
 		// rhs → "(" rhs ")"
 		case 24:
-			return rhs[1].Val, nil
+			f := rhs[1].Val.(fragment)
+			misplaced(f)
+
+			return fragment{f.Strings, "( " + f.Text + " )", nil, f.Fields, nil}, nil
 
 		// rhs → rhs rhs
 		case 23:
-			s1 := rhs[0].Val.(Strings)
-			s2 := rhs[1].Val.(Strings)
+			f1 := rhs[0].Val.(fragment)
+			f2 := rhs[1].Val.(fragment)
+			misplaced(f1, f2)
+
+			all := make(Strings, 0, len(f1.Strings)*len(f2.Strings))
+			var fields [][]string
+			if f1.Fields != nil || f2.Fields != nil {
+				fields = make([][]string, 0, len(f1.Strings)*len(f2.Strings))
+			}
 
-			all := make(Strings, 0, len(s1)*len(s2))
-			for _, α := range s1 {
-				for _, β := range s2 {
+			for i, α := range f1.Strings {
+				for j, β := range f2.Strings {
 					all = append(all, α.Concat(β))
+					if fields != nil {
+						fields = append(fields, concatFields(α, β, f1.fieldsAt(i), f2.fieldsAt(j)))
+					}
 				}
 			}
 
-			return all, nil
+			return fragment{all, f1.Text + " " + f2.Text, nil, fields, nil}, nil
 
 		// lhs → nonterm
 		case 22:
-			return rhs[0].Val, nil
+			A := rhs[0].Val.(grammar.NonTerminal)
+
+			if A.Name() == ErrorToken.Name() {
+				errs = errors.Append(errs, fmt.Errorf("non-terminal name %s is reserved: %s", A, rhs[0].Pos))
+				table.AddNonTerminal(A, rhs[0].Pos)
+			}
+
+			// The production rules that follow replace the existing production rules of an overridden rule.
+			if table.InOverride() {
+				table.EndOverride()
+				if n := table.RemoveRule(A); n == 0 {
+					errs = errors.Append(errs, fmt.Errorf("no production rules to override for non-terminal %s: %s", A, rhs[0].Pos))
+				}
+			}
+
+			return A, nil
 
 		// rule → lhs "="
 		case 21:
@@ -157,6 +1020,12 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 				Body: grammar.E,
 			}
 
+			if table.InMacro() {
+				table.AddMacroProduction(p, nil, rhs[0].Pos)
+				table.EndMacro()
+				return []*grammar.Production{}, nil
+			}
+
 			table.AddProduction(p, rhs[0].Pos)
 			prods := []*grammar.Production{p}
 
@@ -165,13 +1034,44 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 		// rule → lhs "=" rhs
 		case 20:
 			head := rhs[0].Val.(grammar.NonTerminal)
-			s := rhs[2].Val.(Strings)
+			f := rhs[2].Val.(fragment)
+
+			// The production rules of a parameterized rule are templates that are expanded for each instantiation.
+			if table.InMacro() {
+				for _, l := range f.labels() {
+					errs = errors.Append(errs, fmt.Errorf("label #%s in parameterized rule %s: %s", l.Name, head, l.Pos))
+				}
+
+				// The positions of the symbols in the production rules change with the arguments.
+				for _, a := range f.actions() {
+					errs = errors.Append(errs, fmt.Errorf("action in parameterized rule %s: %s", head, a.Pos))
+				}
+
+				for i, α := range f.Strings {
+					table.AddMacroProduction(&grammar.Production{Head: head, Body: α}, f.fieldsAt(i), rhs[0].Pos)
+				}
+
+				table.EndMacro()
+				return []*grammar.Production{}, nil
+			}
 
 			prods := []*grammar.Production{}
-			for _, α := range s {
+			for i, α := range f.Strings {
 				p := &grammar.Production{Head: head, Body: α}
 				table.AddProduction(p, rhs[0].Pos)
 				prods = append(prods, p)
+
+				if l := f.labelAt(i); l != nil {
+					table.AddLabel(l.Name, p, l.Pos)
+				}
+
+				if fields := f.fieldsAt(i); fields != nil {
+					table.AddFields(p, fields, rhs[0].Pos)
+				}
+
+				if a := f.actionAt(i); a != nil {
+					table.AddAction(p, a)
+				}
 			}
 
 			return prods, nil
@@ -277,27 +1177,27 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 				return nil, nil
 			}
 
-			table.AddRegexTokenDef(token, regex, rhs[0].Pos)
+			def := table.AddRegexTokenDef(token, regex, rhs[0].Pos)
 
-			return nil, nil
+			return def, nil
 
 		// token → TOKEN "=" REGEX
 		case 10:
 			token := grammar.Terminal(rhs[0].Val.(string))
 			regex := rhs[2].Val.(string)
 
-			table.AddRegexTokenDef(token, regex, rhs[0].Pos)
+			def := table.AddRegexTokenDef(token, regex, rhs[0].Pos)
 
-			return nil, nil
+			return def, nil
 
 		// token → TOKEN "=" STRING
 		case 9:
 			token := grammar.Terminal(rhs[0].Val.(string))
 			value := rhs[2].Val.(string)
 
-			table.AddStringTokenDef(token, value, rhs[0].Pos)
+			def := table.AddStringTokenDef(token, value, rhs[0].Pos)
 
-			return nil, nil
+			return def, nil
 
 		// semi_opt → ε
 		case 8:
@@ -309,6 +1209,11 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 
 		// decl → rule ";"
 		case 6:
+			// The production rules of a parameterized rule are not kept, so it is not documented.
+			if prods, ok := rhs[0].Val.([]*grammar.Production); ok && len(prods) > 0 {
+				document(prods[0].Head, rhs[0].Pos)
+			}
+
 			return nil, nil
 
 		// decl → directive semi_opt
@@ -317,6 +1222,11 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 
 		// decl → token semi_opt
 		case 4:
+			// The token is nil if its definition is invalid, which has been already reported.
+			if def, ok := rhs[0].Val.(*TerminalDef); ok {
+				document(def.Terminal, rhs[0].Pos)
+			}
+
 			return nil, nil
 
 		// decls → ε
@@ -333,14 +1243,48 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 
 		// grammar → name decls
 		case 0:
+			// An imported file only contributes its declarations to the symbol table.
+			if len(files) > 1 {
+				return nil, nil
+			}
+
+			if err := table.ExpandMacros(); err != nil {
+				errs = errors.Append(errs, err)
+				return nil, errs
+			}
+
+			if err := table.ApplyRemovals(); err != nil {
+				errs = errors.Append(errs, err)
+				return nil, errs
+			}
+
 			if err := table.Verify(); err != nil {
 				errs = errors.Append(errs, err)
 				return nil, errs
 			}
 
 			defs := table.Definitions()
+			starts := table.Starts()
+			terms, nonTerms, prods, S := table.Terminals(), table.NonTerminals(), table.Productions(), start
+
+			// A single start symbol replaces the default one.
+			// Multiple start symbols are derived from a synthesized entry non-terminal,
+			// each one preceded by a marker terminal that selects it as the entry point for parsing.
+			if len(starts) == 1 {
+				S = starts[0]
+			} else if len(starts) > 1 {
+				S = Entry
+				nonTerms = append(nonTerms, Entry)
+				for _, A := range starts {
+					terms = append(terms, EntryMarker(A))
+					prods = append(prods, &grammar.Production{
+						Head: Entry,
+						Body: grammar.String[grammar.Symbol]{EntryMarker(A), A},
+					})
+				}
+			}
 
-			grammar := grammar.NewCFG(table.Terminals(), table.NonTerminals(), table.Productions(), "start")
+			grammar := grammar.NewCFG(terms, nonTerms, prods, S)
 			if err := grammar.Verify(); err != nil {
 				errs = errors.Append(errs, err)
 			}
@@ -357,17 +1301,134 @@ func Parse(filename string, src io.Reader) (*Spec, error) {
 			return &Spec{
 				Name:        rhs[0].Val.(string),
 				Definitions: defs,
+				Fragments:   table.Fragments(),
+				Modes:       table.Modes(),
+				Whitespaces: table.Whitespaces(),
+				Indent:      table.Indent(),
+				Externals:   table.Externals(),
 				Grammar:     grammar,
 				Precedences: precedences,
+				Positions:   table.Positions(),
+				Origins:     table.Origins(),
+				Labels:      table.Labels(),
+				Fields:      table.Fields(),
+				Starts:      starts,
+				Lists:       table.Lists(),
+				Actions:     table.Actions(),
+				CodeBlocks:  table.CodeBlocks(),
+
+				TerminalDocs:    table.TerminalDocs(),
+				NonTerminalDocs: table.NonTerminalDocs(),
 			}, nil
 		}
 
 		return nil, fmt.Errorf("invalid production index: %d", i)
-	})
+	}
+
+	parse = func(filename, path string, src io.Reader) (*lr.Value, error) {
+		p, err := parser.New(filename, src)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, filepath.Clean(filename))
+		paths = append(paths, path)
+		parsers = append(parsers, p)
+		imported[path] = true
+
+		defer func() {
+			files = files[:len(files)-1]
+			paths = paths[:len(paths)-1]
+			parsers = parsers[:len(parsers)-1]
+		}()
 
+		return p.ParseAndEvaluate(eval)
+	}
+
+	res, err := parse(filename, absPath(filepath.Join(dir, filepath.Base(filename))), src)
 	if err != nil {
 		return nil, err
 	}
 
 	return res.Val.(*Spec), nil
 }
+
+// absPath returns the absolute representation of a path, so a file is identified by the same path however it is reached.
+// It returns the cleaned path if the absolute representation cannot be determined.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+
+	return filepath.Clean(path)
+}
+
+// docComment returns the doc comment of a declaration at a given position with the comment markers removed.
+// The doc comment is the group of consecutive comments ending on the line directly above the declaration.
+// It returns an empty string if no comment ends on that line.
+func docComment(comments []lexer.Token, pos *lexer.Position) string {
+	var group []string
+
+	line := pos.Line
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		end := c.Pos.Line + strings.Count(c.Lexeme, "\n")
+
+		// Skip the comments that come after the declaration.
+		if end >= line && len(group) == 0 {
+			continue
+		}
+
+		if end != line-1 {
+			break
+		}
+
+		group = append(group, c.Lexeme)
+		line = c.Pos.Line
+	}
+
+	slices.Reverse(group)
+
+	var lines []string
+	for _, comment := range group {
+		if text, ok := strings.CutPrefix(comment, "//"); ok {
+			lines = append(lines, strings.TrimPrefix(text, " "))
+			continue
+		}
+
+		text := strings.TrimSuffix(strings.TrimPrefix(comment, "/*"), "*/")
+		for _, l := range strings.Split(text, "\n") {
+			lines = append(lines, strings.TrimSpace(l))
+		}
+	}
+
+	for i, l := range lines {
+		lines[i] = strings.TrimRightFunc(l, unicode.IsSpace)
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// unquote interprets the escape sequences in the value of a STRING token, which has its enclosing quotes removed.
+func unquote(value string) (string, error) {
+	var b strings.Builder
+
+	for s := value; len(s) > 0; {
+		// A STRING token may escape a single quote, which a Go string literal does not allow.
+		if strings.HasPrefix(s, `\'`) {
+			b.WriteRune('\'')
+			s = s[2:]
+			continue
+		}
+
+		r, _, tail, err := strconv.UnquoteChar(s, '"')
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteRune(r)
+		s = tail
+	}
+
+	return b.String(), nil
+}
@@ -1,10 +1,16 @@
 package spec
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/parser/lr"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/emerge/internal/ebnf/parser"
 )
 
 func TestParse(t *testing.T) {
@@ -12,6 +18,22 @@ func TestParse(t *testing.T) {
 		name                 string
 		filename             string
 		expectedSpec         *Spec
+		expectedOrigins      map[grammar.NonTerminal]string
+		expectedModes        map[grammar.Terminal][]string
+		expectedSkips        []grammar.Terminal
+		expectedExternals    []grammar.Terminal
+		expectedIgnoreCases  []grammar.Terminal
+		expectedPriorities   map[grammar.Terminal]int
+		expectedFragments    map[string]string
+		expectedRegexes      map[grammar.Terminal]string
+		expectedLabels       map[string]string
+		expectedFields       map[string][]string
+		expectedStarts       []grammar.NonTerminal
+		expectedLists        []grammar.NonTerminal
+		expectedActions      map[string]string
+		expectedCodeBlocks   []string
+		expectedPositions    map[string]string
+		expectedDocs         map[grammar.Symbol]string
 		expectedErrorStrings []string
 	}{
 		{
@@ -31,6 +53,104 @@ func TestParse(t *testing.T) {
 				`missing production rule with the start symbol: start`,
 			},
 		},
+		{
+			name:     "ErrorWithLabels",
+			filename: "../../fixture/test.labels.error.grammar",
+			expectedErrorStrings: []string{
+				`3 errors occurred:`,
+				`label #Group is not at the end of an alternative:`,
+				`label #Sum names 2 productions instead of one:`,
+				`multiple labels #Num and #Int for the same alternative:`,
+			},
+		},
+		{
+			name:     "ErrorWithFields",
+			filename: "../../fixture/test.fields.error.grammar",
+			expectedErrorStrings: []string{
+				`4 errors occurred:`,
+				`field pair does not name a single symbol:`,
+				`multiple fields b and a for the same symbol:`,
+				`multiple symbols named x in production start → "NUM" "NUM":`,
+				`conflicting field names for production start → "NUM":`,
+			},
+		},
+		{
+			name:     "ErrorWithStarts",
+			filename: "../../fixture/test.starts.error.grammar",
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`missing production rule with the start symbol: stmt`,
+				`multiple start declarations for non-terminal expr:`,
+			},
+		},
+		{
+			name:     "ErrorWithMacros",
+			filename: "../../fixture/test.macros.error.grammar",
+			expectedErrorStrings: []string{
+				`5 errors occurred:`,
+				`duplicate parameter X:`,
+				`parameterized rule list is used without arguments:`,
+				`parameterized rule pair expects 2 arguments, but 1 are given:`,
+				`undefined parameterized rule tuple:`,
+				`non-terminating expansion of parameterized rule nest<( X X )> in nest:`,
+			},
+		},
+		{
+			name:     "ErrorWithActions",
+			filename: "../../fixture/test.actions.error.grammar",
+			expectedErrorStrings: []string{
+				`5 errors occurred:`,
+				`syntax error in Go code: expected operand, found '}':`,
+				`reference $4 out of range for 3 symbols in action:`,
+				`undefined reference $rhs in action:`,
+				`action for 2 productions instead of one:`,
+				`multiple actions for the same alternative:`,
+			},
+		},
+		{
+			name:     "ErrorWithRecovery",
+			filename: "../../fixture/test.recovery.error.grammar",
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`non-terminal name error is reserved:`,
+				`terminal name "error" is reserved`,
+			},
+		},
+		{
+			name:     "ErrorWithImports",
+			filename: "../../fixture/test.imports.error.grammar",
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`import cycle ../../fixture/imports/cycle.a.grammar → ../../fixture/imports/cycle.b.grammar → ../../fixture/imports/cycle.a.grammar: ../../fixture/imports/cycle.b.grammar:4:8`,
+				`cannot import ../../fixture/imports/missing.grammar: no such file or directory: ../../fixture/test.imports.error.grammar:5:8`,
+			},
+		},
+		{
+			name:     "ErrorWithDialect",
+			filename: "../../fixture/test.dialect.error.grammar",
+			expectedErrorStrings: []string{
+				`no definition to override for terminal FLOAT: ../../fixture/test.dialect.error.grammar:6:11`,
+				`precedence level overlaps 2 precedence levels: ../../fixture/test.dialect.error.grammar:7:1`,
+				`no production rules to override for non-terminal decl: ../../fixture/test.dialect.error.grammar:8:11`,
+				`no precedence level to remove: ../../fixture/test.dialect.error.grammar:10:1`,
+				`no production rule expr → expr "%" expr to remove: ../../fixture/test.dialect.error.grammar:11:1`,
+				`no production rules to remove for non-terminal term: ../../fixture/test.dialect.error.grammar:12:9`,
+				`no definition to remove for terminal CHAR: ../../fixture/test.dialect.error.grammar:13:9`,
+				`terminal ID is removed but still used in stmt → ID "=" expr ";": ../../fixture/imports/base.grammar:13:1`,
+			},
+		},
+		{
+			name:     "ErrorWithExternals",
+			filename: "../../fixture/test.external.error.grammar",
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`external terminal "RAW_STRING" cannot be defined:`,
+				`../../fixture/test.external.error.grammar:4:1`,
+				`multiple external declarations for terminal "COMMENT":`,
+				`../../fixture/test.external.error.grammar:6:22`,
+				`../../fixture/test.external.error.grammar:7:11`,
+			},
+		},
 		{
 			name:     "Success",
 			filename: "../../fixture/test.success.grammar",
@@ -40,6 +160,407 @@ func TestParse(t *testing.T) {
 				Grammar:     grammars[1],
 				Precedences: precedences[1],
 			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_decl_star": `{ decl }@../../fixture/test.success.grammar:13:13`,
+				"gen_stmt_plus": `{{ stmt }}@../../fixture/test.success.grammar:13:20`,
+			},
+		},
+		{
+			name:     "SuccessWithModes",
+			filename: "../../fixture/test.modes.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Modes:       []string{"string", "interp"},
+				Grammar:     grammars[2],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_value_star": `{ value }@../../fixture/test.modes.grammar:19:9`,
+				"gen_part_star":  `{ part }@../../fixture/test.modes.grammar:20:14`,
+			},
+			expectedModes: map[grammar.Terminal][]string{
+				"OPEN":   {"", "@push string"},
+				"ID":     {"", "", "interp", ""},
+				"NUM":    {"", ""},
+				"CLOSE":  {"string", "@pop"},
+				"INTERP": {"string", "@push interp"},
+				"CHARS":  {"string", ""},
+				"RBRACE": {"interp", "@pop"},
+			},
+		},
+		{
+			name:     "SuccessWithSkips",
+			filename: "../../fixture/test.skip.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[3],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.skip.grammar:10:9`,
+			},
+			expectedSkips: []grammar.Terminal{"COMMENT", "SHEBANG"},
+		},
+		{
+			name:     "SuccessWithWhitespaces",
+			filename: "../../fixture/test.whitespace.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Whitespaces: []rune{' ', '\t'},
+				Grammar:     grammars[4],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_line_star": `{ line }@../../fixture/test.whitespace.grammar:9:9`,
+			},
+		},
+		{
+			name:     "SuccessWithIndent",
+			filename: "../../fixture/test.indent.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Indent:      true,
+				Grammar:     grammars[5],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.indent.grammar:8:9`,
+				"gen_stmt_plus": `{{ stmt }}@../../fixture/test.indent.grammar:9:40`,
+			},
+		},
+		{
+			name:     "SuccessWithIgnoreCase",
+			filename: "../../fixture/test.case.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[6],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.case.grammar:8:15`,
+			},
+			expectedIgnoreCases: []grammar.Terminal{"BEGIN", "END", "then"},
+		},
+		{
+			name:     "SuccessWithPriorities",
+			filename: "../../fixture/test.priority.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[7],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_value_star": `{ value }@../../fixture/test.priority.grammar:11:9`,
+			},
+			expectedPriorities: map[grammar.Terminal]int{
+				"OCT_INT": 1,
+				"INT":     0,
+				"BUILTIN": 2,
+				"ID":      0,
+			},
+		},
+		{
+			name:     "SuccessWithFragments",
+			filename: "../../fixture/test.fragment.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[8],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_value_star": `{ value }@../../fixture/test.fragment.grammar:11:9`,
+			},
+			expectedFragments: map[string]string{
+				"DIGIT":  `[0-9]`,
+				"DIGITS": `{DIGIT}+`,
+				"EXP":    `[eE][-+]?{DIGITS}`,
+			},
+		},
+		{
+			name:     "SuccessWithPredefs",
+			filename: "../../fixture/test.predef.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[9],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_value_star": `{ value }@../../fixture/test.predef.grammar:19:9`,
+			},
+			expectedRegexes: map[grammar.Terminal]string{
+				"ID":            parser.Predefs["$IDENT"],
+				"HEX_INT":       parser.Predefs["$HEX_INT"],
+				"OCT_INT":       parser.Predefs["$OCT_INT"],
+				"BIN_INT":       parser.Predefs["$BIN_INT"],
+				"INT":           parser.Predefs["$DEC_INT"],
+				"FLOAT":         parser.Predefs["$FLOAT"],
+				"STRING":        parser.Predefs["$STRING"],
+				"CHAR":          parser.Predefs["$SQ_STRING"],
+				"RAW":           parser.Predefs["$RAW_STRING"],
+				"LINE_COMMENT":  parser.Predefs["$LINE_COMMENT"],
+				"BLOCK_COMMENT": parser.Predefs["$BLOCK_COMMENT"],
+			},
+		},
+		{
+			name:     "SuccessWithLabels",
+			filename: "../../fixture/test.labels.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[10],
+				Precedences: precedences[0],
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{},
+			expectedLabels: map[string]string{
+				"Add":   `expr → expr "+" expr`,
+				"Sub":   `expr → expr "-" expr`,
+				"Mul":   `expr → expr "*" expr`,
+				"Div":   `expr → expr "/" expr`,
+				"Paren": `expr → "(" expr ")"`,
+				"Num":   `expr → "NUM"`,
+			},
+		},
+		{
+			name:     "SuccessWithFields",
+			filename: "../../fixture/test.fields.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[11],
+				Precedences: lr.PrecedenceLevels{
+					{
+						Associativity: lr.LEFT,
+						Handles: lr.NewPrecedenceHandles(
+							lr.PrecedenceHandleForTerminal("+"),
+							lr.PrecedenceHandleForTerminal("-"),
+						),
+					},
+				},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.fields.grammar:9:15`,
+				"gen_expr_plus": `{{ arg:expr }}@../../fixture/test.fields.grammar:11:22`,
+			},
+			expectedFields: map[string][]string{
+				`start → gen_stmt_star`:              {"stmts"},
+				`stmt → "let" "ID" "=" expr ";"`:     {"", "name", "", "value", ""},
+				`stmt → "let" "ID" ";"`:              {"", "name", ""},
+				`stmt → "print" gen_expr_plus ";"`:   {"", "args", ""},
+				`gen_expr_plus → gen_expr_plus expr`: {"", "arg"},
+				`gen_expr_plus → expr`:               {"arg"},
+				`expr → expr "+" expr`:               {"lhs", "op", "rhs"},
+				`expr → expr "-" expr`:               {"lhs", "op", "rhs"},
+				`expr → "NUM"`:                       {"value"},
+				`expr → "ID"`:                        {"ref"},
+			},
+		},
+		{
+			name:     "SuccessWithStarts",
+			filename: "../../fixture/test.starts.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[12],
+				Precedences: precedences[0],
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{},
+			expectedStarts:  []grammar.NonTerminal{"stmt", "expr"},
+		},
+		{
+			name:     "SuccessWithLists",
+			filename: "../../fixture/test.lists.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[13],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_list": `{{ stmt % ";" }}@../../fixture/test.lists.grammar:6:9`,
+				"gen_arg_list":  `{ arg % "," }@../../fixture/test.lists.grammar:7:23`,
+				"gen1_list":     `{ "ID" %% "," }@../../fixture/test.lists.grammar:8:20`,
+			},
+			expectedLists: []grammar.NonTerminal{"gen_stmt_list", "gen_arg_list", "gen1_list"},
+		},
+		{
+			name:     "SuccessWithMacros",
+			filename: "../../fixture/test.macros.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[14],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_sep_binding_semi":       `sep<binding, ";">@../../fixture/test.macros.grammar:7:19`,
+				"gen_sep_expr_comma":         `sep<expr, ",">@../../fixture/test.macros.grammar:8:31`,
+				"gen_opt_gen_sep_expr_comma": `opt<sep<expr, ",">>@../../fixture/test.macros.grammar:8:27`,
+				"gen_block_start":            `block<start>@../../fixture/test.macros.grammar:9:13`,
+				"gen_start_star":             `{ X }@../../fixture/test.macros.grammar:15:23`,
+			},
+			expectedFields: map[string][]string{
+				`binding → "ID" "=" expr`:                  {"name", "", "value"},
+				`gen_block_start → "{" gen_start_star "}"`: {"", "items", ""},
+			},
+		},
+		{
+			name:     "SuccessWithActions",
+			filename: "../../fixture/test.actions.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[10],
+				Precedences: precedences[0],
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{},
+			expectedLabels: map[string]string{
+				"Div": `expr → expr "/" expr`,
+			},
+			expectedFields: map[string][]string{
+				`expr → expr "+" expr`: {"lhs", "", "rhs"},
+				`expr → expr "-" expr`: {"lhs", "", "rhs"},
+				`expr → expr "*" expr`: {"lhs", "", "rhs"},
+				`expr → expr "/" expr`: {"lhs", "", "rhs"},
+			},
+			expectedActions: map[string]string{
+				`expr → expr "+" expr`: ` _0 = _1.(int) + _3.(int) `,
+				`expr → expr "-" expr`: ` _0 = _1.(int) - _3.(int) `,
+				`expr → expr "*" expr`: ` _0 = _1.(int) * _3.(int) `,
+				`expr → expr "/" expr`: "\n          if _3.(int) == 0 {\n            return nil, errors.New(\"division by zero\")\n          }\n          _0 = _1.(int) / _3.(int)\n        ",
+				`expr → "(" expr ")"`:  ` _0 = _2 `,
+				`expr → "NUM"`:         ` _0, err = strconv.Atoi(_1) `,
+			},
+			expectedCodeBlocks: []string{
+				"\nimport (\n  \"errors\"\n  \"strconv\"\n)\n",
+			},
+		},
+		{
+			name:     "SuccessWithRecovery",
+			filename: "../../fixture/test.recovery.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[15],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.recovery.grammar:7:9`,
+			},
+			expectedRegexes: map[grammar.Terminal]string{
+				"=":   `=`,
+				";":   `;`,
+				"(":   `(`,
+				")":   `)`,
+				"ID":  `[a-z]+`,
+				"NUM": `[0-9]+`,
+			},
+		},
+		{
+			name:     "SuccessWithImports",
+			filename: "../../fixture/test.imports.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[16],
+				Precedences: precedences[0],
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.imports.grammar:7:9`,
+			},
+			expectedRegexes: map[grammar.Terminal]string{
+				"=":   `=`,
+				";":   `;`,
+				"+":   `+`,
+				"-":   `-`,
+				"*":   `*`,
+				"/":   `/`,
+				"(":   `(`,
+				")":   `)`,
+				"ID":  `[A-Za-z_][0-9A-Za-z_]*`,
+				"NUM": `[0-9]+`,
+			},
+			expectedPositions: map[string]string{
+				`start → gen_stmt_star`:  `../../fixture/test.imports.grammar:7:1`,
+				`stmt → ID "=" expr ";"`: `../../fixture/imports/stmt.grammar:8:1`,
+				`expr → "(" expr ")"`:    `../../fixture/imports/expr.grammar:9:1`,
+				`expr → NUM`:             `../../fixture/imports/expr.grammar:9:1`,
+			},
+		},
+		{
+			name:     "SuccessWithDialect",
+			filename: "../../fixture/test.dialect.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[17],
+				Precedences: precedences[2],
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.dialect.grammar:11:9`,
+			},
+			expectedRegexes: map[grammar.Terminal]string{
+				"=":   `=`,
+				";":   `;`,
+				"+=":  `+=`,
+				"+":   `+`,
+				"-":   `-`,
+				"*":   `*`,
+				"%":   `%`,
+				"<":   `<`,
+				"(":   `(`,
+				")":   `)`,
+				"ID":  `[A-Za-z_][0-9A-Za-z_]*`,
+				"NUM": `[0-9][0-9_]*`,
+			},
+			expectedPositions: map[string]string{
+				`start → gen_stmt_star`:   `../../fixture/imports/base.grammar:11:1`,
+				`stmt → ID "=" expr ";"`:  `../../fixture/test.dialect.grammar:14:11`,
+				`stmt → ID "+=" expr ";"`: `../../fixture/test.dialect.grammar:14:11`,
+				`expr → NUM`:              `../../fixture/imports/base.grammar:17:1`,
+				`expr → expr "%" expr`:    `../../fixture/test.dialect.grammar:18:1`,
+			},
+		},
+		{
+			name:     "SuccessWithExternals",
+			filename: "../../fixture/test.external.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[18],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.external.grammar:9:9`,
+			},
+			expectedSkips:     []grammar.Terminal{"COMMENT"},
+			expectedExternals: []grammar.Terminal{"RAW_STRING", "COMMENT"},
+		},
+		{
+			name:     "SuccessWithDocs",
+			filename: "../../fixture/test.docs.grammar",
+			expectedSpec: &Spec{
+				Name:        "test",
+				Definitions: []*TerminalDef{},
+				Grammar:     grammars[19],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			expectedOrigins: map[grammar.NonTerminal]string{
+				"gen_stmt_star": `{ stmt }@../../fixture/test.docs.grammar:17:9`,
+			},
+			expectedDocs: map[grammar.Symbol]string{
+				grammar.Terminal("ID"):       "ID is an identifier.",
+				grammar.Terminal("NUM"):      "NUM is a number literal,\nmade of one or more decimal digits.",
+				grammar.NonTerminal("start"): "start is the list of statements.",
+				grammar.NonTerminal("stmt"):  "stmt is a statement,\nwhich assigns a number to an identifier.",
+			},
 		},
 	}
 
@@ -68,8 +589,266 @@ func TestParse(t *testing.T) {
 
 				assert.True(t, spec.Name == tc.expectedSpec.Name)
 				assert.NotNil(t, spec.Definitions)
+				assert.Equal(t, tc.expectedSpec.Whitespaces, spec.Whitespaces)
+				assert.Equal(t, tc.expectedSpec.Indent, spec.Indent)
 				assert.True(t, spec.Grammar.Equal(tc.expectedSpec.Grammar), "Expected:\n%s\nGot:\n%s", tc.expectedSpec.Grammar, spec.Grammar)
 				assert.True(t, spec.Precedences.Equal(tc.expectedSpec.Precedences), "Expected:\n%s\nGot:\n%s", tc.expectedSpec.Precedences, spec.Precedences)
+
+				assert.Equal(t, len(tc.expectedOrigins), spec.Origins.Size())
+				for A, expectedOrigin := range tc.expectedOrigins {
+					assert.Equal(t, expectedOrigin, spec.Origin(A).String())
+				}
+
+				if tc.expectedModes != nil {
+					assert.Equal(t, tc.expectedSpec.Modes, spec.Modes)
+
+					modes := map[grammar.Terminal][]string{}
+					for _, def := range spec.Definitions {
+						action := ""
+						if def.Action != nil {
+							action = def.Action.String()
+						}
+						modes[def.Terminal] = append(modes[def.Terminal], def.Mode, action)
+					}
+
+					assert.Equal(t, tc.expectedModes, modes)
+				}
+
+				if tc.expectedSkips != nil {
+					skips := []grammar.Terminal{}
+					for _, def := range spec.Definitions {
+						if def.Skip {
+							skips = append(skips, def.Terminal)
+						}
+					}
+					for _, def := range spec.Externals {
+						if def.Skip {
+							skips = append(skips, def.Terminal)
+						}
+					}
+
+					assert.ElementsMatch(t, tc.expectedSkips, skips)
+				}
+
+				if tc.expectedExternals != nil {
+					externals := []grammar.Terminal{}
+					for _, def := range spec.Externals {
+						externals = append(externals, def.Terminal)
+					}
+
+					assert.Equal(t, tc.expectedExternals, externals)
+				}
+
+				if tc.expectedIgnoreCases != nil {
+					ignoreCases := []grammar.Terminal{}
+					for _, def := range spec.Definitions {
+						if def.IgnoreCase {
+							ignoreCases = append(ignoreCases, def.Terminal)
+						}
+					}
+
+					assert.ElementsMatch(t, tc.expectedIgnoreCases, ignoreCases)
+				}
+
+				if tc.expectedPriorities != nil {
+					priorities := map[grammar.Terminal]int{}
+					for _, def := range spec.Definitions {
+						priorities[def.Terminal] = def.Priority
+					}
+
+					assert.Equal(t, tc.expectedPriorities, priorities)
+				}
+
+				if tc.expectedFragments != nil {
+					fragments := map[string]string{}
+					for _, def := range spec.Fragments {
+						fragments[def.Name] = def.Value
+					}
+
+					assert.Equal(t, tc.expectedFragments, fragments)
+				}
+
+				if tc.expectedRegexes != nil {
+					regexes := map[grammar.Terminal]string{}
+					for _, def := range spec.Definitions {
+						regexes[def.Terminal] = def.Value
+					}
+
+					assert.Equal(t, tc.expectedRegexes, regexes)
+				}
+
+				if tc.expectedLabels != nil {
+					labels := map[string]string{}
+					for p, label := range spec.Labels.All() {
+						labels[label] = p.String()
+					}
+
+					assert.Equal(t, tc.expectedLabels, labels)
+				}
+
+				if tc.expectedStarts != nil {
+					assert.Equal(t, tc.expectedStarts, spec.Starts)
+				}
+
+				if tc.expectedLists != nil {
+					assert.Equal(t, tc.expectedLists, spec.Lists)
+				}
+
+				if tc.expectedActions != nil {
+					actions := map[string]string{}
+					for p, a := range spec.Actions.All() {
+						actions[p.String()] = a.Expand(func(i int) string {
+							return fmt.Sprintf("_%d", i)
+						})
+					}
+
+					assert.Equal(t, tc.expectedActions, actions)
+				}
+
+				if tc.expectedCodeBlocks != nil {
+					blocks := []string{}
+					for _, block := range spec.CodeBlocks {
+						blocks = append(blocks, block.Code)
+					}
+
+					assert.Equal(t, tc.expectedCodeBlocks, blocks)
+				}
+
+				if tc.expectedPositions != nil {
+					positions := map[string]string{}
+					for p, pos := range spec.Positions.All() {
+						positions[p.String()] = pos.String()
+					}
+
+					for p, expectedPos := range tc.expectedPositions {
+						assert.Equal(t, expectedPos, positions[p])
+					}
+				}
+
+				if tc.expectedFields != nil {
+					fields := map[string][]string{}
+					for p, names := range spec.Fields.All() {
+						fields[p.String()] = names
+					}
+
+					assert.Equal(t, tc.expectedFields, fields)
+				}
+
+				if tc.expectedDocs != nil {
+					assert.Equal(t, len(tc.expectedDocs), spec.TerminalDocs.Size()+spec.NonTerminalDocs.Size())
+					for X, expectedDoc := range tc.expectedDocs {
+						assert.Equal(t, expectedDoc, spec.Doc(X))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	tests := []struct {
+		name                 string
+		dir                  string
+		filename             string
+		expectedPositions    map[string]string
+		expectedErrorStrings []string
+	}{
+		{
+			name:     "Error",
+			dir:      "../../fixture",
+			filename: "test.imports.error.grammar",
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`import cycle imports/cycle.a.grammar → imports/cycle.b.grammar → imports/cycle.a.grammar: imports/cycle.b.grammar:4:8`,
+				`cannot import imports/missing.grammar: no such file or directory: test.imports.error.grammar:5:8`,
+			},
+		},
+		{
+			name:     "Success",
+			dir:      "../../fixture",
+			filename: "grammars/test.imports.grammar",
+			expectedPositions: map[string]string{
+				`start → gen_stmt_star`:  `grammars/test.imports.grammar:7:1`,
+				`stmt → ID "=" expr ";"`: `grammars/imports/stmt.grammar:8:1`,
+				`expr → NUM`:             `grammars/imports/expr.grammar:9:1`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join(tc.dir, filepath.Base(tc.filename)))
+			assert.NoError(t, err)
+
+			defer func() {
+				assert.NoError(t, f.Close())
+			}()
+
+			spec, err := ParseDir(tc.dir, tc.filename, f)
+
+			if len(tc.expectedErrorStrings) > 0 {
+				assert.Nil(t, spec)
+				assert.Error(t, err)
+
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			} else {
+				assert.NotNil(t, spec)
+				assert.NoError(t, err)
+
+				positions := map[string]string{}
+				for p, pos := range spec.Positions.All() {
+					positions[p.String()] = pos.String()
+				}
+
+				for p, expectedPos := range tc.expectedPositions {
+					assert.Equal(t, expectedPos, positions[p])
+				}
+			}
+		})
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			name:          "Empty",
+			value:         ``,
+			expectedValue: "",
+		},
+		{
+			name:          "Escapes",
+			value:         ` \t\n\r\"\'\\`,
+			expectedValue: " \t\n\r\"'\\",
+		},
+		{
+			name:          "Unicode",
+			value:         `\x20\u00A0\U00003000`,
+			expectedValue: "\x20\u00A0\U00003000",
+		},
+		{
+			name:          "Invalid",
+			value:         `\uD800`,
+			expectedError: "invalid syntax",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := unquote(tc.value)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedValue, value)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
 			}
 		})
 	}
@@ -3,12 +3,15 @@ package spec
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/moorara/algo/automata"
 	"github.com/moorara/algo/errors"
 	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
 	"github.com/moorara/algo/parser/lr"
 	"github.com/moorara/algo/parser/lr/canonical"
 	"github.com/moorara/algo/parser/lr/lookahead"
@@ -16,6 +19,7 @@ import (
 	"github.com/moorara/algo/sort"
 	"github.com/moorara/algo/symboltable"
 
+	"github.com/gardenbed/emerge/internal/regex/parser"
 	"github.com/gardenbed/emerge/internal/regex/parser/nfa"
 )
 
@@ -57,34 +61,273 @@ var (
 	}
 )
 
+// Entry is the non-terminal synthesized as the start symbol of a grammar with multiple start symbols.
+const Entry = grammar.NonTerminal("gen_entry")
+
+// EntryMarker returns the marker terminal that selects a start symbol as the entry point for parsing.
+// The marker is never produced by the lexer; the parser injects it as the first input token.
+func EntryMarker(A grammar.NonTerminal) grammar.Terminal {
+	return grammar.Terminal("$" + string(A))
+}
+
+// ErrorToken is the reserved terminal that stands for erroneous input in error recovery production rules, e.g., stmt = error ";".
+// It is never produced by the lexer; the parser shifts it in place of the erroneous input when recovering from a syntax error.
+const ErrorToken = grammar.Terminal("error")
+
 // Spec contains the result of a successful input parsing.
+//
+// Fragments are the named regex fragments that can be referenced from the regular expressions of terminal definitions.
+//
+// Whitespaces is the set of characters the lexer discards when no terminal definition accepts them.
+// If it is nil, all Unicode whitespace characters are discarded.
+// If it is empty, no character is discarded and whitespaces must be handled by the grammar.
+//
+// Indent indicates whether the lexer synthesizes the NEWLINE, INDENT, and DEDENT terminals from the indentation of lines.
+//
+// Externals are the terminals recognized by scanner functions registered with the generated lexer,
+// in the order they are declared by @external directives.
+//
+// Labels are the names given to production rules by labeled alternatives.
+//
+// Fields are the names given to the symbols in the bodies of production rules.
+//
+// Starts are the start symbols declared by @start directives, i.e., the entry points for parsing.
+// If there is more than one, the grammar is augmented with the Entry non-terminal deriving each start symbol
+// preceded by its marker terminal, so a single parsing table serves all entry points.
+//
+// Lists are the non-terminals synthesized for separated lists.
+// Each one is left-recursive, so the recursive production rules can be flattened into a single list.
+//
+// Actions are the semantic actions attached to production rules,
+// and CodeBlocks are the top-level blocks of Go code that the semantic actions depend on.
+//
+// TerminalDocs and NonTerminalDocs are the doc comments placed directly above token declarations and rules.
 type Spec struct {
 	Name        string
 	Definitions []*TerminalDef
+	Fragments   []*FragmentDef
+	Modes       []string
+	Whitespaces []rune
+	Indent      bool
+	Externals   []*ExternalDef
 	Grammar     *grammar.CFG
 	Precedences lr.PrecedenceLevels
+	Positions   symboltable.SymbolTable[*grammar.Production, *lexer.Position]
+	Origins     symboltable.SymbolTable[grammar.NonTerminal, *Origin]
+	Labels      symboltable.SymbolTable[*grammar.Production, string]
+	Fields      symboltable.SymbolTable[*grammar.Production, []string]
+	Starts      []grammar.NonTerminal
+	Lists       []grammar.NonTerminal
+	Actions     symboltable.SymbolTable[*grammar.Production, *SemanticAction]
+	CodeBlocks  []*CodeBlock
+
+	TerminalDocs    symboltable.SymbolTable[grammar.Terminal, string]
+	NonTerminalDocs symboltable.SymbolTable[grammar.NonTerminal, string]
+}
+
+// Origin returns the EBNF construct from which a non-terminal symbol is synthesized.
+// It returns nil if the non-terminal symbol is defined by the grammar itself.
+func (s *Spec) Origin(A grammar.NonTerminal) *Origin {
+	if s.Origins == nil {
+		return nil
+	}
+
+	o, _ := s.Origins.Get(A)
+	return o
+}
+
+// Label returns the label naming a production rule.
+// It returns an empty string if the production rule is not labeled.
+func (s *Spec) Label(p *grammar.Production) string {
+	if s.Labels == nil {
+		return ""
+	}
+
+	label, _ := s.Labels.Get(p)
+	return label
+}
+
+// FieldNames returns the field names of the symbols in the body of a production rule.
+// An unnamed symbol has an empty field name, and nil is returned if no symbol is named.
+func (s *Spec) FieldNames(p *grammar.Production) []string {
+	if s.Fields == nil {
+		return nil
+	}
+
+	fields, _ := s.Fields.Get(p)
+	return fields
+}
+
+// Action returns the semantic action attached to a production rule.
+// It returns nil if the production rule has no semantic action.
+func (s *Spec) Action(p *grammar.Production) *SemanticAction {
+	if s.Actions == nil {
+		return nil
+	}
+
+	a, _ := s.Actions.Get(p)
+	return a
+}
+
+// Doc returns the doc comment of a terminal or non-terminal symbol with the comment markers removed.
+// It returns an empty string if the symbol is not documented.
+func (s *Spec) Doc(X grammar.Symbol) string {
+	var doc string
+
+	switch X := X.(type) {
+	case grammar.Terminal:
+		if s.TerminalDocs != nil {
+			doc, _ = s.TerminalDocs.Get(X)
+		}
+
+	case grammar.NonTerminal:
+		if s.NonTerminalDocs != nil {
+			doc, _ = s.NonTerminalDocs.Get(X)
+		}
+	}
+
+	return doc
+}
+
+// Describe returns a string representation of a grammar symbol for diagnostics.
+// Synthesized non-terminal symbols are represented by the EBNF constructs they are generated from.
+func (s *Spec) Describe(X grammar.Symbol) string {
+	if A, ok := X.(grammar.NonTerminal); ok {
+		if o := s.Origin(A); o != nil {
+			return o.String()
+		}
+	}
+
+	return X.String()
+}
+
+// DescribeText replaces the names of synthesized non-terminal symbols in a diagnostic text,
+// such as an error or a parsing table, with the EBNF constructs they are generated from.
+func (s *Spec) DescribeText(text string) string {
+	if s.Origins == nil || s.Origins.Size() == 0 {
+		return text
+	}
+
+	var names []string
+	for A := range s.Origins.All() {
+		names = append(names, regexp.QuoteMeta(string(A)))
+	}
+
+	re := regexp.MustCompile(`\b(?:` + strings.Join(names, "|") + `)\b`)
+
+	return re.ReplaceAllStringFunc(text, func(name string) string {
+		return s.Describe(grammar.NonTerminal(name))
+	})
+}
+
+// LexerMode is a lexer mode (start condition) along with the DFA for recognizing the terminal symbols defined in it.
+type LexerMode struct {
+	Name   string
+	DFA    *automata.DFA
+	Assocs []FinalTerminalAssociation
 }
 
 // BuildLexerDFA constructs a single deterministic finite automaton (DFA)
-// for recognizing all terminal symbols (tokens) in the grammar of the spec.
+// for recognizing all terminal symbols (tokens) in the default lexer mode of the spec.
 //
 // The second return value associates each terminal to its set of final states in the DFA.
 func (s *Spec) BuildLexerDFA() (*automata.DFA, []FinalTerminalAssociation, error) {
+	return buildLexerDFA(s.modeDefinitions(""), s.fragments(), s.whitespaceSymbols())
+}
+
+// BuildLexerModes constructs a deterministic finite automaton (DFA) for each lexer mode of the spec.
+// The default mode always comes first, followed by the declared modes in the order they are declared.
+func (s *Spec) BuildLexerModes() ([]*LexerMode, error) {
+	errs := &errors.MultiError{
+		Format: errors.BulletErrorFormat,
+	}
+
+	names := append([]string{""}, s.Modes...)
+	modes := make([]*LexerMode, 0, len(names))
+
+	for _, name := range names {
+		dfa, assocs, err := buildLexerDFA(s.modeDefinitions(name), s.fragments(), s.whitespaceSymbols())
+		if err != nil {
+			if name != "" {
+				err = fmt.Errorf("mode %s: %s", name, err)
+			}
+
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		if name == "" {
+			name = DefaultMode
+		}
+
+		modes = append(modes, &LexerMode{
+			Name:   name,
+			DFA:    dfa,
+			Assocs: assocs,
+		})
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return modes, nil
+}
+
+// modeDefinitions returns the terminal definitions that belong to a lexer mode.
+func (s *Spec) modeDefinitions(mode string) []*TerminalDef {
+	return generic.SelectMatch(s.Definitions, func(def *TerminalDef) bool {
+		return def.Mode == mode
+	})
+}
+
+// fragments returns the regular expressions of the regex fragments by their names.
+func (s *Spec) fragments() parser.Fragments {
+	fragments := make(parser.Fragments, len(s.Fragments))
+	for _, def := range s.Fragments {
+		fragments[def.Name] = def.Value
+	}
+
+	return fragments
+}
+
+// whitespaceSymbols returns the set of whitespace characters the lexer discards.
+func (s *Spec) whitespaceSymbols() []automata.Symbol {
+	if s.Whitespaces == nil {
+		return whitespaces
+	}
+
+	return generic.Transform(s.Whitespaces, func(r rune) automata.Symbol {
+		return automata.Symbol(r)
+	})
+}
+
+// buildLexerDFA constructs a single deterministic finite automaton (DFA) for recognizing a list of terminal definitions.
+// Whitespace characters not accepted by any of the terminal definitions are recognized as the WS terminal.
+// If there are no whitespace characters, the WS terminal is not recognized at all.
+// Regex fragments referenced by regex-based terminal definitions are expanded in place.
+func buildLexerDFA(defs []*TerminalDef, fragments parser.Fragments, ws []automata.Symbol) (*automata.DFA, []FinalTerminalAssociation, error) {
 	errs := &errors.MultiError{
 		Format: errors.BulletErrorFormat,
 	}
 
 	// Construct a DFA for each terminal.
-	ds := make([]*automata.DFA, len(s.Definitions))
-	for i, def := range s.Definitions {
+	ds := make([]*automata.DFA, len(defs))
+	for i, def := range defs {
 		switch def.Kind {
 		case StringDef:
-			ds[i] = stringToDFA(def.Value)
+			ds[i] = stringToDFA(def.Value, def.IgnoreCase)
 		case RegexDef:
 			var err error
-			ds[i], err = regexToDFA(def.Value)
+			ds[i], err = regexToDFA(def.Value, fragments)
 			if err != nil {
-				errs = errors.Append(errs, fmt.Errorf("%s: %s", def.Terminal, err))
+				if def.Pos != nil {
+					err = fmt.Errorf("%s: %s: %s", def.Pos, def.Terminal, err)
+				} else {
+					err = fmt.Errorf("%s: %s", def.Terminal, err)
+				}
+
+				errs = errors.Append(errs, err)
 			}
 		}
 	}
@@ -98,24 +341,26 @@ func (s *Spec) BuildLexerDFA() (*automata.DFA, []FinalTerminalAssociation, error
 		return d.Runner()
 	})
 
-	// Find whitespace characters that are not accepted by any terminal DFA.
-	// This effectively excludes whitespace characters that are explicitly handled by the grammar.
-	unusedWS := generic.SelectMatch(whitespaces, func(ws automata.Symbol) bool {
-		return !generic.AnyMatch(runners, func(r *automata.DFARunner) bool {
-			return r.Accept(automata.String{ws})
+	if len(ws) > 0 {
+		// Find whitespace characters that are not accepted by any terminal DFA.
+		// This effectively excludes whitespace characters that are explicitly handled by the grammar.
+		unusedWS := generic.SelectMatch(ws, func(ws automata.Symbol) bool {
+			return !generic.AnyMatch(runners, func(r *automata.DFARunner) bool {
+				return r.Accept(automata.String{ws})
+			})
 		})
-	})
 
-	// Build a DFA for the unused whitespace characters.
-	b := automata.NewDFABuilder().SetStart(0).SetFinal([]automata.State{1})
-	for _, ws := range unusedWS {
-		b.AddTransition(0, ws, ws, 1)
-		b.AddTransition(1, ws, ws, 1)
-	}
+		// Build a DFA for the unused whitespace characters.
+		b := automata.NewDFABuilder().SetStart(0).SetFinal([]automata.State{1})
+		for _, ws := range unusedWS {
+			b.AddTransition(0, ws, ws, 1)
+			b.AddTransition(1, ws, ws, 1)
+		}
 
-	// Prepend the terminal definition and whitespace DFA to the appropriate lists.
-	s.Definitions = append([]*TerminalDef{wsTerminalDef}, s.Definitions...)
-	ds = append([]*automata.DFA{b.Build()}, ds...)
+		// Prepend the terminal definition and whitespace DFA to the appropriate lists.
+		defs = append([]*TerminalDef{wsTerminalDef}, defs...)
+		ds = append([]*automata.DFA{b.Build()}, ds...)
+	}
 
 	// Combine multiple DFAs into one, preserving state mappings.
 	dfa, finalMap := automata.UnionDFA(ds...)
@@ -125,7 +370,7 @@ func (s *Spec) BuildLexerDFA() (*automata.DFA, []FinalTerminalAssociation, error
 	finalToDefs := make(map[automata.State][]*TerminalDef)
 	for i, finals := range finalMap {
 		for _, f := range finals {
-			finalToDefs[f] = append(finalToDefs[f], s.Definitions[i])
+			finalToDefs[f] = append(finalToDefs[f], defs[i])
 		}
 	}
 
@@ -146,16 +391,10 @@ func (s *Spec) BuildLexerDFA() (*automata.DFA, []FinalTerminalAssociation, error
 			finals = append(finals, f)
 			defToFinals.Put(defs[0], finals)
 		default:
-			// Prefer a string-based definition over regex-based definitions.
-			// This ensures keywords take precedence over identifiers.
-			stringDefs := generic.SelectMatch(defs, func(def *TerminalDef) bool {
-				return def.Kind == StringDef
-			})
-
-			if len(stringDefs) == 1 {
-				finals, _ := defToFinals.Get(stringDefs[0])
+			if def, ok := resolveDefs(defs); ok {
+				finals, _ := defToFinals.Get(def)
 				finals = append(finals, f)
-				defToFinals.Put(stringDefs[0], finals)
+				defToFinals.Put(def, finals)
 			} else {
 				poses := generic.Transform(defs, func(def *TerminalDef) string {
 					return fmt.Sprintf("  %s: %s", def.Pos, def.Terminal)
@@ -172,10 +411,13 @@ func (s *Spec) BuildLexerDFA() (*automata.DFA, []FinalTerminalAssociation, error
 	assocs := make([]FinalTerminalAssociation, 0, defToFinals.Size())
 	for def, finals := range defToFinals.All() {
 		assocs = append(assocs, FinalTerminalAssociation{
-			Final:    automata.NewStates(finals...),
-			Terminal: def.Terminal,
-			Kind:     def.Kind,
-			Value:    def.Value,
+			Final:      automata.NewStates(finals...),
+			Terminal:   def.Terminal,
+			Kind:       def.Kind,
+			Value:      def.Value,
+			IgnoreCase: def.IgnoreCase,
+			Action:     def.Action,
+			Skip:       def.Skip,
 		})
 	}
 
@@ -190,15 +432,50 @@ func (s *Spec) BuildLexerDFA() (*automata.DFA, []FinalTerminalAssociation, error
 	return dfa, assocs, nil
 }
 
+// resolveDefs chooses a single terminal definition among multiple definitions that capture the same string.
+// A string-based definition is preferred over regex-based definitions, so keywords take precedence over identifiers.
+// Otherwise, the regex-based definition with the highest explicit priority is chosen.
+// If no single definition can be chosen, it returns false.
+func resolveDefs(defs []*TerminalDef) (*TerminalDef, bool) {
+	stringDefs := generic.SelectMatch(defs, func(def *TerminalDef) bool {
+		return def.Kind == StringDef
+	})
+
+	switch len(stringDefs) {
+	case 0:
+	case 1:
+		return stringDefs[0], true
+	default:
+		return nil, false
+	}
+
+	var top *TerminalDef
+	for _, def := range defs {
+		if def.Priority > 0 && (top == nil || def.Priority < top.Priority) {
+			top = def
+		}
+	}
+
+	return top, top != nil
+}
+
 // FinalTerminalAssociation associates a terminal with its set of final states in a DFA.
+// IgnoreCase indicates whether a string value is matched case-insensitively,
+// Action is the mode action, if any, to take after the terminal is recognized,
+// and Skip indicates whether the terminal is discarded by the lexer.
 type FinalTerminalAssociation struct {
-	Final    automata.States
-	Terminal grammar.Terminal
-	Kind     TerminalDefKind
-	Value    string
+	Final      automata.States
+	Terminal   grammar.Terminal
+	Kind       TerminalDefKind
+	Value      string
+	IgnoreCase bool
+	Action     *ModeAction
+	Skip       bool
 }
 
-func stringToDFA(value string) *automata.DFA {
+// stringToDFA constructs a DFA that recognizes a string value.
+// If ignoreCase is true, each character also matches its case-folded equivalents.
+func stringToDFA(value string, ignoreCase bool) *automata.DFA {
 	start := automata.State(0)
 	b := automata.NewDFABuilder().SetStart(start)
 
@@ -206,6 +483,14 @@ func stringToDFA(value string) *automata.DFA {
 	for _, r := range value {
 		sym := automata.Symbol(r)
 		b.AddTransition(curr, sym, sym, next)
+
+		if ignoreCase {
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				sym := automata.Symbol(f)
+				b.AddTransition(curr, sym, sym, next)
+			}
+		}
+
 		curr, next = next, next+1
 	}
 
@@ -215,8 +500,10 @@ func stringToDFA(value string) *automata.DFA {
 	return b.Build()
 }
 
-func regexToDFA(regex string) (*automata.DFA, error) {
-	n, err := nfa.Parse(regex)
+// regexToDFA constructs a DFA that recognizes a regular expression.
+// References to regex fragments in the regular expression are expanded in place.
+func regexToDFA(regex string, fragments parser.Fragments) (*automata.DFA, error) {
+	n, err := nfa.ParseWithFragments(regex, fragments)
 	if err != nil {
 		return nil, err
 	}
@@ -234,12 +521,60 @@ func (s *Spec) Productions() []*grammar.Production {
 	return prods
 }
 
+// TableKind determines the algorithm used for constructing an LR parsing table.
+type TableKind string
+
+const (
+	SLR  TableKind = "slr"  // SLR(1) (Simple LR)
+	LALR TableKind = "lalr" // LALR(1) (Lookahead LR)
+	LR1  TableKind = "lr1"  // Canonical LR(1)
+)
+
+// IsValid determines whether or not a table kind is one of the supported ones.
+func (k TableKind) IsValid() bool {
+	switch k {
+	case SLR, LALR, LR1:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the name of the parsing algorithm for a table kind.
+func (k TableKind) String() string {
+	switch k {
+	case SLR:
+		return "SLR(1)"
+	case LALR:
+		return "LALR(1)"
+	case LR1:
+		return "LR(1)"
+	default:
+		return string(k)
+	}
+}
+
+// ParsingTable builds and returns an LR parsing table for the grammar and precedences in the spec
+// using the construction algorithm determined by the given table kind.
+func (s *Spec) ParsingTable(kind TableKind) (*lr.ParsingTable, error) {
+	switch kind {
+	case SLR:
+		return s.SLRParsingTable()
+	case LALR:
+		return s.LALRParsingTable()
+	case LR1:
+		return s.GLRParsingTable()
+	default:
+		return nil, fmt.Errorf("invalid parsing table %q, expected one of slr, lalr, or lr1", string(kind))
+	}
+}
+
 // SLRParsingTable builds and returns the SLR(1) (Simple LR) parsing table
 // for the grammar and precedences in the spec.
 func (s *Spec) SLRParsingTable() (*lr.ParsingTable, error) {
-	T, err := simple.BuildParsingTable(s.Grammar, s.Precedences)
+	T, err := s.buildParsingTable(SLR)
 	if err != nil {
-		return nil, fmt.Errorf("error on building SLR(1) parsing table:\n%s", err)
+		return nil, fmt.Errorf("error on building SLR(1) parsing table:\n%s", s.explainConflicts(SLR, err))
 	}
 
 	return T, nil
@@ -248,9 +583,9 @@ func (s *Spec) SLRParsingTable() (*lr.ParsingTable, error) {
 // LALRParsingTable builds and returns the LALR(1) (Lookahead LR) parsing table
 // for the grammar and precedences in the spec.
 func (s *Spec) LALRParsingTable() (*lr.ParsingTable, error) {
-	T, err := lookahead.BuildParsingTable(s.Grammar, s.Precedences)
+	T, err := s.buildParsingTable(LALR)
 	if err != nil {
-		return nil, fmt.Errorf("error on building LALR(1) parsing table:\n%s", err)
+		return nil, fmt.Errorf("error on building LALR(1) parsing table:\n%s", s.explainConflicts(LALR, err))
 	}
 
 	return T, nil
@@ -259,10 +594,22 @@ func (s *Spec) LALRParsingTable() (*lr.ParsingTable, error) {
 // GLRParsingTable builds and returns the GLR(1) (Canonical LR a.k.a. Generalized LR) parsing table
 // for the grammar and precedences in the spec.
 func (s *Spec) GLRParsingTable() (*lr.ParsingTable, error) {
-	T, err := canonical.BuildParsingTable(s.Grammar, s.Precedences)
+	T, err := s.buildParsingTable(LR1)
 	if err != nil {
-		return nil, fmt.Errorf("error on building GLR(1) parsing table:\n%s", err)
+		return nil, fmt.Errorf("error on building GLR(1) parsing table:\n%s", s.explainConflicts(LR1, err))
 	}
 
 	return T, nil
 }
+
+// buildParsingTable builds an LR parsing table using the construction algorithm determined by the given table kind.
+func (s *Spec) buildParsingTable(kind TableKind) (*lr.ParsingTable, error) {
+	switch kind {
+	case SLR:
+		return simple.BuildParsingTable(s.Grammar, s.Precedences)
+	case LR1:
+		return canonical.BuildParsingTable(s.Grammar, s.Precedences)
+	default:
+		return lookahead.BuildParsingTable(s.Grammar, s.Precedences)
+	}
+}
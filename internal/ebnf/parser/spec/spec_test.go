@@ -1,13 +1,18 @@
 package spec
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/moorara/algo/automata"
+	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/lexer"
 	"github.com/moorara/algo/parser/lr"
+	"github.com/moorara/algo/symboltable"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/emerge/internal/ebnf/parser"
 )
 
 func TestSpec_BuildLexerDFA(t *testing.T) {
@@ -51,6 +56,20 @@ func TestSpec_BuildLexerDFA(t *testing.T) {
 				`  test:3:1: "INT"`,
 			},
 		},
+		{
+			name: "UndefinedFragment",
+			s: &Spec{
+				Definitions: []*TerminalDef{
+					{Terminal: "NUM", Kind: RegexDef, Value: "{DIGITS}", Pos: &lexer.Position{Filename: "test", Offset: 20, Line: 2, Column: 1}},
+				},
+			},
+			expectedDFA:            nil,
+			expectedTerminalFinals: nil,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`test:2:1: "NUM": invalid regular expression: {DIGITS}: 0: undefined fragment DIGITS`,
+			},
+		},
 		{
 			name: "Success",
 			s: &Spec{
@@ -174,6 +193,190 @@ func TestSpec_BuildLexerDFA(t *testing.T) {
 	}
 }
 
+func TestSpec_BuildLexerModes(t *testing.T) {
+	tests := []struct {
+		name                 string
+		s                    *Spec
+		expectedModes        []string
+		expectedTerminals    [][]string
+		expectedSkips        []string
+		expectedErrorStrings []string
+	}{
+		{
+			name: "InvalidRegex",
+			s: &Spec{
+				Modes: []string{"str"},
+				Definitions: []*TerminalDef{
+					{Terminal: "ID", Kind: RegexDef, Value: "[A-Z"},
+					{Terminal: "CHARS", Kind: RegexDef, Value: "[^\"", Mode: "str"},
+				},
+			},
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`"ID": invalid regular expression: [A-Z`,
+				`mode str: `,
+				`"CHARS": invalid regular expression: [^"`,
+			},
+		},
+		{
+			name: "Success",
+			s: &Spec{
+				Modes: []string{"str"},
+				Definitions: []*TerminalDef{
+					{Terminal: "QUOTE", Kind: StringDef, Value: "\"", Action: &ModeAction{Kind: PushMode, Mode: "str"}},
+					{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+"},
+					{Terminal: "COMMENT", Kind: RegexDef, Value: "#[a-z]*", Skip: true},
+					{Terminal: "END", Kind: StringDef, Value: "\"", Mode: "str", Action: &ModeAction{Kind: PopMode}},
+					{Terminal: "CHARS", Kind: RegexDef, Value: "[^\"]+", Mode: "str"},
+				},
+			},
+			expectedModes: []string{"default", "str"},
+			expectedTerminals: [][]string{
+				{"WS", "QUOTE", "ID", "COMMENT"},
+				{"WS", "END", "CHARS"},
+			},
+			expectedSkips: []string{"COMMENT"},
+		},
+		{
+			name: "CustomWhitespaces",
+			s: &Spec{
+				Whitespaces: []rune{' ', '\t'},
+				Definitions: []*TerminalDef{
+					{Terminal: "EOL", Kind: RegexDef, Value: "\n|\r|\r\n"},
+					{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+"},
+				},
+			},
+			expectedModes: []string{"default"},
+			expectedTerminals: [][]string{
+				{"WS", "EOL", "ID"},
+			},
+		},
+		{
+			name: "NoWhitespaces",
+			s: &Spec{
+				Whitespaces: []rune{},
+				Definitions: []*TerminalDef{
+					{Terminal: "SPACE", Kind: RegexDef, Value: "[ \t]+"},
+					{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+"},
+				},
+			},
+			expectedModes: []string{"default"},
+			expectedTerminals: [][]string{
+				{"SPACE", "ID"},
+			},
+		},
+		{
+			name: "IgnoreCaseConflict",
+			s: &Spec{
+				Definitions: []*TerminalDef{
+					{Terminal: "BEGIN", Kind: StringDef, Value: "begin", IgnoreCase: true, Pos: &lexer.Position{Filename: "test", Offset: 20, Line: 2, Column: 1}},
+					{Terminal: "START", Kind: StringDef, Value: "BEGIN", Pos: &lexer.Position{Filename: "test", Offset: 40, Line: 3, Column: 1}},
+				},
+			},
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`conflicting definitions capture the same string:`,
+				`  test:2:1: "BEGIN"`,
+				`  test:3:1: "START"`,
+			},
+		},
+		{
+			name: "IgnoreCase",
+			s: &Spec{
+				Definitions: []*TerminalDef{
+					{Terminal: "BEGIN", Kind: StringDef, Value: "begin", IgnoreCase: true},
+					{Terminal: "END", Kind: StringDef, Value: "end", IgnoreCase: true},
+					{Terminal: "ID", Kind: RegexDef, Value: "(?i)[a-z]+"},
+				},
+			},
+			expectedModes: []string{"default"},
+			expectedTerminals: [][]string{
+				{"WS", "BEGIN", "END", "ID"},
+			},
+		},
+		{
+			name: "Fragments",
+			s: &Spec{
+				Definitions: []*TerminalDef{
+					{Terminal: "INT", Kind: RegexDef, Value: "{DIGITS}"},
+					{Terminal: "FLOAT", Kind: RegexDef, Value: `{DIGITS}\.{DIGITS}`},
+				},
+				Fragments: []*FragmentDef{
+					{Name: "DIGIT", Value: "[0-9]"},
+					{Name: "DIGITS", Value: "{DIGIT}+"},
+				},
+			},
+			expectedModes: []string{"default"},
+			expectedTerminals: [][]string{
+				{"WS", "INT", "FLOAT"},
+			},
+		},
+		{
+			name: "PriorityConflict",
+			s: &Spec{
+				Definitions: []*TerminalDef{
+					{Terminal: "HEX", Kind: RegexDef, Value: "[0-9a-f]+", Pos: &lexer.Position{Filename: "test", Offset: 20, Line: 2, Column: 1}},
+					{Terminal: "INT", Kind: RegexDef, Value: "[0-9]+", Pos: &lexer.Position{Filename: "test", Offset: 40, Line: 3, Column: 1}},
+					{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+", Priority: 1, Pos: &lexer.Position{Filename: "test", Offset: 60, Line: 4, Column: 1}},
+				},
+			},
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`conflicting definitions capture the same string:`,
+				`  test:2:1: "HEX"`,
+				`  test:3:1: "INT"`,
+			},
+		},
+		{
+			name: "Priorities",
+			s: &Spec{
+				Definitions: []*TerminalDef{
+					{Terminal: "OCT", Kind: RegexDef, Value: "0[0-7]*", Priority: 2},
+					{Terminal: "INT", Kind: RegexDef, Value: "[0-9]+"},
+					{Terminal: "BUILTIN", Kind: RegexDef, Value: "__[a-z]+__", Priority: 1},
+					{Terminal: "ID", Kind: RegexDef, Value: "[_a-z]+"},
+				},
+			},
+			expectedModes: []string{"default"},
+			expectedTerminals: [][]string{
+				{"WS", "OCT", "INT", "BUILTIN", "ID"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			modes, err := tc.s.BuildLexerModes()
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NoError(t, err)
+				assert.Len(t, modes, len(tc.expectedModes))
+
+				for i, mode := range modes {
+					assert.Equal(t, tc.expectedModes[i], mode.Name)
+					assert.NotNil(t, mode.DFA)
+
+					terminals := []string{}
+					for _, assoc := range mode.Assocs {
+						terminals = append(terminals, string(assoc.Terminal))
+						assert.Equal(t, slices.Contains(tc.expectedSkips, string(assoc.Terminal)), assoc.Skip)
+					}
+
+					assert.ElementsMatch(t, tc.expectedTerminals[i], terminals)
+				}
+			} else {
+				assert.Nil(t, modes)
+				assert.Error(t, err)
+
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			}
+		})
+	}
+}
+
 func TestSpec_Productions(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -206,6 +409,344 @@ func TestSpec_Productions(t *testing.T) {
 	}
 }
 
+func TestSpec_Describe(t *testing.T) {
+	origins := symboltable.NewQuadraticHashTable[grammar.NonTerminal, *Origin](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	origins.Put("gen_comma_star", &Origin{
+		Text: `{ "," expr }`,
+		Pos:  &lexer.Position{Filename: "test", Offset: 40, Line: 4, Column: 12},
+	})
+
+	tests := []struct {
+		name           string
+		s              *Spec
+		X              grammar.Symbol
+		expectedString string
+	}{
+		{
+			name:           "Terminal",
+			s:              &Spec{Origins: origins},
+			X:              grammar.Terminal(","),
+			expectedString: `","`,
+		},
+		{
+			name:           "NonTerminal",
+			s:              &Spec{Origins: origins},
+			X:              grammar.NonTerminal("expr"),
+			expectedString: `expr`,
+		},
+		{
+			name:           "SynthesizedNonTerminal",
+			s:              &Spec{Origins: origins},
+			X:              grammar.NonTerminal("gen_comma_star"),
+			expectedString: `{ "," expr }@test:4:12`,
+		},
+		{
+			name:           "NoOrigins",
+			s:              &Spec{},
+			X:              grammar.NonTerminal("gen_comma_star"),
+			expectedString: `gen_comma_star`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.s.Describe(tc.X))
+		})
+	}
+}
+
+func TestSpec_DescribeText(t *testing.T) {
+	origins := symboltable.NewQuadraticHashTable[grammar.NonTerminal, *Origin](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	origins.Put("gen1_star", &Origin{
+		Text: `{ stmt }`,
+		Pos:  &lexer.Position{Filename: "test", Offset: 20, Line: 2, Column: 9},
+	})
+
+	origins.Put("gen10_star", &Origin{
+		Text: `{ "," expr }`,
+		Pos:  &lexer.Position{Filename: "test", Offset: 40, Line: 4, Column: 12},
+	})
+
+	tests := []struct {
+		name           string
+		s              *Spec
+		text           string
+		expectedString string
+	}{
+		{
+			name:           "NoOrigins",
+			s:              &Spec{},
+			text:           `start → gen1_star`,
+			expectedString: `start → gen1_star`,
+		},
+		{
+			name:           "NoSynthesizedNonTerminal",
+			s:              &Spec{Origins: origins},
+			text:           `stmt → ID "=" expr`,
+			expectedString: `stmt → ID "=" expr`,
+		},
+		{
+			name:           "SynthesizedNonTerminals",
+			s:              &Spec{Origins: origins},
+			text:           "start → gen1_star\ngen1_star → gen1_star stmt\nlist → expr gen10_star\ngen1_stars → ID",
+			expectedString: "start → { stmt }@test:2:9\n{ stmt }@test:2:9 → { stmt }@test:2:9 stmt\nlist → expr { \",\" expr }@test:4:12\ngen1_stars → ID",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.s.DescribeText(tc.text))
+		})
+	}
+}
+
+func TestSpec_Doc(t *testing.T) {
+	terminalDocs := symboltable.NewQuadraticHashTable[grammar.Terminal, string](
+		grammar.HashTerminal,
+		grammar.EqTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	nonTerminalDocs := symboltable.NewQuadraticHashTable[grammar.NonTerminal, string](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	terminalDocs.Put("ID", "ID is an identifier.")
+	nonTerminalDocs.Put("expr", "expr is an expression.")
+
+	tests := []struct {
+		name        string
+		s           *Spec
+		X           grammar.Symbol
+		expectedDoc string
+	}{
+		{
+			name:        "Terminal",
+			s:           &Spec{TerminalDocs: terminalDocs, NonTerminalDocs: nonTerminalDocs},
+			X:           grammar.Terminal("ID"),
+			expectedDoc: "ID is an identifier.",
+		},
+		{
+			name:        "NonTerminal",
+			s:           &Spec{TerminalDocs: terminalDocs, NonTerminalDocs: nonTerminalDocs},
+			X:           grammar.NonTerminal("expr"),
+			expectedDoc: "expr is an expression.",
+		},
+		{
+			name:        "Undocumented",
+			s:           &Spec{TerminalDocs: terminalDocs, NonTerminalDocs: nonTerminalDocs},
+			X:           grammar.NonTerminal("term"),
+			expectedDoc: "",
+		},
+		{
+			name:        "NoDocs",
+			s:           &Spec{},
+			X:           grammar.Terminal("ID"),
+			expectedDoc: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDoc, tc.s.Doc(tc.X))
+		})
+	}
+}
+
+func TestTableKind_IsValid(t *testing.T) {
+	tests := []struct {
+		name          string
+		k             TableKind
+		expectedValid bool
+	}{
+		{
+			name:          "SLR",
+			k:             SLR,
+			expectedValid: true,
+		},
+		{
+			name:          "LALR",
+			k:             LALR,
+			expectedValid: true,
+		},
+		{
+			name:          "LR1",
+			k:             LR1,
+			expectedValid: true,
+		},
+		{
+			name:          "Invalid",
+			k:             TableKind("glr"),
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedValid, tc.k.IsValid())
+		})
+	}
+}
+
+func TestTableKind_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		k              TableKind
+		expectedString string
+	}{
+		{
+			name:           "SLR",
+			k:              SLR,
+			expectedString: "SLR(1)",
+		},
+		{
+			name:           "LALR",
+			k:              LALR,
+			expectedString: "LALR(1)",
+		},
+		{
+			name:           "LR1",
+			k:              LR1,
+			expectedString: "LR(1)",
+		},
+		{
+			name:           "Invalid",
+			k:              TableKind("glr"),
+			expectedString: "glr",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.k.String())
+		})
+	}
+}
+
+func TestSpec_ParsingTable(t *testing.T) {
+	tests := []struct {
+		name                 string
+		s                    *Spec
+		kind                 TableKind
+		expectedErrorStrings []string
+	}{
+		{
+			name: "InvalidKind",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: precedences[0],
+			},
+			kind: TableKind("glr"),
+			expectedErrorStrings: []string{
+				`invalid parsing table "glr", expected one of slr, lalr, or lr1`,
+			},
+		},
+		{
+			name: "SLR_Error",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: SLR,
+			expectedErrorStrings: []string{
+				`error on building SLR(1) parsing table:`,
+			},
+		},
+		{
+			name: "LALR_Error",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: LALR,
+			expectedErrorStrings: []string{
+				`error on building LALR(1) parsing table:`,
+			},
+		},
+		{
+			name: "LR1_Error",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: lr.PrecedenceLevels{},
+			},
+			kind: LR1,
+			expectedErrorStrings: []string{
+				`error on building GLR(1) parsing table:`,
+			},
+		},
+		{
+			name: "SLR_Success",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: precedences[0],
+			},
+			kind:                 SLR,
+			expectedErrorStrings: []string{},
+		},
+		{
+			name: "LALR_Success",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: precedences[0],
+			},
+			kind:                 LALR,
+			expectedErrorStrings: []string{},
+		},
+		{
+			name: "LR1_Success",
+			s: &Spec{
+				Grammar:     grammars[0],
+				Precedences: precedences[0],
+			},
+			kind:                 LR1,
+			expectedErrorStrings: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			T, err := tc.s.ParsingTable(tc.kind)
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NotNil(t, T)
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, T)
+				assert.Error(t, err)
+
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			}
+		})
+	}
+}
+
 func TestSpec_SLRParsingTable(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -231,6 +772,10 @@ func TestSpec_SLRParsingTable(t *testing.T) {
 				`              • "+" vs. "*", "+"`,
 				`            Terminals/Productions listed earlier will have higher precedence.`,
 				`            Terminals/Productions in the same line will have the same precedence.`,
+				`Shift/Reduce conflict on "*":`,
+				`Example: E "*" E • "*" E`,
+				`Reduce derivation:`,
+				`Shift derivation:`,
 			},
 		},
 		{
@@ -345,6 +890,9 @@ func TestSpec_GLRParsingTable(t *testing.T) {
 				`              • "+" vs. "*", "+"`,
 				`            Terminals/Productions listed earlier will have higher precedence.`,
 				`            Terminals/Productions in the same line will have the same precedence.`,
+				`Shift/Reduce conflict on "*":`,
+				`Reduce derivation:`,
+				`Shift derivation:`,
 			},
 		},
 		{
@@ -376,3 +924,128 @@ func TestSpec_GLRParsingTable(t *testing.T) {
 		})
 	}
 }
+
+func TestPredefs(t *testing.T) {
+	tests := []struct {
+		predef   string
+		accepted []string
+		rejected []string
+	}{
+		{
+			predef:   "$DIGIT",
+			accepted: []string{"0", "9"},
+			rejected: []string{"", "a", "10"},
+		},
+		{
+			predef:   "$HEX",
+			accepted: []string{"0", "a", "F"},
+			rejected: []string{"", "g", "ff"},
+		},
+		{
+			predef:   "$LETTER",
+			accepted: []string{"a", "Z"},
+			rejected: []string{"", "0", "_", "é"},
+		},
+		{
+			predef:   "$IDENT_START",
+			accepted: []string{"a", "Z", "_", "é", "π", "ж", "字", "℘", "゛"},
+			rejected: []string{"", "0", "٣", "-", "ab", "·", "ⸯ"},
+		},
+		{
+			predef:   "$IDENT_CONTINUE",
+			accepted: []string{"a", "é", "_", "0", "٣", "·", "፩"},
+			rejected: []string{"", "-", " ", "ab", "ⸯ"},
+		},
+		{
+			predef:   "$IDENT",
+			accepted: []string{"x", "_tmp", "camelCase", "snake_case_2", "größe", "π", "переменная", "変数", "l·l"},
+			rejected: []string{"", "0x", "-x", "a-b", "a b", "aⸯ"},
+		},
+		{
+			predef:   "$INT",
+			accepted: []string{"0", "42", "-7"},
+			rejected: []string{"", "+7", "1_000", "4.2"},
+		},
+		{
+			predef:   "$DEC_INT",
+			accepted: []string{"0", "42", "1_000_000"},
+			rejected: []string{"", "-7", "_1", "1_", "1__0"},
+		},
+		{
+			predef:   "$HEX_INT",
+			accepted: []string{"0x0", "0XFF", "0xdead_beef"},
+			rejected: []string{"", "0x", "0x_1", "0x1_", "0xG", "FF"},
+		},
+		{
+			predef:   "$OCT_INT",
+			accepted: []string{"0o0", "0O17", "0o7_7_7"},
+			rejected: []string{"", "0o", "0o8", "0o_7", "017"},
+		},
+		{
+			predef:   "$BIN_INT",
+			accepted: []string{"0b0", "0B1", "0b1010_0101"},
+			rejected: []string{"", "0b", "0b2", "0b_1", "0b1__0"},
+		},
+		{
+			predef:   "$FLOAT",
+			accepted: []string{"0", "-1", "3.14", "-0.5", "1e10", "6.022E23", "1.5e-3", "2E+8"},
+			rejected: []string{"", ".5", "1.", "1e", "1.5e+", "e10"},
+		},
+		{
+			predef:   "$STRING",
+			accepted: []string{`""`, `"hello"`, `"it's"`, `"\"quoted\""`, `"\t\n\r\\"`, `"\x41"`, `"\u00E9"`, `"\U0001F600"`},
+			rejected: []string{``, `"`, `'a'`, `"\"`, `"\q"`, `"\x4"`, `"\u00E"`, `"\U0001F60"`},
+		},
+		{
+			predef:   "$SQ_STRING",
+			accepted: []string{`''`, `'a'`, `'say "hi"'`, `'\''`, `'\u00E9'`},
+			rejected: []string{``, `'`, `"a"`, `'\'`, `'\q'`},
+		},
+		{
+			predef:   "$RAW_STRING",
+			accepted: []string{"``", "`raw`", "`C:\\path\\to`", "`multi\nline`"},
+			rejected: []string{"", "`", "`a`b`", `"a"`},
+		},
+		{
+			predef:   "$LINE_COMMENT",
+			accepted: []string{"#", "# comment", "//", "// comment"},
+			rejected: []string{"", "/", "/* comment */", "// line\n"},
+		},
+		{
+			predef:   "$BLOCK_COMMENT",
+			accepted: []string{"/**/", "/* comment */", "/** doc **/", "/* a\nb */", "/* a * b / c */"},
+			rejected: []string{"", "/*/", "/* a */ b */", "/* open", "// comment"},
+		},
+		{
+			predef:   "$COMMENT",
+			accepted: []string{"# comment", "// comment", "/* comment */", "/* a\nb */"},
+			rejected: []string{"", "/* a */ b */", "// line\n"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.predef, func(t *testing.T) {
+			regex, ok := parser.Predefs[tc.predef]
+			assert.True(t, ok)
+
+			dfa, err := regexToDFA(regex, nil)
+			assert.NoError(t, err)
+
+			runner := dfa.Runner()
+
+			for _, s := range tc.accepted {
+				assert.True(t, runner.Accept(toString(s)), "%s should accept %q", tc.predef, s)
+			}
+
+			for _, s := range tc.rejected {
+				assert.False(t, runner.Accept(toString(s)), "%s should reject %q", tc.predef, s)
+			}
+		})
+	}
+}
+
+func toString(s string) automata.String {
+	return automata.String(generic.Transform([]rune(s), func(r rune) automata.Symbol {
+		return automata.Symbol(r)
+	}))
+}
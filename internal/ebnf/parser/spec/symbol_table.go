@@ -2,6 +2,7 @@ package spec
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -17,9 +18,19 @@ import (
 // start is always the start symbol of an EBNF grammar by convention.
 const start = grammar.NonTerminal("start")
 
+// DefaultMode is the name of the lexer mode for token definitions declared outside of any mode block.
+// It is reserved and cannot be declared, but it can be the target of a mode action.
+const DefaultMode = "default"
+
 // reservedTerminals lists terminal names that are reserved and cannot be used in the grammar.
+// These names should match those defined and used in "internal/generator/golang/templates/lexer.go.tmpl"
+// and "internal/generator/golang/templates/parser.lr.go.tmpl".
+// The ErrorToken can only be used by its name in error recovery production rules.
+var reservedTerminals = []grammar.Terminal{"ERR", "WS", ErrorToken}
+
+// indentTerminals lists terminal names that are synthesized by the lexer when indentation tracking is enabled.
 // These names should match those defined and used in "internal/generator/golang/templates/lexer.go.tmpl".
-var reservedTerminals = []grammar.Terminal{"ERR", "WS"}
+var indentTerminals = []grammar.Terminal{"NEWLINE", "INDENT", "DEDENT"}
 
 // TerminalDefKind indicates whether a terminal definition is based on a string or a regex.
 type TerminalDefKind int
@@ -30,13 +41,85 @@ const (
 )
 
 // TerminalDef represents a terminal symbol along with a deterministic finite automaton (DFA) for recognizing it.
+// IgnoreCase indicates a string value is matched case-insensitively.
+// Mode is the lexer mode the definition belongs to; an empty Mode refers to the default mode.
+// Skip indicates the lexer discards the terminal, the same way it discards whitespaces.
+// Priority is the rank of the terminal in the priority directives, where 1 is the highest priority;
+// a zero Priority means the terminal has no explicit priority.
 type TerminalDef struct {
 	grammar.Terminal
-	Kind  TerminalDefKind
+	Kind       TerminalDefKind
+	Value      string
+	IgnoreCase bool
+	Mode       string
+	Action     *ModeAction
+	Skip       bool
+	Priority   int
+	Pos        *lexer.Position
+}
+
+// FragmentDef represents a named regex fragment.
+// A fragment is not a terminal on its own; it can be referenced by name from the regular expressions of terminals.
+type FragmentDef struct {
+	Name  string
 	Value string
 	Pos   *lexer.Position
 }
 
+// ExternalDef represents a terminal symbol recognized by a scanner function registered with the generated lexer
+// instead of a deterministic finite automaton (DFA).
+// Skip indicates the lexer discards the terminal, the same way it discards whitespaces.
+type ExternalDef struct {
+	grammar.Terminal
+	Skip bool
+	Pos  *lexer.Position
+}
+
+// ModeActionKind indicates how recognizing a token changes the current lexer mode.
+type ModeActionKind int
+
+const (
+	PushMode ModeActionKind = iota
+	PopMode
+	SwitchMode
+)
+
+// ModeAction represents a change of the lexer mode that takes place after a token is recognized.
+type ModeAction struct {
+	Kind ModeActionKind
+	Mode string
+	Pos  *lexer.Position
+}
+
+// String returns the EBNF representation of a mode action.
+func (a *ModeAction) String() string {
+	switch a.Kind {
+	case PushMode:
+		return "@push " + a.Mode
+	case PopMode:
+		return "@pop"
+	case SwitchMode:
+		return "@switch " + a.Mode
+	default:
+		return fmt.Sprintf("ModeAction(%d)", a.Kind)
+	}
+}
+
+// Origin represents the EBNF construct, such as { ... } or {{ ... }}, from which a non-terminal symbol is synthesized.
+type Origin struct {
+	Text string
+	Pos  *lexer.Position
+}
+
+// String returns the original EBNF text of the construct along with its position in the EBNF source.
+func (o *Origin) String() string {
+	if o.Pos == nil {
+		return o.Text
+	}
+
+	return fmt.Sprintf("%s@%s", o.Text, o.Pos.String())
+}
+
 type (
 	// SymbolTable is used by an EBNF parser during parsing.
 	// It keeps track of grammar symbols encountered, their occurrences, and other relevant information.
@@ -67,6 +150,82 @@ type (
 			counter int
 			table   symboltable.SymbolTable[Strings, *stringsEntry]
 		}
+
+		origins struct {
+			table symboltable.SymbolTable[grammar.NonTerminal, *Origin]
+		}
+
+		modes struct {
+			counter int
+			table   symboltable.SymbolTable[string, *modeEntry]
+		}
+
+		whitespaces struct {
+			chars       []rune
+			occurrences []*lexer.Position
+		}
+
+		indent struct {
+			occurrences []*lexer.Position
+		}
+
+		recovery struct {
+			occurrences []*lexer.Position
+		}
+
+		priorities struct {
+			counter int
+		}
+
+		fragments struct {
+			table symboltable.SymbolTable[string, *fragmentEntry]
+		}
+
+		labels struct {
+			table symboltable.SymbolTable[string, *labelEntry]
+		}
+
+		fields struct {
+			table symboltable.SymbolTable[*grammar.Production, *fieldsEntry]
+		}
+
+		starts struct {
+			nonTerminals []grammar.NonTerminal
+			occurrences  []*lexer.Position
+		}
+
+		lists struct {
+			nonTerminals []grammar.NonTerminal
+		}
+
+		externals struct {
+			terminals   []grammar.Terminal
+			occurrences []*lexer.Position
+		}
+
+		macros struct {
+			current   *macroDef
+			table     symboltable.SymbolTable[grammar.NonTerminal, *macroEntry]
+			instances []*instanceEntry
+		}
+
+		actions struct {
+			table symboltable.SymbolTable[*grammar.Production, *actionEntry]
+		}
+
+		overrides struct {
+			active bool
+		}
+
+		removals struct {
+			terminals    []grammar.Terminal
+			nonTerminals []grammar.NonTerminal
+			symbols      []grammar.Symbol
+		}
+
+		codeBlocks struct {
+			list []*CodeBlock
+		}
 	}
 
 	// terminalEntry is the table entry for a terminal.
@@ -75,6 +234,11 @@ type (
 		index       int
 		definitions []*TerminalDef
 		occurrences []*lexer.Position
+		skips       []*lexer.Position
+		ignoreCases []*lexer.Position
+		priority    int
+		priorities  []*lexer.Position
+		doc         string
 	}
 
 	// nonTerminalEntry is the table entry for a non-terminal.
@@ -82,6 +246,7 @@ type (
 	nonTerminalEntry struct {
 		index       int
 		occurrences []*lexer.Position
+		doc         string
 	}
 
 	// productionEntry is the table entry for a production rule.
@@ -91,12 +256,85 @@ type (
 		occurrences []*lexer.Position
 	}
 
+	// modeEntry is the table entry for a lexer mode.
+	// mode → "@mode" IDENT "{" mode_decls "}"
+	modeEntry struct {
+		index       int
+		occurrences []*lexer.Position
+	}
+
+	// fragmentEntry is the table entry for a regex fragment.
+	// fragment → "@fragment" TOKEN "=" REGEX
+	fragmentEntry struct {
+		definitions []*FragmentDef
+	}
+
+	// labelEntry is the table entry for a production label.
+	// The production rules and their occurrences are recorded in pairs.
+	// rhs → rhs LABEL
+	labelEntry struct {
+		productions []*grammar.Production
+		occurrences []*lexer.Position
+	}
+
+	// fieldsEntry is the table entry for the field names of the symbols in a production rule.
+	// The field names and their occurrences are recorded in pairs.
+	// rhs → FIELD rhs
+	fieldsEntry struct {
+		fields      [][]string
+		occurrences []*lexer.Position
+	}
+
+	// actionEntry is the table entry for the semantic actions of a production rule.
+	// rhs → rhs CODE | rhs "|" CODE
+	actionEntry struct {
+		actions []*SemanticAction
+	}
+
 	// stringsEntry is the table entry for a list of strings of grammar symbols.
 	stringsEntry struct {
 		Group grammar.NonTerminal
 		Opt   grammar.NonTerminal
 		Star  grammar.NonTerminal
 		Plus  grammar.NonTerminal
+		Lists []*listEntry
+	}
+
+	// listEntry is the entry for a list of strings of grammar symbols separated by another list of strings.
+	listEntry struct {
+		Sep  Strings
+		List grammar.NonTerminal
+	}
+
+	// macroEntry is the table entry for a parameterized rule.
+	// lhs → nonterm "<" params ">"
+	macroEntry struct {
+		definitions []*macroDef
+	}
+
+	// macroDef is a definition of a parameterized rule.
+	// The production rules of the definition, their field names, and their occurrences are recorded in triples.
+	// The parameters occur in the production rules as placeholder non-terminals with the same names.
+	macroDef struct {
+		name        grammar.NonTerminal
+		params      []string
+		productions []*grammar.Production
+		fields      [][]string
+		occurrences []*lexer.Position
+		pos         *lexer.Position
+	}
+
+	// instanceEntry is the entry for an instantiation of a parameterized rule with a list of arguments.
+	// The scopes and the occurrences of the instantiation are recorded in pairs.
+	// The scope is the parameterized rule in which the instantiation occurs, or empty if it occurs in a regular rule.
+	// rhs → nonterm "<" args ">"
+	instanceEntry struct {
+		Macro       grammar.NonTerminal
+		Args        []Strings
+		NonTerminal grammar.NonTerminal
+		Text        string
+		scopes      []grammar.NonTerminal
+		occurrences []*lexer.Position
 	}
 )
 
@@ -138,6 +376,47 @@ func NewSymbolTable() *SymbolTable {
 		opts,
 	)
 
+	st.origins.table = symboltable.NewQuadraticHashTable[grammar.NonTerminal, *Origin](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		opts,
+	)
+
+	st.modes.table = symboltable.NewRedBlack[string, *modeEntry](
+		generic.NewCompareFunc[string](),
+		nil,
+	)
+
+	st.fragments.table = symboltable.NewRedBlack[string, *fragmentEntry](
+		generic.NewCompareFunc[string](),
+		nil,
+	)
+
+	st.labels.table = symboltable.NewRedBlack[string, *labelEntry](
+		generic.NewCompareFunc[string](),
+		nil,
+	)
+
+	st.fields.table = symboltable.NewQuadraticHashTable[*grammar.Production, *fieldsEntry](
+		grammar.HashProduction,
+		grammar.EqProduction,
+		nil,
+		opts,
+	)
+
+	st.macros.table = symboltable.NewRedBlack[grammar.NonTerminal, *macroEntry](
+		generic.NewCompareFunc[grammar.NonTerminal](),
+		nil,
+	)
+
+	st.actions.table = symboltable.NewQuadraticHashTable[*grammar.Production, *actionEntry](
+		grammar.HashProduction,
+		grammar.EqProduction,
+		nil,
+		opts,
+	)
+
 	return st
 }
 
@@ -152,6 +431,41 @@ func (t *SymbolTable) Reset() {
 	t.nonTerminals.table.DeleteAll()
 	t.productions.table.DeleteAll()
 	t.strings.table.DeleteAll()
+	t.origins.table.DeleteAll()
+	t.modes.table.DeleteAll()
+	t.fragments.table.DeleteAll()
+	t.labels.table.DeleteAll()
+	t.fields.table.DeleteAll()
+
+	t.whitespaces.chars = nil
+	t.whitespaces.occurrences = nil
+
+	t.indent.occurrences = nil
+	t.recovery.occurrences = nil
+
+	t.priorities.counter = 0
+
+	t.starts.nonTerminals = nil
+	t.starts.occurrences = nil
+
+	t.lists.nonTerminals = nil
+
+	t.externals.terminals = nil
+	t.externals.occurrences = nil
+
+	t.macros.current = nil
+	t.macros.table.DeleteAll()
+	t.macros.instances = nil
+
+	t.actions.table.DeleteAll()
+
+	t.codeBlocks.list = nil
+
+	t.overrides.active = false
+
+	t.removals.terminals = nil
+	t.removals.nonTerminals = nil
+	t.removals.symbols = nil
 }
 
 // Verify is called after parsing is complete and the symbol table is populated.
@@ -181,6 +495,50 @@ func (t *SymbolTable) Verify() error {
 		errs = errors.Append(errs, err)
 	}
 
+	if err := t.ensureValidModes(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureValidSkips(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureValidExternals(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureSingleWhitespaces(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureValidIndent(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureConsistentCases(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureSinglePriorities(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureSingleFragments(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureUniqueLabels(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureUniqueFields(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	if err := t.ensureSingleActions(); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -189,7 +547,12 @@ func (t *SymbolTable) Verify() error {
 func (t *SymbolTable) ensureValidTerminals() error {
 	var errs error
 
-	for a := range t.terminals.table.All() {
+	for a, e := range t.terminals.table.All() {
+		// The error token is allowed if all of its occurrences are in error recovery production rules.
+		if a.Equal(ErrorToken) && len(e.definitions) == 0 && len(e.occurrences) == len(t.recovery.occurrences) {
+			continue
+		}
+
 		if generic.Contains(reservedTerminals, grammar.EqTerminal, a) {
 			errs = errors.Append(errs,
 				fmt.Errorf("terminal name %s is reserved", a),
@@ -202,40 +565,73 @@ func (t *SymbolTable) ensureValidTerminals() error {
 
 // ensureSingleDefs verifies that each terminal has exactly one definition.
 // It reports an error if a terminal is missing a definition or has multiple definitions.
+// The terminals synthesized for indentation tracking are verified separately by ensureValidIndent.
+// The error token has no definition, since it is never produced by the lexer.
+// The external terminals are verified separately by ensureValidExternals.
 func (t *SymbolTable) ensureSingleDefs() error {
 	var errs error
 
 	for a, e := range t.terminals.table.All() {
-		if count := len(e.definitions); count == 0 {
+		if len(t.indent.occurrences) > 0 && generic.Contains(indentTerminals, grammar.EqTerminal, a) {
+			continue
+		}
+
+		if generic.Contains(t.externals.terminals, grammar.EqTerminal, a) {
+			continue
+		}
+
+		if len(t.recovery.occurrences) > 0 && a.Equal(ErrorToken) {
+			continue
+		}
+
+		if len(e.definitions) == 0 {
 			errs = errors.Append(errs, fmt.Errorf("no definition for terminal %s", a))
-		} else if count > 1 {
-			poses := generic.Transform(e.definitions, func(def *TerminalDef) string {
-				return fmt.Sprintf("  %s", def.Pos)
-			})
+			continue
+		}
 
-			errs = errors.Append(errs,
-				fmt.Errorf("multiple definitions for terminal %s:\n%s", a, strings.Join(poses, "\n")),
-			)
+		for _, defs := range groupByMode(e.definitions) {
+			if len(defs) > 1 {
+				poses := generic.Transform(defs, func(def *TerminalDef) string {
+					return fmt.Sprintf("  %s", def.Pos)
+				})
+
+				var in string
+				if mode := defs[0].Mode; mode != "" {
+					in = " in mode " + mode
+				}
+
+				errs = errors.Append(errs,
+					fmt.Errorf("multiple definitions for terminal %s%s:\n%s", a, in, strings.Join(poses, "\n")),
+				)
+			}
 		}
 	}
 
 	return errs
 }
 
-// ensureDistinctDefs verifies that terminal definitions use distinct values.
-// It reports an error when more than one terminal is defined with the same value.
+// ensureDistinctDefs verifies that terminal definitions in the same lexer mode use distinct values.
+// It reports an error when more than one terminal is defined with the same value in the same mode.
 func (t *SymbolTable) ensureDistinctDefs() error {
 	var errs error
 
-	reverse := make(map[string][]*TerminalDef)
+	type key struct {
+		mode, value string
+	}
+
+	reverse := make(map[key][]*TerminalDef)
 	for _, e := range t.terminals.table.All() {
-		if len(e.definitions) == 1 {
-			def := e.definitions[0]
-			reverse[def.Value] = append(reverse[def.Value], def)
+		for _, defs := range groupByMode(e.definitions) {
+			if len(defs) == 1 {
+				def := defs[0]
+				k := key{def.Mode, def.Value}
+				reverse[k] = append(reverse[k], def)
+			}
 		}
 	}
 
-	for val, defs := range reverse {
+	for k, defs := range reverse {
+		val := k.value
 		if len(defs) > 1 {
 			poses := generic.Transform(defs, func(def *TerminalDef) string {
 				return fmt.Sprintf("  %s: %s", def.Pos, def.Terminal)
@@ -250,58 +646,639 @@ func (t *SymbolTable) ensureDistinctDefs() error {
 	return errs
 }
 
-// ensureStartSymbol ensures a production rule exists with the start symbol as the head non-terminal.
+// ensureStartSymbol ensures a production rule exists with each start symbol as the head non-terminal.
+// Without any @start directive, the start symbol is the non-terminal named start.
+// It also reports an error if a non-terminal is declared as a start symbol more than once.
 func (t *SymbolTable) ensureStartSymbol() error {
-	hasStart := t.productions.table.AnyMatch(func(p *grammar.Production, _ *productionEntry) bool {
-		return p.Head.Equal(start)
+	var errs error
+
+	starts := t.starts.nonTerminals
+	if len(starts) == 0 {
+		starts = []grammar.NonTerminal{start}
+	}
+
+	for i, A := range starts {
+		if generic.Contains(starts[:i], grammar.EqNonTerminal, A) {
+			continue
+		}
+
+		hasStart := t.productions.table.AnyMatch(func(p *grammar.Production, _ *productionEntry) bool {
+			return p.Head.Equal(A)
+		})
+
+		if !hasStart {
+			errs = errors.Append(errs,
+				fmt.Errorf("missing production rule with the start symbol: %s", A),
+			)
+		}
+
+		var poses []string
+		for j, B := range t.starts.nonTerminals {
+			if B.Equal(A) {
+				poses = append(poses, fmt.Sprintf("  %s", t.starts.occurrences[j]))
+			}
+		}
+
+		if len(poses) > 1 {
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple start declarations for non-terminal %s:\n%s", A, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureValidModes verifies that no lexer mode uses the reserved default mode name
+// and that every mode action refers to either a declared mode or the default mode.
+func (t *SymbolTable) ensureValidModes() error {
+	var errs error
+
+	if e, ok := t.modes.table.Get(DefaultMode); ok {
+		errs = errors.Append(errs,
+			fmt.Errorf("mode name %s is reserved: %s", DefaultMode, e.occurrences[0]),
+		)
+	}
+
+	for _, e := range t.terminals.table.All() {
+		for _, def := range e.definitions {
+			if def.Action == nil || def.Action.Kind == PopMode || def.Action.Mode == DefaultMode {
+				continue
+			}
+
+			if _, ok := t.modes.table.Get(def.Action.Mode); !ok {
+				errs = errors.Append(errs,
+					fmt.Errorf("undefined mode %s in %s action for terminal %s: %s", def.Action.Mode, def.Action, def.Terminal, def.Action.Pos),
+				)
+			}
+		}
+	}
+
+	return errs
+}
+
+// ensureValidSkips verifies that skipped terminals are not used in any production rule.
+// A skipped terminal is discarded by the lexer, so the parser never receives it.
+func (t *SymbolTable) ensureValidSkips() error {
+	var errs error
+
+	for a, e := range t.terminals.table.All() {
+		if len(e.skips) > 0 && len(e.occurrences) > 0 {
+			poses := generic.Transform(e.occurrences, func(pos *lexer.Position) string {
+				return fmt.Sprintf("  %s", pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("skipped terminal %s is used in production rules:\n%s", a, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureValidExternals verifies that each external terminal is declared at most once
+// and that it is not defined by the grammar, since it is recognized by a scanner function registered with the lexer.
+func (t *SymbolTable) ensureValidExternals() error {
+	var errs error
+
+	for i, a := range t.externals.terminals {
+		if generic.Contains(t.externals.terminals[:i], grammar.EqTerminal, a) {
+			continue
+		}
+
+		var poses []string
+		for j, b := range t.externals.terminals {
+			if b.Equal(a) {
+				poses = append(poses, fmt.Sprintf("  %s", t.externals.occurrences[j]))
+			}
+		}
+
+		if len(poses) > 1 {
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple external declarations for terminal %s:\n%s", a, strings.Join(poses, "\n")),
+			)
+		}
+
+		if e, ok := t.terminals.table.Get(a); ok && len(e.definitions) > 0 {
+			poses := generic.Transform(e.definitions, func(def *TerminalDef) string {
+				return fmt.Sprintf("  %s", def.Pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("external terminal %s cannot be defined:\n%s", a, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureSingleWhitespaces verifies that the set of whitespace characters is declared at most once.
+func (t *SymbolTable) ensureSingleWhitespaces() error {
+	if len(t.whitespaces.occurrences) > 1 {
+		poses := generic.Transform(t.whitespaces.occurrences, func(pos *lexer.Position) string {
+			return fmt.Sprintf("  %s", pos)
+		})
+
+		return fmt.Errorf("multiple whitespace directives:\n%s", strings.Join(poses, "\n"))
+	}
+
+	return nil
+}
+
+// ensureValidIndent verifies that indentation tracking is enabled at most once
+// and that the terminals synthesized for it are not defined by the grammar.
+func (t *SymbolTable) ensureValidIndent() error {
+	var errs error
+
+	if len(t.indent.occurrences) == 0 {
+		return nil
+	}
+
+	if len(t.indent.occurrences) > 1 {
+		poses := generic.Transform(t.indent.occurrences, func(pos *lexer.Position) string {
+			return fmt.Sprintf("  %s", pos)
+		})
+
+		errs = errors.Append(errs,
+			fmt.Errorf("multiple indent directives:\n%s", strings.Join(poses, "\n")),
+		)
+	}
+
+	for _, a := range indentTerminals {
+		if e, ok := t.terminals.table.Get(a); ok && len(e.definitions) > 0 {
+			poses := generic.Transform(e.definitions, func(def *TerminalDef) string {
+				return fmt.Sprintf("  %s", def.Pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("terminal %s is synthesized by the indent directive and cannot be defined:\n%s", a, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureConsistentCases verifies that a terminal referenced by its string value
+// is either always or never marked as case-insensitive.
+func (t *SymbolTable) ensureConsistentCases() error {
+	var errs error
+
+	for a, e := range t.terminals.table.All() {
+		if len(e.ignoreCases) > 0 && len(e.ignoreCases) < len(e.occurrences) {
+			poses := generic.Transform(e.occurrences, func(pos *lexer.Position) string {
+				return fmt.Sprintf("  %s", pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("terminal %s is used both with and without the case-insensitive marker:\n%s", a, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureSinglePriorities verifies that each terminal is listed in the priority directives at most once.
+func (t *SymbolTable) ensureSinglePriorities() error {
+	var errs error
+
+	for a, e := range t.terminals.table.All() {
+		if len(e.priorities) > 1 {
+			poses := generic.Transform(e.priorities, func(pos *lexer.Position) string {
+				return fmt.Sprintf("  %s", pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple priorities for terminal %s:\n%s", a, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureSingleFragments verifies that each regex fragment has exactly one definition.
+func (t *SymbolTable) ensureSingleFragments() error {
+	var errs error
+
+	for name, e := range t.fragments.table.All() {
+		if len(e.definitions) > 1 {
+			poses := generic.Transform(e.definitions, func(def *FragmentDef) string {
+				return fmt.Sprintf("  %s", def.Pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple definitions for fragment %s:\n%s", name, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureUniqueLabels verifies that each label names exactly one production rule
+// and that each production rule is named by at most one label.
+// The same alternative can occur more than once, e.g., in a rule and in a precedence directive.
+func (t *SymbolTable) ensureUniqueLabels() error {
+	var errs error
+
+	type occurrence struct {
+		label string
+		pos   *lexer.Position
+	}
+
+	// Production rules are keyed by their string representations.
+	var order []string
+	reverse := make(map[string][]occurrence)
+
+	for name, e := range t.labels.table.All() {
+		for i, p := range e.productions {
+			if !p.Equal(e.productions[0]) {
+				poses := make([]string, len(e.productions))
+				for j, p := range e.productions {
+					poses[j] = fmt.Sprintf("  %s: %s", e.occurrences[j], p)
+				}
+
+				errs = errors.Append(errs,
+					fmt.Errorf("label #%s names multiple productions:\n%s", name, strings.Join(poses, "\n")),
+				)
+
+				break
+			}
+
+			key := p.String()
+			if _, ok := reverse[key]; !ok {
+				order = append(order, key)
+			}
+
+			reverse[key] = append(reverse[key], occurrence{name, e.occurrences[i]})
+		}
+	}
+
+	for _, key := range order {
+		occurrences := reverse[key]
+		if generic.AnyMatch(occurrences, func(o occurrence) bool { return o.label != occurrences[0].label }) {
+			poses := generic.Transform(occurrences, func(o occurrence) string {
+				return fmt.Sprintf("  %s: #%s", o.pos, o.label)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple labels for production %s:\n%s", key, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureUniqueFields verifies that the symbols in each production rule have distinct field names
+// and that all occurrences of a production rule name its symbols the same way.
+func (t *SymbolTable) ensureUniqueFields() error {
+	var errs error
+
+	for p, e := range t.fields.table.All() {
+		seen := map[string]bool{}
+		for _, name := range e.fields[0] {
+			if name != "" && seen[name] {
+				errs = errors.Append(errs, fmt.Errorf("multiple symbols named %s in production %s: %s", name, p, e.occurrences[0]))
+			}
+			seen[name] = true
+		}
+
+		if generic.AnyMatch(e.fields, func(fields []string) bool { return !slices.Equal(fields, e.fields[0]) }) {
+			poses := make([]string, len(e.fields))
+			for i, fields := range e.fields {
+				poses[i] = fmt.Sprintf("  %s: %s", e.occurrences[i], formatFields(p, fields))
+			}
+
+			errs = errors.Append(errs,
+				fmt.Errorf("conflicting field names for production %s:\n%s", p, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// ensureSingleActions verifies that each production rule has at most one semantic action.
+// The same alternative can occur more than once, e.g., in a rule and in a precedence directive.
+func (t *SymbolTable) ensureSingleActions() error {
+	var errs error
+
+	for p, e := range t.actions.table.All() {
+		if len(e.actions) > 1 {
+			poses := generic.Transform(e.actions, func(a *SemanticAction) string {
+				return fmt.Sprintf("  %s", a.Pos)
+			})
+
+			errs = errors.Append(errs,
+				fmt.Errorf("multiple actions for production %s:\n%s", p, strings.Join(poses, "\n")),
+			)
+		}
+	}
+
+	return errs
+}
+
+// formatFields returns a string representation of the body of a production rule along with the field names of its symbols.
+func formatFields(p *grammar.Production, fields []string) string {
+	syms := make([]string, len(p.Body))
+	for i, X := range p.Body {
+		if i < len(fields) && fields[i] != "" {
+			syms[i] = fields[i] + ":" + X.String()
+		} else {
+			syms[i] = X.String()
+		}
+	}
+
+	return strings.Join(syms, " ")
+}
+
+// Precedences returns the set of precedence levels added to the symbol table.
+func (t *SymbolTable) Precedences() lr.PrecedenceLevels {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.precedences.list
+}
+
+// Definitions constructs and returns an ordered list of definitions,
+// representing deterministic finite automata (DFAs) for all terminal symbols in the symbol table.
+// Definitions in the default mode come first, followed by the definitions in each mode in the order they are declared.
+func (t *SymbolTable) Definitions() []*TerminalDef {
+	t.Lock()
+	defer t.Unlock()
+
+	defs := make([]*TerminalDef, 0, t.terminals.table.Size())
+	for _, e := range t.terminals.table.All() {
+		for _, group := range groupByMode(e.definitions) {
+			if len(group) == 1 {
+				group[0].IgnoreCase = group[0].IgnoreCase || len(e.ignoreCases) > 0
+				group[0].Skip = len(e.skips) > 0
+				group[0].Priority = e.priority
+				defs = append(defs, group[0])
+			}
+		}
+	}
+
+	modeIndex := func(mode string) int {
+		if e, ok := t.modes.table.Get(mode); ok {
+			return e.index
+		}
+		return 0
+	}
+
+	// Sort terminals by mode, placing string-based terminals before regex-based ones and shorter terminals before longer ones.
+	sort.Quick(defs, func(lhs, rhs *TerminalDef) int {
+		if l, r := modeIndex(lhs.Mode), modeIndex(rhs.Mode); l < r {
+			return -1
+		} else if l > r {
+			return 1
+		}
+
+		if lhs.Kind == StringDef && rhs.Kind == RegexDef {
+			return -1
+		} else if lhs.Kind == RegexDef && rhs.Kind == StringDef {
+			return 1
+		}
+
+		if len(lhs.Terminal) < len(rhs.Terminal) {
+			return -1
+		} else if len(lhs.Terminal) > len(rhs.Terminal) {
+			return 1
+		}
+
+		return grammar.CmpTerminal(lhs.Terminal, rhs.Terminal)
 	})
 
-	if !hasStart {
-		return fmt.Errorf("missing production rule with the start symbol: %s", start)
+	return defs
+}
+
+// Modes returns the names of the lexer modes added to the symbol table in the order they are declared.
+// The default mode is not included.
+func (t *SymbolTable) Modes() []string {
+	t.Lock()
+	defer t.Unlock()
+
+	all := make([]string, t.modes.table.Size())
+	for name, e := range t.modes.table.All() {
+		all[e.index-1] = name
+	}
+
+	return all
+}
+
+// Whitespaces returns the set of whitespace characters declared in the symbol table.
+// It returns nil if no set is declared, and an empty list if the declared set is empty.
+func (t *SymbolTable) Whitespaces() []rune {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.whitespaces.occurrences) == 0 {
+		return nil
+	}
+
+	return append([]rune{}, t.whitespaces.chars...)
+}
+
+// Fragments returns the regex fragments added to the symbol table, sorted by name.
+func (t *SymbolTable) Fragments() []*FragmentDef {
+	t.Lock()
+	defer t.Unlock()
+
+	all := make([]*FragmentDef, 0, t.fragments.table.Size())
+	for _, e := range t.fragments.table.All() {
+		all = append(all, e.definitions[0])
+	}
+
+	return all
+}
+
+// Labels returns the labels of the labeled production rules.
+// If a production rule is named by more than one label, the first label in alphabetical order is kept.
+func (t *SymbolTable) Labels() symboltable.SymbolTable[*grammar.Production, string] {
+	t.Lock()
+	defer t.Unlock()
+
+	labels := symboltable.NewQuadraticHashTable[*grammar.Production, string](
+		grammar.HashProduction,
+		grammar.EqProduction,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for name, e := range t.labels.table.All() {
+		for _, p := range e.productions {
+			if _, ok := labels.Get(p); !ok {
+				labels.Put(p, name)
+			}
+		}
+	}
+
+	return labels
+}
+
+// Fields returns the field names of the symbols in the production rules that have named symbols.
+// Each list of field names is aligned with the body of its production rule and has an empty name for each unnamed symbol.
+func (t *SymbolTable) Fields() symboltable.SymbolTable[*grammar.Production, []string] {
+	t.Lock()
+	defer t.Unlock()
+
+	fields := symboltable.NewQuadraticHashTable[*grammar.Production, []string](
+		grammar.HashProduction,
+		grammar.EqProduction,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for p, e := range t.fields.table.All() {
+		fields.Put(p, e.fields[0])
+	}
+
+	return fields
+}
+
+// Starts returns the start symbols declared by @start directives in the order of declaration.
+// It returns nil if no start symbol is declared, in which case the start symbol is the non-terminal named start.
+func (t *SymbolTable) Starts() []grammar.NonTerminal {
+	t.Lock()
+	defer t.Unlock()
+
+	var starts []grammar.NonTerminal
+	for _, A := range t.starts.nonTerminals {
+		if !generic.Contains(starts, grammar.EqNonTerminal, A) {
+			starts = append(starts, A)
+		}
+	}
+
+	return starts
+}
+
+// Externals returns the external terminals declared by @external directives in the order of declaration.
+// It returns nil if no external terminal is declared.
+func (t *SymbolTable) Externals() []*ExternalDef {
+	t.Lock()
+	defer t.Unlock()
+
+	var defs []*ExternalDef
+	for i, a := range t.externals.terminals {
+		if generic.Contains(t.externals.terminals[:i], grammar.EqTerminal, a) {
+			continue
+		}
+
+		def := &ExternalDef{
+			Terminal: a,
+			Pos:      t.externals.occurrences[i],
+		}
+
+		if e, ok := t.terminals.table.Get(a); ok {
+			def.Skip = len(e.skips) > 0
+		}
+
+		defs = append(defs, def)
+	}
+
+	return defs
+}
+
+// TerminalDocs returns the doc comments of the terminal symbols that have one.
+func (t *SymbolTable) TerminalDocs() symboltable.SymbolTable[grammar.Terminal, string] {
+	t.Lock()
+	defer t.Unlock()
+
+	docs := symboltable.NewQuadraticHashTable[grammar.Terminal, string](
+		grammar.HashTerminal,
+		grammar.EqTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for a, e := range t.terminals.table.All() {
+		if e.doc != "" {
+			docs.Put(a, e.doc)
+		}
+	}
+
+	return docs
+}
+
+// NonTerminalDocs returns the doc comments of the non-terminal symbols that have one.
+func (t *SymbolTable) NonTerminalDocs() symboltable.SymbolTable[grammar.NonTerminal, string] {
+	t.Lock()
+	defer t.Unlock()
+
+	docs := symboltable.NewQuadraticHashTable[grammar.NonTerminal, string](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for A, e := range t.nonTerminals.table.All() {
+		if e.doc != "" {
+			docs.Put(A, e.doc)
+		}
+	}
+
+	return docs
+}
+
+// Lists returns the non-terminal symbols generated for separated lists in the order of generation.
+// It returns nil if the grammar has no separated list.
+func (t *SymbolTable) Lists() []grammar.NonTerminal {
+	t.Lock()
+	defer t.Unlock()
+
+	return slices.Clone(t.lists.nonTerminals)
+}
+
+// Actions returns the semantic actions of the production rules that have one.
+// If a production rule has more than one semantic action, the first one is kept.
+func (t *SymbolTable) Actions() symboltable.SymbolTable[*grammar.Production, *SemanticAction] {
+	t.Lock()
+	defer t.Unlock()
+
+	actions := symboltable.NewQuadraticHashTable[*grammar.Production, *SemanticAction](
+		grammar.HashProduction,
+		grammar.EqProduction,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for p, e := range t.actions.table.All() {
+		actions.Put(p, e.actions[0])
 	}
 
-	return nil
+	return actions
 }
 
-// Precedences returns the set of precedence levels added to the symbol table.
-func (t *SymbolTable) Precedences() lr.PrecedenceLevels {
+// CodeBlocks returns the top-level code blocks in the order they are declared.
+func (t *SymbolTable) CodeBlocks() []*CodeBlock {
 	t.Lock()
 	defer t.Unlock()
 
-	return t.precedences.list
+	return slices.Clone(t.codeBlocks.list)
 }
 
-// Definitions constructs and returns an ordered list of definitions,
-// representing deterministic finite automata (DFAs) for all terminal symbols in the symbol table.
-func (t *SymbolTable) Definitions() []*TerminalDef {
+// Indent returns true if indentation tracking is enabled in the symbol table.
+func (t *SymbolTable) Indent() bool {
 	t.Lock()
 	defer t.Unlock()
 
-	defs := make([]*TerminalDef, 0, t.terminals.table.Size())
-	for _, e := range t.terminals.table.All() {
-		if len(e.definitions) == 1 {
-			defs = append(defs, e.definitions[0])
-		}
-	}
-
-	// Sort terminals, placing string-based terminals before regex-based ones and shorter terminals before longer ones.
-	sort.Quick(defs, func(lhs, rhs *TerminalDef) int {
-		if lhs.Kind == StringDef && rhs.Kind == RegexDef {
-			return -1
-		} else if lhs.Kind == RegexDef && rhs.Kind == StringDef {
-			return 1
-		}
-
-		if len(lhs.Terminal) < len(rhs.Terminal) {
-			return -1
-		} else if len(lhs.Terminal) > len(rhs.Terminal) {
-			return 1
-		}
-
-		return grammar.CmpTerminal(lhs.Terminal, rhs.Terminal)
-	})
-
-	return defs
+	return len(t.indent.occurrences) > 0
 }
 
 // Terminals returns the set of terminal symbols added to the symbol table.
@@ -343,6 +1320,50 @@ func (t *SymbolTable) Productions() []*grammar.Production {
 	return all
 }
 
+// Positions returns the position of the first occurrence of each production rule added to the symbol table.
+func (t *SymbolTable) Positions() symboltable.SymbolTable[*grammar.Production, *lexer.Position] {
+	t.Lock()
+	defer t.Unlock()
+
+	positions := symboltable.NewQuadraticHashTable[*grammar.Production, *lexer.Position](
+		grammar.HashProduction,
+		grammar.EqProduction,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for p, e := range t.productions.table.All() {
+		if len(e.occurrences) > 0 {
+			positions.Put(p, e.occurrences[0])
+		}
+	}
+
+	return positions
+}
+
+// Origins returns the EBNF constructs from which the synthesized non-terminal symbols are generated.
+func (t *SymbolTable) Origins() symboltable.SymbolTable[grammar.NonTerminal, *Origin] {
+	t.Lock()
+	defer t.Unlock()
+
+	origins := symboltable.NewQuadraticHashTable[grammar.NonTerminal, *Origin](
+		grammar.HashNonTerminal,
+		grammar.EqNonTerminal,
+		nil,
+		symboltable.HashOpts{
+			InitialCap: 89,
+		},
+	)
+
+	for A, o := range t.origins.table.All() {
+		origins.Put(A, o)
+	}
+
+	return origins
+}
+
 // AddPrecedence adds a new precedence level to the symbol table.
 func (t *SymbolTable) AddPrecedence(p *lr.PrecedenceLevel) {
 	t.Lock()
@@ -352,7 +1373,8 @@ func (t *SymbolTable) AddPrecedence(p *lr.PrecedenceLevel) {
 }
 
 // AddStringTokenDef adds a token definition with a string value to the symbol table.
-func (t *SymbolTable) AddStringTokenDef(token grammar.Terminal, value string, pos *lexer.Position) {
+// It returns the new definition, so the parser can associate it with a lexer mode and a mode action.
+func (t *SymbolTable) AddStringTokenDef(token grammar.Terminal, value string, pos *lexer.Position) *TerminalDef {
 	t.Lock()
 	defer t.Unlock()
 
@@ -369,16 +1391,21 @@ func (t *SymbolTable) AddStringTokenDef(token grammar.Terminal, value string, po
 		t.terminals.table.Put(token, e)
 	}
 
-	e.definitions = append(e.definitions, &TerminalDef{
+	def := &TerminalDef{
 		Terminal: token,
 		Kind:     StringDef,
 		Value:    value,
 		Pos:      pos,
-	})
+	}
+
+	e.definitions = append(e.definitions, def)
+
+	return def
 }
 
 // AddRegexTokenDef adds a token definition with a regex value to the symbol table.
-func (t *SymbolTable) AddRegexTokenDef(token grammar.Terminal, regex string, pos *lexer.Position) {
+// It returns the new definition, so the parser can associate it with a lexer mode and a mode action.
+func (t *SymbolTable) AddRegexTokenDef(token grammar.Terminal, regex string, pos *lexer.Position) *TerminalDef {
 	t.Lock()
 	defer t.Unlock()
 
@@ -395,12 +1422,16 @@ func (t *SymbolTable) AddRegexTokenDef(token grammar.Terminal, regex string, pos
 		t.terminals.table.Put(token, e)
 	}
 
-	e.definitions = append(e.definitions, &TerminalDef{
+	def := &TerminalDef{
 		Terminal: token,
 		Kind:     RegexDef,
 		Value:    regex,
 		Pos:      pos,
-	})
+	}
+
+	e.definitions = append(e.definitions, def)
+
+	return def
 }
 
 // AddStringTerminal records an occurrence of a terminal symbol referenced by its string value in the symbol table.
@@ -434,6 +1465,20 @@ func (t *SymbolTable) AddStringTerminal(a grammar.Terminal, pos *lexer.Position)
 	})
 }
 
+// AddIgnoreCaseStringTerminal records an occurrence of a terminal symbol
+// referenced by its string value with the case-insensitive marker in the symbol table.
+//
+// It works the same as AddStringTerminal, but the terminal definition matches its string value case-insensitively.
+func (t *SymbolTable) AddIgnoreCaseStringTerminal(a grammar.Terminal, pos *lexer.Position) {
+	t.AddStringTerminal(a, pos)
+
+	t.Lock()
+	defer t.Unlock()
+
+	e, _ := t.terminals.table.Get(a)
+	e.ignoreCases = append(e.ignoreCases, pos)
+}
+
 // AddTokenTerminal records an occurrence of a terminal symbol referenced by its token name in the symbol table.
 //
 // If the terminal already have a definition in the table,
@@ -459,12 +1504,27 @@ func (t *SymbolTable) AddTokenTerminal(a grammar.Terminal, pos *lexer.Position)
 	})
 }
 
+// AddErrorToken records an occurrence of the error token in an error recovery production rule.
+// It works the same as AddTokenTerminal, but the error token needs no definition.
+func (t *SymbolTable) AddErrorToken(pos *lexer.Position) {
+	t.AddTokenTerminal(ErrorToken, pos)
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.recovery.occurrences = append(t.recovery.occurrences, pos)
+}
+
 // AddNonTerminal adds a non-terminal symbol to the symbol table.
 // If the non-terminal symbol already exists, the position is added to its occurrences.
 func (t *SymbolTable) AddNonTerminal(A grammar.NonTerminal, pos *lexer.Position) {
 	t.Lock()
 	defer t.Unlock()
 
+	t.addNonTerminal(A, pos)
+}
+
+func (t *SymbolTable) addNonTerminal(A grammar.NonTerminal, pos *lexer.Position) {
 	if e, ok := t.nonTerminals.table.Get(A); ok {
 		e.occurrences = append(e.occurrences, pos)
 		return
@@ -483,6 +1543,10 @@ func (t *SymbolTable) AddProduction(p *grammar.Production, pos *lexer.Position)
 	t.Lock()
 	defer t.Unlock()
 
+	t.addProduction(p, pos)
+}
+
+func (t *SymbolTable) addProduction(p *grammar.Production, pos *lexer.Position) {
 	if e, ok := t.productions.table.Get(p); ok {
 		e.occurrences = append(e.occurrences, pos)
 		return
@@ -495,23 +1559,273 @@ func (t *SymbolTable) AddProduction(p *grammar.Production, pos *lexer.Position)
 	})
 }
 
+// AddOrigin records the EBNF construct from which a synthesized non-terminal symbol is generated.
+// If the same non-terminal symbol is generated from multiple constructs, the first one is kept.
+func (t *SymbolTable) AddOrigin(A grammar.NonTerminal, text string, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.addOrigin(A, text, pos)
+}
+
+func (t *SymbolTable) addOrigin(A grammar.NonTerminal, text string, pos *lexer.Position) {
+	if _, ok := t.origins.table.Get(A); ok {
+		return
+	}
+
+	t.origins.table.Put(A, &Origin{
+		Text: text,
+		Pos:  pos,
+	})
+}
+
+// AddMode records a declaration of a lexer mode in the symbol table.
+// A mode can be declared more than once, in which case all of its declarations are merged.
+func (t *SymbolTable) AddMode(name string, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	if e, ok := t.modes.table.Get(name); ok {
+		e.occurrences = append(e.occurrences, pos)
+		return
+	}
+
+	t.modes.counter++
+	t.modes.table.Put(name, &modeEntry{
+		index:       t.modes.counter,
+		occurrences: []*lexer.Position{pos},
+	})
+}
+
+// SetWhitespaces declares the set of whitespace characters that the lexer should discard.
+// If the set is declared more than once, the last declaration is kept.
+func (t *SymbolTable) SetWhitespaces(chars []rune, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.whitespaces.chars = chars
+	t.whitespaces.occurrences = append(t.whitespaces.occurrences, pos)
+}
+
+// SetIndent enables indentation tracking, so that the lexer synthesizes the NEWLINE, INDENT, and DEDENT terminals.
+func (t *SymbolTable) SetIndent(pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.indent.occurrences = append(t.indent.occurrences, pos)
+}
+
+// AddStart declares a non-terminal as a start symbol, i.e., an entry point for parsing.
+func (t *SymbolTable) AddStart(A grammar.NonTerminal, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.starts.nonTerminals = append(t.starts.nonTerminals, A)
+	t.starts.occurrences = append(t.starts.occurrences, pos)
+}
+
+// AddAction records a semantic action attached to a production rule.
+func (t *SymbolTable) AddAction(p *grammar.Production, a *SemanticAction) {
+	t.Lock()
+	defer t.Unlock()
+
+	if e, ok := t.actions.table.Get(p); ok {
+		e.actions = append(e.actions, a)
+		return
+	}
+
+	t.actions.table.Put(p, &actionEntry{
+		actions: []*SemanticAction{a},
+	})
+}
+
+// AddCodeBlock records a top-level code block.
+func (t *SymbolTable) AddCodeBlock(block *CodeBlock) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.codeBlocks.list = append(t.codeBlocks.list, block)
+}
+
+// AddFragment adds a new definition for a regex fragment.
+func (t *SymbolTable) AddFragment(name, regex string, pos *lexer.Position) *FragmentDef {
+	t.Lock()
+	defer t.Unlock()
+
+	def := &FragmentDef{
+		Name:  name,
+		Value: regex,
+		Pos:   pos,
+	}
+
+	if e, ok := t.fragments.table.Get(name); ok {
+		e.definitions = append(e.definitions, def)
+		return def
+	}
+
+	t.fragments.table.Put(name, &fragmentEntry{
+		definitions: []*FragmentDef{def},
+	})
+
+	return def
+}
+
+// AddLabel records a label that names a production rule.
+func (t *SymbolTable) AddLabel(name string, p *grammar.Production, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	if e, ok := t.labels.table.Get(name); ok {
+		e.productions = append(e.productions, p)
+		e.occurrences = append(e.occurrences, pos)
+		return
+	}
+
+	t.labels.table.Put(name, &labelEntry{
+		productions: []*grammar.Production{p},
+		occurrences: []*lexer.Position{pos},
+	})
+}
+
+// AddFields records the field names of the symbols in a production rule.
+func (t *SymbolTable) AddFields(p *grammar.Production, fields []string, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.addFields(p, fields, pos)
+}
+
+func (t *SymbolTable) addFields(p *grammar.Production, fields []string, pos *lexer.Position) {
+	if e, ok := t.fields.table.Get(p); ok {
+		e.fields = append(e.fields, fields)
+		e.occurrences = append(e.occurrences, pos)
+		return
+	}
+
+	t.fields.table.Put(p, &fieldsEntry{
+		fields:      [][]string{fields},
+		occurrences: []*lexer.Position{pos},
+	})
+}
+
+// AddSkip records a terminal symbol that the lexer should discard.
+// If the terminal does not yet exist in the table,
+// a new entry is created with an empty list of definitions and no occurrences;
+// the terminal's actual definition will be added later when it is encountered during parsing.
+func (t *SymbolTable) AddSkip(a grammar.Terminal, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	if e, ok := t.terminals.table.Get(a); ok {
+		e.skips = append(e.skips, pos)
+		return
+	}
+
+	t.terminals.counter++
+
+	t.terminals.table.Put(a, &terminalEntry{
+		index:       t.terminals.counter,
+		definitions: []*TerminalDef{},
+		occurrences: []*lexer.Position{},
+		skips:       []*lexer.Position{pos},
+	})
+}
+
+// AddExternal declares a terminal symbol as external, i.e., recognized by a scanner function registered with the lexer.
+// If the terminal does not yet exist in the table,
+// a new entry is created with an empty list of definitions and no occurrences.
+func (t *SymbolTable) AddExternal(a grammar.Terminal, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.externals.terminals = append(t.externals.terminals, a)
+	t.externals.occurrences = append(t.externals.occurrences, pos)
+
+	if _, ok := t.terminals.table.Get(a); ok {
+		return
+	}
+
+	t.terminals.counter++
+
+	t.terminals.table.Put(a, &terminalEntry{
+		index:       t.terminals.counter,
+		definitions: []*TerminalDef{},
+		occurrences: []*lexer.Position{},
+	})
+}
+
+// AddPriority records a terminal symbol that takes precedence over other regex-based terminals
+// when their definitions capture the same string.
+// Terminals are ranked in the order they are added, so the first terminal added has the highest priority.
+// If the terminal does not yet exist in the table,
+// a new entry is created with an empty list of definitions and no occurrences;
+// the terminal's actual definition will be added later when it is encountered during parsing.
+func (t *SymbolTable) AddPriority(a grammar.Terminal, pos *lexer.Position) {
+	t.Lock()
+	defer t.Unlock()
+
+	if e, ok := t.terminals.table.Get(a); ok {
+		if e.priority == 0 {
+			t.priorities.counter++
+			e.priority = t.priorities.counter
+		}
+
+		e.priorities = append(e.priorities, pos)
+		return
+	}
+
+	t.terminals.counter++
+	t.priorities.counter++
+
+	t.terminals.table.Put(a, &terminalEntry{
+		index:       t.terminals.counter,
+		definitions: []*TerminalDef{},
+		occurrences: []*lexer.Position{},
+		priority:    t.priorities.counter,
+		priorities:  []*lexer.Position{pos},
+	})
+}
+
+// SetDoc sets the doc comment of a terminal or non-terminal symbol already added to the symbol table.
+// A later doc comment replaces an earlier one, so a token or rule overridden by a dialect can be documented anew.
+func (t *SymbolTable) SetDoc(X grammar.Symbol, doc string) {
+	t.Lock()
+	defer t.Unlock()
+
+	switch X := X.(type) {
+	case grammar.Terminal:
+		if e, ok := t.terminals.table.Get(X); ok {
+			e.doc = doc
+		}
+
+	case grammar.NonTerminal:
+		if e, ok := t.nonTerminals.table.Get(X); ok {
+			e.doc = doc
+		}
+	}
+}
+
 // GetStar generates a new non-terminal symbol for zero or more occurrences of a list of grammar strings.
 // If a name was previously generated for the same strings and purpose, it will be reused.
 func (t *SymbolTable) GetStar(s Strings) grammar.NonTerminal {
 	t.Lock()
 	defer t.Unlock()
 
+	return t.getStar(s)
+}
+
+func (t *SymbolTable) getStar(s Strings) grammar.NonTerminal {
 	e, ok := t.strings.table.Get(s)
-	if ok {
-		return e.Star
+	if !ok {
+		e = &stringsEntry{}
+		t.strings.table.Put(s, e)
 	}
 
-	star := t.mapStringToNoneTerminal(s, "star")
-	t.strings.table.Put(s, &stringsEntry{
-		Star: star,
-	})
+	if e.Star == "" {
+		e.Star = t.mapStringToNoneTerminal(s, "star")
+	}
 
-	return star
+	return e.Star
 }
 
 // GetPlus generates a new non-terminal symbol for one or more occurrences of a list of grammar strings.
@@ -520,17 +1834,62 @@ func (t *SymbolTable) GetPlus(s Strings) grammar.NonTerminal {
 	t.Lock()
 	defer t.Unlock()
 
+	return t.getPlus(s)
+}
+
+func (t *SymbolTable) getPlus(s Strings) grammar.NonTerminal {
+	e, ok := t.strings.table.Get(s)
+	if !ok {
+		e = &stringsEntry{}
+		t.strings.table.Put(s, e)
+	}
+
+	if e.Plus == "" {
+		e.Plus = t.mapStringToNoneTerminal(s, "plus")
+	}
+
+	return e.Plus
+}
+
+// GetList generates a new non-terminal symbol for one or more occurrences of a list of grammar strings
+// separated by occurrences of another list of grammar strings.
+// If a name was previously generated for the same strings and separators, it will be reused.
+func (t *SymbolTable) GetList(s, sep Strings) grammar.NonTerminal {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.getList(s, sep)
+}
+
+func (t *SymbolTable) getList(s, sep Strings) grammar.NonTerminal {
 	e, ok := t.strings.table.Get(s)
-	if ok {
-		return e.Plus
+	if !ok {
+		e = &stringsEntry{}
+		t.strings.table.Put(s, e)
+	}
+
+	for _, l := range e.Lists {
+		if eqStrings(l.Sep, sep) {
+			return l.List
+		}
+	}
+
+	// Lists of the same strings with different separators need distinct names.
+	var list grammar.NonTerminal
+	if len(e.Lists) == 0 {
+		list = t.mapStringToNoneTerminal(s, "list")
+	} else {
+		list = t.mapStringToNoneTerminal(nil, "list")
 	}
 
-	plus := t.mapStringToNoneTerminal(s, "plus")
-	t.strings.table.Put(s, &stringsEntry{
-		Plus: plus,
+	e.Lists = append(e.Lists, &listEntry{
+		Sep:  sep,
+		List: list,
 	})
 
-	return plus
+	t.lists.nonTerminals = append(t.lists.nonTerminals, list)
+
+	return list
 }
 
 // mapStringToNoneTerminal generates a non-terminal name based on the provided list of grammar strings and a suffix.
@@ -558,6 +1917,23 @@ func (t *SymbolTable) mapStringToNoneTerminal(s Strings, suffix string) grammar.
 	return grammar.NonTerminal(name)
 }
 
+// groupByMode groups a list of terminal definitions by their lexer modes, preserving the order of the definitions.
+func groupByMode(defs []*TerminalDef) [][]*TerminalDef {
+	var groups [][]*TerminalDef
+	index := make(map[string]int)
+
+	for _, def := range defs {
+		if i, ok := index[def.Mode]; ok {
+			groups[i] = append(groups[i], def)
+		} else {
+			index[def.Mode] = len(groups)
+			groups = append(groups, []*TerminalDef{def})
+		}
+	}
+
+	return groups
+}
+
 // terminalAliases maps common terminal symbols to short, human-readable aliases.
 // These aliases are used when auto-generating names for synthetic non-terminal symbols that
 // represent a grouping, an optional string, a Kleene star (zero or more), or a Kleene plus (one or more).
@@ -11,6 +11,41 @@ import (
 	"github.com/moorara/algo/parser/lr"
 )
 
+func TestModeAction_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		a              *ModeAction
+		expectedString string
+	}{
+		{
+			name:           "Push",
+			a:              &ModeAction{Kind: PushMode, Mode: "string"},
+			expectedString: "@push string",
+		},
+		{
+			name:           "Pop",
+			a:              &ModeAction{Kind: PopMode},
+			expectedString: "@pop",
+		},
+		{
+			name:           "Switch",
+			a:              &ModeAction{Kind: SwitchMode, Mode: "default"},
+			expectedString: "@switch default",
+		},
+		{
+			name:           "Invalid",
+			a:              &ModeAction{Kind: ModeActionKind(9)},
+			expectedString: "ModeAction(9)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.a.String())
+		})
+	}
+}
+
 func TestNewSymbolTable(t *testing.T) {
 	t.Run("OK", func(t *testing.T) {
 		st := NewSymbolTable()
@@ -20,12 +55,26 @@ func TestNewSymbolTable(t *testing.T) {
 		assert.NotNil(t, st.nonTerminals.table)
 		assert.NotNil(t, st.productions.table)
 		assert.NotNil(t, st.strings.table)
+		assert.NotNil(t, st.origins.table)
+		assert.NotNil(t, st.modes.table)
+		assert.NotNil(t, st.labels.table)
+		assert.NotNil(t, st.fields.table)
+		assert.NotNil(t, st.macros.table)
+		assert.NotNil(t, st.actions.table)
 	})
 }
 
 func TestSymbolTable_Reset(t *testing.T) {
 	t.Run("OK", func(t *testing.T) {
 		st := NewSymbolTable()
+		st.SetWhitespaces([]rune{' '}, &lexer.Position{})
+		st.SetIndent(&lexer.Position{})
+		st.AddErrorToken(&lexer.Position{})
+		st.AddMacro("list", []string{"X"}, &lexer.Position{})
+		st.AddCodeBlock(&CodeBlock{Code: ` import "strconv" `, Pos: &lexer.Position{}})
+		st.BeginOverride()
+		st.RemoveTokenDefs("STR")
+		st.AddExternal("RAW", &lexer.Position{})
 		st.Reset()
 
 		assert.NotNil(t, st.precedences.list)
@@ -33,6 +82,25 @@ func TestSymbolTable_Reset(t *testing.T) {
 		assert.NotNil(t, st.nonTerminals.table)
 		assert.NotNil(t, st.productions.table)
 		assert.NotNil(t, st.strings.table)
+		assert.NotNil(t, st.origins.table)
+		assert.NotNil(t, st.modes.table)
+		assert.NotNil(t, st.labels.table)
+		assert.NotNil(t, st.fields.table)
+		assert.Nil(t, st.whitespaces.chars)
+		assert.Nil(t, st.whitespaces.occurrences)
+		assert.Nil(t, st.indent.occurrences)
+		assert.Nil(t, st.recovery.occurrences)
+		assert.NotNil(t, st.macros.table)
+		assert.Nil(t, st.macros.current)
+		assert.Nil(t, st.macros.instances)
+		assert.NotNil(t, st.actions.table)
+		assert.Nil(t, st.codeBlocks.list)
+		assert.Nil(t, st.externals.terminals)
+		assert.Nil(t, st.externals.occurrences)
+		assert.False(t, st.overrides.active)
+		assert.Nil(t, st.removals.terminals)
+		assert.Nil(t, st.removals.nonTerminals)
+		assert.Nil(t, st.removals.symbols)
 	})
 }
 
@@ -85,6 +153,291 @@ func TestSymbolTable_Verify(t *testing.T) {
 		&lexer.Position{Filename: "test", Offset: 60, Line: 6, Column: 1},
 	)
 
+	st6 := NewSymbolTable()
+	st6.AddMode("default", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 7})
+	st6.AddStringTokenDef("QUOT", "\"", &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 3}).Mode = "default"
+	st6.AddStringTokenDef("OPEN", "\"", &lexer.Position{Filename: "test", Offset: 40, Line: 5, Column: 1}).Action = &ModeAction{
+		Kind: PushMode,
+		Mode: "string",
+		Pos:  &lexer.Position{Filename: "test", Offset: 47, Line: 5, Column: 8},
+	}
+	st6.AddTokenTerminal("QUOT", &lexer.Position{Filename: "test", Offset: 50, Line: 5, Column: 10})
+	st6.AddTokenTerminal("OPEN", &lexer.Position{Filename: "test", Offset: 55, Line: 5, Column: 15})
+	st6.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OPEN"), grammar.Terminal("QUOT")}},
+		&lexer.Position{Filename: "test", Offset: 60, Line: 6, Column: 1},
+	)
+
+	st7 := NewSymbolTable()
+	st7.AddMode("string", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 7})
+	st7.AddStringTokenDef("QUOT", "'", &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 3}).Mode = "string"
+	st7.AddStringTokenDef("QUOT", "\"", &lexer.Position{Filename: "test", Offset: 30, Line: 4, Column: 3}).Mode = "string"
+	st7.AddTokenTerminal("QUOT", &lexer.Position{Filename: "test", Offset: 50, Line: 5, Column: 10})
+	st7.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("QUOT")}},
+		&lexer.Position{Filename: "test", Offset: 60, Line: 6, Column: 1},
+	)
+
+	st8 := NewSymbolTable()
+	st8.AddMode("string", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 7})
+	st8.AddStringTokenDef("CLOSE", "\"", &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 3}).Mode = "string"
+	st8.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Filename: "test", Offset: 25, Line: 4, Column: 3}).Mode = "string"
+	st8.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Filename: "test", Offset: 30, Line: 5, Column: 1})
+	st8.AddStringTokenDef("OPEN", "\"", &lexer.Position{Filename: "test", Offset: 40, Line: 6, Column: 1}).Action = &ModeAction{
+		Kind: PushMode,
+		Mode: "string",
+		Pos:  &lexer.Position{Filename: "test", Offset: 47, Line: 6, Column: 8},
+	}
+	st8.AddTokenTerminal("OPEN", &lexer.Position{Filename: "test", Offset: 50, Line: 7, Column: 10})
+	st8.AddTokenTerminal("ID", &lexer.Position{Filename: "test", Offset: 55, Line: 7, Column: 15})
+	st8.AddTokenTerminal("CLOSE", &lexer.Position{Filename: "test", Offset: 58, Line: 7, Column: 18})
+	st8.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OPEN"), grammar.Terminal("ID"), grammar.Terminal("CLOSE")}},
+		&lexer.Position{Filename: "test", Offset: 60, Line: 7, Column: 1},
+	)
+
+	st9 := NewSymbolTable()
+	st9.AddRegexTokenDef("COMMENT", "//[^\\n]*", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st9.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 1})
+	st9.AddSkip("COMMENT", &lexer.Position{Filename: "test", Offset: 35, Line: 4, Column: 7})
+	st9.AddTokenTerminal("COMMENT", &lexer.Position{Filename: "test", Offset: 50, Line: 5, Column: 9})
+	st9.AddTokenTerminal("ID", &lexer.Position{Filename: "test", Offset: 58, Line: 5, Column: 17})
+	st9.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("COMMENT"), grammar.Terminal("ID")}},
+		&lexer.Position{Filename: "test", Offset: 42, Line: 5, Column: 1},
+	)
+
+	st10 := NewSymbolTable()
+	st10.AddSkip("COMMENT", &lexer.Position{Filename: "test", Offset: 5, Line: 1, Column: 7})
+	st10.AddRegexTokenDef("COMMENT", "//[^\\n]*", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st10.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 1})
+	st10.AddTokenTerminal("ID", &lexer.Position{Filename: "test", Offset: 50, Line: 5, Column: 9})
+	st10.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID")}},
+		&lexer.Position{Filename: "test", Offset: 42, Line: 5, Column: 1},
+	)
+
+	st11 := NewSymbolTable()
+	st11.SetWhitespaces([]rune{' '}, &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st11.SetWhitespaces([]rune{'\t'}, &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 1})
+	st11.AddStringTokenDef("QUOT", "\"", &lexer.Position{Filename: "test", Offset: 40, Line: 4, Column: 1})
+	st11.AddTokenTerminal("QUOT", &lexer.Position{Filename: "test", Offset: 60, Line: 5, Column: 9})
+	st11.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("QUOT")}},
+		&lexer.Position{Filename: "test", Offset: 52, Line: 5, Column: 1},
+	)
+
+	st12 := NewSymbolTable()
+	st12.SetIndent(&lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st12.SetIndent(&lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 1})
+	st12.AddRegexTokenDef("NEWLINE", `\n`, &lexer.Position{Filename: "test", Offset: 30, Line: 4, Column: 1})
+	st12.AddTokenTerminal("NEWLINE", &lexer.Position{Filename: "test", Offset: 60, Line: 5, Column: 9})
+	st12.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NEWLINE")}},
+		&lexer.Position{Filename: "test", Offset: 52, Line: 5, Column: 1},
+	)
+
+	st13 := NewSymbolTable()
+	st13.SetIndent(&lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st13.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{Filename: "test", Offset: 20, Line: 3, Column: 1})
+	st13.AddTokenTerminal("ID", &lexer.Position{Filename: "test", Offset: 60, Line: 5, Column: 9})
+	st13.AddTokenTerminal("NEWLINE", &lexer.Position{Filename: "test", Offset: 63, Line: 5, Column: 12})
+	st13.AddTokenTerminal("INDENT", &lexer.Position{Filename: "test", Offset: 71, Line: 5, Column: 20})
+	st13.AddTokenTerminal("DEDENT", &lexer.Position{Filename: "test", Offset: 78, Line: 5, Column: 27})
+	st13.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("ID"), grammar.Terminal("NEWLINE"), grammar.Terminal("INDENT"), grammar.Terminal("DEDENT")}},
+		&lexer.Position{Filename: "test", Offset: 52, Line: 5, Column: 1},
+	)
+
+	st14 := NewSymbolTable()
+	st14.AddIgnoreCaseStringTerminal("begin", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 9})
+	st14.AddStringTerminal("begin", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st14.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("begin")}},
+		&lexer.Position{Filename: "test", Offset: 2, Line: 2, Column: 1},
+	)
+
+	st15 := NewSymbolTable()
+	st15.AddIgnoreCaseStringTerminal("begin", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 9})
+	st15.AddIgnoreCaseStringTerminal("begin", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st15.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("begin")}},
+		&lexer.Position{Filename: "test", Offset: 2, Line: 2, Column: 1},
+	)
+
+	st16 := NewSymbolTable()
+	st16.AddRegexTokenDef("OCT", "0[0-7]*", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st16.AddRegexTokenDef("INT", "[0-9]+", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 1})
+	st16.AddPriority("OCT", &lexer.Position{Filename: "test", Offset: 60, Line: 5, Column: 11})
+	st16.AddPriority("OCT", &lexer.Position{Filename: "test", Offset: 80, Line: 6, Column: 11})
+	st16.AddTokenTerminal("OCT", &lexer.Position{Filename: "test", Offset: 100, Line: 8, Column: 9})
+	st16.AddTokenTerminal("INT", &lexer.Position{Filename: "test", Offset: 106, Line: 8, Column: 15})
+	st16.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OCT"), grammar.Terminal("INT")}},
+		&lexer.Position{Filename: "test", Offset: 92, Line: 8, Column: 1},
+	)
+
+	st17 := NewSymbolTable()
+	st17.AddPriority("OCT", &lexer.Position{Filename: "test", Offset: 5, Line: 1, Column: 11})
+	st17.AddRegexTokenDef("OCT", "0[0-7]*", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st17.AddRegexTokenDef("INT", "[0-9]+", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 1})
+	st17.AddTokenTerminal("OCT", &lexer.Position{Filename: "test", Offset: 50, Line: 5, Column: 9})
+	st17.AddTokenTerminal("INT", &lexer.Position{Filename: "test", Offset: 56, Line: 5, Column: 15})
+	st17.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("OCT"), grammar.Terminal("INT")}},
+		&lexer.Position{Filename: "test", Offset: 42, Line: 5, Column: 1},
+	)
+
+	st18 := NewSymbolTable()
+	st18.AddFragment("DIGIT", "[0-9]", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 11})
+	st18.AddFragment("DIGIT", "[0-7]", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 11})
+	st18.AddRegexTokenDef("INT", "{DIGIT}+", &lexer.Position{Filename: "test", Offset: 50, Line: 4, Column: 1})
+	st18.AddTokenTerminal("INT", &lexer.Position{Filename: "test", Offset: 78, Line: 6, Column: 9})
+	st18.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("INT")}},
+		&lexer.Position{Filename: "test", Offset: 70, Line: 6, Column: 1},
+	)
+
+	st19 := NewSymbolTable()
+	st19.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st19.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st19.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 50, Line: 4, Column: 9})
+	st19.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st19.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM"), grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st19.AddLabel("Num",
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 34, Line: 3, Column: 13},
+	)
+	st19.AddLabel("Num",
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM"), grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 58, Line: 4, Column: 17},
+	)
+
+	st20 := NewSymbolTable()
+	st20.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st20.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st20.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st20.AddLabel("Num",
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 34, Line: 3, Column: 13},
+	)
+	st20.AddLabel("Int",
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 54, Line: 4, Column: 13},
+	)
+
+	st21 := NewSymbolTable()
+	st21.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st21.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 11})
+	st21.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM"), grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st21.AddFields(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM"), grammar.Terminal("NUM")}},
+		[]string{"x", "x"},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+
+	st22 := NewSymbolTable()
+	st22.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st22.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 11})
+	st22.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st22.AddFields(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		[]string{"l"},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st22.AddFields(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		[]string{"r"},
+		&lexer.Position{Filename: "test", Offset: 50, Line: 4, Column: 1},
+	)
+
+	st23 := NewSymbolTable()
+	st23.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st23.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 40, Line: 5, Column: 8})
+	st23.AddProduction(
+		&grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 33, Line: 5, Column: 1},
+	)
+	st23.AddStart("expr", &lexer.Position{Filename: "test", Offset: 7, Line: 3, Column: 8})
+	st23.AddStart("stmt", &lexer.Position{Filename: "test", Offset: 12, Line: 3, Column: 13})
+	st23.AddStart("expr", &lexer.Position{Filename: "test", Offset: 24, Line: 4, Column: 8})
+
+	st24 := NewSymbolTable()
+	st24.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st24.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 23, Line: 4, Column: 8})
+	st24.AddProduction(
+		&grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 16, Line: 4, Column: 1},
+	)
+	st24.AddStart("expr", &lexer.Position{Filename: "test", Offset: 7, Line: 3, Column: 8})
+
+	st26 := NewSymbolTable()
+	st26.AddStringTerminal("error", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st26.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("error")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+
+	st27 := NewSymbolTable()
+	st27.AddStringTerminal(";", &lexer.Position{Filename: "test", Offset: 36, Line: 3, Column: 15})
+	st27.AddErrorToken(&lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st27.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("error"), grammar.Terminal(";")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+
+	st28 := NewSymbolTable()
+	st28.AddRegexTokenDef("RAW", `r"[^"]*"`, &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 1})
+	st28.AddExternal("RAW", &lexer.Position{Filename: "test", Offset: 30, Line: 4, Column: 11})
+	st28.AddExternal("COMMENT", &lexer.Position{Filename: "test", Offset: 34, Line: 4, Column: 15})
+	st28.AddExternal("COMMENT", &lexer.Position{Filename: "test", Offset: 52, Line: 5, Column: 11})
+	st28.AddTokenTerminal("RAW", &lexer.Position{Filename: "test", Offset: 70, Line: 7, Column: 9})
+	st28.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("RAW")}},
+		&lexer.Position{Filename: "test", Offset: 62, Line: 7, Column: 1},
+	)
+
+	st29 := NewSymbolTable()
+	st29.AddExternal("RAW", &lexer.Position{Filename: "test", Offset: 10, Line: 2, Column: 11})
+	st29.AddExternal("COMMENT", &lexer.Position{Filename: "test", Offset: 14, Line: 2, Column: 15})
+	st29.AddSkip("COMMENT", &lexer.Position{Filename: "test", Offset: 28, Line: 3, Column: 7})
+	st29.AddTokenTerminal("RAW", &lexer.Position{Filename: "test", Offset: 50, Line: 5, Column: 9})
+	st29.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("RAW")}},
+		&lexer.Position{Filename: "test", Offset: 42, Line: 5, Column: 1},
+	)
+
+	st25 := NewSymbolTable()
+	st25.AddRegexTokenDef("NUM", "[0-9]+", &lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1})
+	st25.AddTokenTerminal("NUM", &lexer.Position{Filename: "test", Offset: 30, Line: 3, Column: 9})
+	st25.AddProduction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Filename: "test", Offset: 22, Line: 3, Column: 1},
+	)
+	st25.AddAction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&SemanticAction{Code: " $$ = $1 ", Pos: &lexer.Position{Filename: "test", Offset: 34, Line: 3, Column: 13}},
+	)
+	st25.AddAction(
+		&grammar.Production{Head: "start", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&SemanticAction{Code: " $$ = nil ", Pos: &lexer.Position{Filename: "test", Offset: 56, Line: 4, Column: 13}},
+	)
+
 	tests := []struct {
 		name                 string
 		st                   *SymbolTable
@@ -135,11 +488,210 @@ func TestSymbolTable_Verify(t *testing.T) {
 				`missing production rule with the start symbol: start`,
 			},
 		},
+		{
+			name: "InvalidModes",
+			st:   st6,
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`mode name default is reserved: test:2:7`,
+				`undefined mode string in @push string action for terminal "OPEN": test:5:8`,
+			},
+		},
+		{
+			name: "MultipleDefinitionsInMode",
+			st:   st7,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple definitions for terminal "QUOT" in mode string:`,
+				`test:3:3`,
+				`test:4:3`,
+			},
+		},
+		{
+			name: "SkippedTerminalUsed",
+			st:   st9,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`skipped terminal "COMMENT" is used in production rules:`,
+				`test:5:9`,
+			},
+		},
+		{
+			name: "MultipleWhitespaces",
+			st:   st11,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple whitespace directives:`,
+				`test:2:1`,
+				`test:3:1`,
+			},
+		},
+		{
+			name: "InvalidIndent",
+			st:   st12,
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`multiple indent directives:`,
+				`test:2:1`,
+				`test:3:1`,
+				`terminal "NEWLINE" is synthesized by the indent directive and cannot be defined:`,
+				`test:4:1`,
+			},
+		},
 		{
 			name:                 "OK",
 			st:                   st5,
 			expectedErrorStrings: nil,
 		},
+		{
+			name:                 "OKWithModes",
+			st:                   st8,
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "OKWithSkips",
+			st:                   st10,
+			expectedErrorStrings: nil,
+		},
+		{
+			name: "InconsistentCases",
+			st:   st14,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`terminal "begin" is used both with and without the case-insensitive marker:`,
+				`test:2:9`,
+				`test:3:9`,
+			},
+		},
+		{
+			name:                 "OKWithIndent",
+			st:                   st13,
+			expectedErrorStrings: nil,
+		},
+		{
+			name: "MultiplePriorities",
+			st:   st16,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple priorities for terminal "OCT":`,
+				`test:5:11`,
+				`test:6:11`,
+			},
+		},
+		{
+			name: "MultipleFragments",
+			st:   st18,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple definitions for fragment DIGIT:`,
+				`test:2:11`,
+				`test:3:11`,
+			},
+		},
+		{
+			name: "LabelMultipleProductions",
+			st:   st19,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`label #Num names multiple productions:`,
+				`test:3:13: start → "NUM"`,
+				`test:4:17: start → "NUM" "NUM"`,
+			},
+		},
+		{
+			name: "MultipleLabels",
+			st:   st20,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple labels for production start → "NUM":`,
+				`test:4:13: #Int`,
+				`test:3:13: #Num`,
+			},
+		},
+		{
+			name: "MultipleSymbolsWithSameField",
+			st:   st21,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple symbols named x in production start → "NUM" "NUM": test:3:1`,
+			},
+		},
+		{
+			name: "ConflictingFields",
+			st:   st22,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`conflicting field names for production start → "NUM":`,
+				`test:3:1: l:"NUM"`,
+				`test:4:1: r:"NUM"`,
+			},
+		},
+		{
+			name: "InvalidStarts",
+			st:   st23,
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`missing production rule with the start symbol: stmt`,
+				`multiple start declarations for non-terminal expr:`,
+				`test:3:8`,
+				`test:4:8`,
+			},
+		},
+		{
+			name: "MultipleActions",
+			st:   st25,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`multiple actions for production start → "NUM":`,
+				`test:3:13`,
+				`test:4:13`,
+			},
+		},
+		{
+			name: "ErrorTokenReserved",
+			st:   st26,
+			expectedErrorStrings: []string{
+				`1 error occurred:`,
+				`terminal name "error" is reserved`,
+			},
+		},
+		{
+			name: "InvalidExternals",
+			st:   st28,
+			expectedErrorStrings: []string{
+				`2 errors occurred:`,
+				`external terminal "RAW" cannot be defined:`,
+				`test:2:1`,
+				`multiple external declarations for terminal "COMMENT":`,
+				`test:4:15`,
+				`test:5:11`,
+			},
+		},
+		{
+			name:                 "OKWithIgnoreCase",
+			st:                   st15,
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "OKWithPriorities",
+			st:                   st17,
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "OKWithStarts",
+			st:                   st24,
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "OKWithRecovery",
+			st:                   st27,
+			expectedErrorStrings: nil,
+		},
+		{
+			name:                 "OKWithExternals",
+			st:                   st29,
+			expectedErrorStrings: nil,
+		},
 	}
 
 	for _, tc := range tests {
@@ -212,6 +764,27 @@ func TestSymbolTable_Definitions(t *testing.T) {
 	st.AddTokenTerminal("NUM", &lexer.Position{})
 	st.AddTokenTerminal("ID", &lexer.Position{})
 
+	stModes := NewSymbolTable()
+	stModes.AddMode("string", &lexer.Position{})
+	stModes.AddMode("interp", &lexer.Position{})
+	stModes.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{}).Mode = "interp"
+	stModes.AddRegexTokenDef("CHARS", "[^\"]+", &lexer.Position{}).Mode = "string"
+	stModes.AddStringTokenDef("CLOSE", "\"", &lexer.Position{}).Mode = "string"
+	stModes.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{})
+	stModes.AddStringTokenDef("OPEN", "\"", &lexer.Position{})
+
+	stSkips := NewSymbolTable()
+	stSkips.AddRegexTokenDef("COMMENT", "//[^\\n]*", &lexer.Position{})
+	stSkips.AddRegexTokenDef("ID", "[a-z]+", &lexer.Position{})
+	stSkips.AddSkip("COMMENT", &lexer.Position{})
+
+	stPriorities := NewSymbolTable()
+	stPriorities.AddRegexTokenDef("OCT", "0[0-7]*", &lexer.Position{})
+	stPriorities.AddRegexTokenDef("INT", "[0-9]+", &lexer.Position{})
+	stPriorities.AddRegexTokenDef("HEX", "0x[0-9a-f]+", &lexer.Position{})
+	stPriorities.AddPriority("HEX", &lexer.Position{})
+	stPriorities.AddPriority("OCT", &lexer.Position{})
+
 	tests := []struct {
 		name                string
 		st                  *SymbolTable
@@ -227,6 +800,34 @@ func TestSymbolTable_Definitions(t *testing.T) {
 				{Terminal: "NUM", Kind: RegexDef, Value: "[0-9]+"},
 			},
 		},
+		{
+			name: "WithModes",
+			st:   stModes,
+			expectedDefinitions: []*TerminalDef{
+				{Terminal: "OPEN", Kind: StringDef, Value: "\""},
+				{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+"},
+				{Terminal: "CLOSE", Kind: StringDef, Value: "\"", Mode: "string"},
+				{Terminal: "CHARS", Kind: RegexDef, Value: "[^\"]+", Mode: "string"},
+				{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+", Mode: "interp"},
+			},
+		},
+		{
+			name: "WithSkips",
+			st:   stSkips,
+			expectedDefinitions: []*TerminalDef{
+				{Terminal: "ID", Kind: RegexDef, Value: "[a-z]+"},
+				{Terminal: "COMMENT", Kind: RegexDef, Value: "//[^\\n]*", Skip: true},
+			},
+		},
+		{
+			name: "WithPriorities",
+			st:   stPriorities,
+			expectedDefinitions: []*TerminalDef{
+				{Terminal: "HEX", Kind: RegexDef, Value: "0x[0-9a-f]+", Priority: 1},
+				{Terminal: "INT", Kind: RegexDef, Value: "[0-9]+"},
+				{Terminal: "OCT", Kind: RegexDef, Value: "0[0-7]*", Priority: 2},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -240,90 +841,396 @@ func TestSymbolTable_Definitions(t *testing.T) {
 					assert.True(t, defs[i].Terminal.Equal(expectedDef.Terminal))
 					assert.Equal(t, expectedDef.Kind, defs[i].Kind)
 					assert.Equal(t, expectedDef.Value, defs[i].Value)
+					assert.Equal(t, expectedDef.Mode, defs[i].Mode)
+					assert.Equal(t, expectedDef.Skip, defs[i].Skip)
+					assert.Equal(t, expectedDef.Priority, defs[i].Priority)
 				})
 			}
 		})
 	}
 }
 
-func TestSymbolTable_Terminals(t *testing.T) {
+func TestSymbolTable_Modes(t *testing.T) {
 	st := NewSymbolTable()
-	st.AddStringTerminal(";", &lexer.Position{})
-	st.AddTokenTerminal("ID", &lexer.Position{})
+	st.AddMode("string", &lexer.Position{Line: 2, Column: 7})
+	st.AddMode("interp", &lexer.Position{Line: 8, Column: 7})
+	st.AddMode("string", &lexer.Position{Line: 14, Column: 7})
 
 	tests := []struct {
-		name              string
-		st                *SymbolTable
-		expectedTerminals []grammar.Terminal
+		name          string
+		st            *SymbolTable
+		expectedModes []string
 	}{
 		{
-			name:              "OK",
-			st:                st,
-			expectedTerminals: []grammar.Terminal{";", "ID"},
+			name:          "Empty",
+			st:            NewSymbolTable(),
+			expectedModes: []string{},
+		},
+		{
+			name:          "OK",
+			st:            st,
+			expectedModes: []string{"string", "interp"},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			terms := tc.st.Terminals()
-
-			assert.Len(t, terms, len(tc.expectedTerminals))
-			for _, expectedTerminal := range tc.expectedTerminals {
-				assert.Contains(t, terms, expectedTerminal)
-			}
+			assert.Equal(t, tc.expectedModes, tc.st.Modes())
 		})
 	}
 }
 
-func TestSymbolTable_NonTerminals(t *testing.T) {
+func TestSymbolTable_Fragments(t *testing.T) {
 	st := NewSymbolTable()
-	st.AddNonTerminal("expr", &lexer.Position{})
-	st.AddNonTerminal("stmt", &lexer.Position{})
+	st.AddFragment("EXP", "[eE]{DIGITS}", &lexer.Position{Line: 3, Column: 11})
+	st.AddFragment("DIGITS", "[0-9]+", &lexer.Position{Line: 2, Column: 11})
 
 	tests := []struct {
-		name                 string
-		st                   *SymbolTable
-		expectedNonTerminals []grammar.NonTerminal
+		name              string
+		st                *SymbolTable
+		expectedFragments []*FragmentDef
 	}{
 		{
-			name:                 "OK",
-			st:                   st,
-			expectedNonTerminals: []grammar.NonTerminal{"expr", "stmt"},
+			name:              "Empty",
+			st:                NewSymbolTable(),
+			expectedFragments: []*FragmentDef{},
+		},
+		{
+			name: "OK",
+			st:   st,
+			expectedFragments: []*FragmentDef{
+				{Name: "DIGITS", Value: "[0-9]+", Pos: &lexer.Position{Line: 2, Column: 11}},
+				{Name: "EXP", Value: "[eE]{DIGITS}", Pos: &lexer.Position{Line: 3, Column: 11}},
+			},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			nonTerms := tc.st.NonTerminals()
-
-			assert.Len(t, nonTerms, len(tc.expectedNonTerminals))
-			for _, expectedNonTerminal := range tc.expectedNonTerminals {
-				assert.Contains(t, nonTerms, expectedNonTerminal)
-			}
+			assert.Equal(t, tc.expectedFragments, tc.st.Fragments())
 		})
 	}
 }
 
-func TestSymbolTable_Productions(t *testing.T) {
-	st := NewSymbolTable()
-	st.AddProduction(
-		&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}},
-		&lexer.Position{},
-	)
+func TestSymbolTable_Whitespaces(t *testing.T) {
+	st1 := NewSymbolTable()
+	st1.SetWhitespaces([]rune{}, &lexer.Position{Line: 2, Column: 1})
+
+	st2 := NewSymbolTable()
+	st2.SetWhitespaces([]rune{' ', '\t'}, &lexer.Position{Line: 2, Column: 1})
 
 	tests := []struct {
 		name                string
 		st                  *SymbolTable
-		expectedProductions []*grammar.Production
+		expectedWhitespaces []rune
 	}{
 		{
-			name: "OK",
-			st:   st,
-			expectedProductions: []*grammar.Production{
-				{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}},
-			},
+			name:                "NotDeclared",
+			st:                  NewSymbolTable(),
+			expectedWhitespaces: nil,
 		},
-	}
+		{
+			name:                "Empty",
+			st:                  st1,
+			expectedWhitespaces: []rune{},
+		},
+		{
+			name:                "OK",
+			st:                  st2,
+			expectedWhitespaces: []rune{' ', '\t'},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedWhitespaces, tc.st.Whitespaces())
+		})
+	}
+}
+
+func TestSymbolTable_Indent(t *testing.T) {
+	st := NewSymbolTable()
+	st.SetIndent(&lexer.Position{Line: 2, Column: 1})
+
+	tests := []struct {
+		name           string
+		st             *SymbolTable
+		expectedIndent bool
+	}{
+		{
+			name:           "NotDeclared",
+			st:             NewSymbolTable(),
+			expectedIndent: false,
+		},
+		{
+			name:           "OK",
+			st:             st,
+			expectedIndent: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIndent, tc.st.Indent())
+		})
+	}
+}
+
+func TestSymbolTable_Starts(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddStart("stmt", &lexer.Position{Line: 2, Column: 8})
+	st.AddStart("expr", &lexer.Position{Line: 2, Column: 13})
+	st.AddStart("stmt", &lexer.Position{Line: 3, Column: 8})
+
+	tests := []struct {
+		name           string
+		st             *SymbolTable
+		expectedStarts []grammar.NonTerminal
+	}{
+		{
+			name:           "NotDeclared",
+			st:             NewSymbolTable(),
+			expectedStarts: nil,
+		},
+		{
+			name:           "OK",
+			st:             st,
+			expectedStarts: []grammar.NonTerminal{"stmt", "expr"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedStarts, tc.st.Starts())
+		})
+	}
+}
+
+func TestSymbolTable_Externals(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddExternal("RAW", &lexer.Position{Line: 2, Column: 11})
+	st.AddExternal("COMMENT", &lexer.Position{Line: 2, Column: 15})
+	st.AddExternal("RAW", &lexer.Position{Line: 3, Column: 11})
+	st.AddSkip("COMMENT", &lexer.Position{Line: 4, Column: 7})
+
+	tests := []struct {
+		name              string
+		st                *SymbolTable
+		expectedExternals []*ExternalDef
+	}{
+		{
+			name:              "NotDeclared",
+			st:                NewSymbolTable(),
+			expectedExternals: nil,
+		},
+		{
+			name: "OK",
+			st:   st,
+			expectedExternals: []*ExternalDef{
+				{Terminal: "RAW", Skip: false, Pos: &lexer.Position{Line: 2, Column: 11}},
+				{Terminal: "COMMENT", Skip: true, Pos: &lexer.Position{Line: 2, Column: 15}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedExternals, tc.st.Externals())
+		})
+	}
+}
+
+func TestSymbolTable_TerminalDocs(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddRegexTokenDef("ID", `[a-z]+`, &lexer.Position{Line: 2, Column: 1})
+	st.AddRegexTokenDef("NUM", `[0-9]+`, &lexer.Position{Line: 3, Column: 1})
+	st.SetDoc(grammar.Terminal("ID"), "ID is an identifier.")
+
+	tests := []struct {
+		name         string
+		st           *SymbolTable
+		expectedDocs map[grammar.Terminal]string
+	}{
+		{
+			name:         "Empty",
+			st:           NewSymbolTable(),
+			expectedDocs: map[grammar.Terminal]string{},
+		},
+		{
+			name: "OK",
+			st:   st,
+			expectedDocs: map[grammar.Terminal]string{
+				"ID": "ID is an identifier.",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			docs := tc.st.TerminalDocs()
+
+			assert.Equal(t, len(tc.expectedDocs), docs.Size())
+			for a, expectedDoc := range tc.expectedDocs {
+				doc, ok := docs.Get(a)
+				assert.True(t, ok)
+				assert.Equal(t, expectedDoc, doc)
+			}
+		})
+	}
+}
+
+func TestSymbolTable_NonTerminalDocs(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddNonTerminal("expr", &lexer.Position{Line: 2, Column: 1})
+	st.AddNonTerminal("term", &lexer.Position{Line: 3, Column: 1})
+	st.SetDoc(grammar.NonTerminal("expr"), "expr is an expression.")
+
+	tests := []struct {
+		name         string
+		st           *SymbolTable
+		expectedDocs map[grammar.NonTerminal]string
+	}{
+		{
+			name:         "Empty",
+			st:           NewSymbolTable(),
+			expectedDocs: map[grammar.NonTerminal]string{},
+		},
+		{
+			name: "OK",
+			st:   st,
+			expectedDocs: map[grammar.NonTerminal]string{
+				"expr": "expr is an expression.",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			docs := tc.st.NonTerminalDocs()
+
+			assert.Equal(t, len(tc.expectedDocs), docs.Size())
+			for A, expectedDoc := range tc.expectedDocs {
+				doc, ok := docs.Get(A)
+				assert.True(t, ok)
+				assert.Equal(t, expectedDoc, doc)
+			}
+		})
+	}
+}
+
+func TestSymbolTable_Lists(t *testing.T) {
+	st := NewSymbolTable()
+	st.GetList(
+		Strings{{grammar.NonTerminal("arg")}},
+		Strings{{grammar.Terminal(",")}},
+	)
+	st.GetList(
+		Strings{{grammar.NonTerminal("stmt")}},
+		Strings{{grammar.Terminal(";")}},
+	)
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		expectedLists []grammar.NonTerminal
+	}{
+		{
+			name:          "NoList",
+			st:            NewSymbolTable(),
+			expectedLists: nil,
+		},
+		{
+			name:          "OK",
+			st:            st,
+			expectedLists: []grammar.NonTerminal{"gen_arg_list", "gen_stmt_list"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedLists, tc.st.Lists())
+		})
+	}
+}
+
+func TestSymbolTable_Terminals(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddStringTerminal(";", &lexer.Position{})
+	st.AddTokenTerminal("ID", &lexer.Position{})
+
+	tests := []struct {
+		name              string
+		st                *SymbolTable
+		expectedTerminals []grammar.Terminal
+	}{
+		{
+			name:              "OK",
+			st:                st,
+			expectedTerminals: []grammar.Terminal{";", "ID"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			terms := tc.st.Terminals()
+
+			assert.Len(t, terms, len(tc.expectedTerminals))
+			for _, expectedTerminal := range tc.expectedTerminals {
+				assert.Contains(t, terms, expectedTerminal)
+			}
+		})
+	}
+}
+
+func TestSymbolTable_NonTerminals(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddNonTerminal("expr", &lexer.Position{})
+	st.AddNonTerminal("stmt", &lexer.Position{})
+
+	tests := []struct {
+		name                 string
+		st                   *SymbolTable
+		expectedNonTerminals []grammar.NonTerminal
+	}{
+		{
+			name:                 "OK",
+			st:                   st,
+			expectedNonTerminals: []grammar.NonTerminal{"expr", "stmt"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nonTerms := tc.st.NonTerminals()
+
+			assert.Len(t, nonTerms, len(tc.expectedNonTerminals))
+			for _, expectedNonTerminal := range tc.expectedNonTerminals {
+				assert.Contains(t, nonTerms, expectedNonTerminal)
+			}
+		})
+	}
+}
+
+func TestSymbolTable_Productions(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddProduction(
+		&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}},
+		&lexer.Position{},
+	)
+
+	tests := []struct {
+		name                string
+		st                  *SymbolTable
+		expectedProductions []*grammar.Production
+	}{
+		{
+			name: "OK",
+			st:   st,
+			expectedProductions: []*grammar.Production{
+				{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}},
+			},
+		},
+	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -337,305 +1244,1144 @@ func TestSymbolTable_Productions(t *testing.T) {
 	}
 }
 
-func TestSymbolTable_AddPrecedence(t *testing.T) {
+func TestSymbolTable_Origins(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddOrigin("gen_decl_star", "{ decl }", &lexer.Position{Line: 2, Column: 8})
+
+	tests := []struct {
+		name            string
+		st              *SymbolTable
+		expectedOrigins map[grammar.NonTerminal]*Origin
+	}{
+		{
+			name: "OK",
+			st:   st,
+			expectedOrigins: map[grammar.NonTerminal]*Origin{
+				"gen_decl_star": {Text: "{ decl }", Pos: &lexer.Position{Line: 2, Column: 8}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			origins := tc.st.Origins()
+
+			assert.Equal(t, len(tc.expectedOrigins), origins.Size())
+			for A, expectedOrigin := range tc.expectedOrigins {
+				o, ok := origins.Get(A)
+				assert.True(t, ok)
+				assert.Equal(t, expectedOrigin, o)
+			}
+		})
+	}
+}
+
+func TestSymbolTable_Labels(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddLabel("Add",
+		&grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+		&lexer.Position{Line: 2, Column: 23},
+	)
+	st.AddLabel("Num",
+		&grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&lexer.Position{Line: 3, Column: 23},
+	)
+
+	tests := []struct {
+		name           string
+		st             *SymbolTable
+		expectedLabels map[string]*grammar.Production
+	}{
+		{
+			name:           "Empty",
+			st:             NewSymbolTable(),
+			expectedLabels: map[string]*grammar.Production{},
+		},
+		{
+			name: "OK",
+			st:   st,
+			expectedLabels: map[string]*grammar.Production{
+				"Add": {Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+				"Num": {Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			labels := tc.st.Labels()
+
+			assert.Equal(t, len(tc.expectedLabels), labels.Size())
+			for expectedLabel, p := range tc.expectedLabels {
+				label, ok := labels.Get(p)
+				assert.True(t, ok)
+				assert.Equal(t, expectedLabel, label)
+			}
+		})
+	}
+}
+
+func TestSymbolTable_Fields(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddFields(
+		&grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+		[]string{"lhs", "", "rhs"},
+		&lexer.Position{Line: 2, Column: 1},
+	)
+
+	tests := []struct {
+		name           string
+		st             *SymbolTable
+		p              *grammar.Production
+		expectedFields []string
+	}{
+		{
+			name:           "Empty",
+			st:             NewSymbolTable(),
+			p:              &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			expectedFields: nil,
+		},
+		{
+			name:           "OK",
+			st:             st,
+			p:              &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+			expectedFields: []string{"lhs", "", "rhs"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, _ := tc.st.Fields().Get(tc.p)
+			assert.Equal(t, tc.expectedFields, fields)
+		})
+	}
+}
+
+func TestSymbolTable_Actions(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddAction(
+		&grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+		&SemanticAction{Code: " $$ = $1 ", Pos: &lexer.Position{Line: 2, Column: 13}},
+	)
+
+	tests := []struct {
+		name         string
+		st           *SymbolTable
+		p            *grammar.Production
+		expectedCode string
+	}{
+		{
+			name:         "Empty",
+			st:           NewSymbolTable(),
+			p:            &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			expectedCode: "",
+		},
+		{
+			name:         "OK",
+			st:           st,
+			p:            &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			expectedCode: " $$ = $1 ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var code string
+			if a, ok := tc.st.Actions().Get(tc.p); ok {
+				code = a.Code
+			}
+
+			assert.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
+func TestSymbolTable_CodeBlocks(t *testing.T) {
+	st := NewSymbolTable()
+	st.AddCodeBlock(&CodeBlock{Code: ` import "strconv" `, Pos: &lexer.Position{Line: 3, Column: 1}})
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		expectedCodes []string
+	}{
+		{
+			name:          "Empty",
+			st:            NewSymbolTable(),
+			expectedCodes: []string{},
+		},
+		{
+			name:          "OK",
+			st:            st,
+			expectedCodes: []string{` import "strconv" `},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			codes := []string{}
+			for _, block := range tc.st.CodeBlocks() {
+				codes = append(codes, block.Code)
+			}
+
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
+
+func TestSymbolTable_AddPrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		st   *SymbolTable
+		p    *lr.PrecedenceLevel
+	}{
+		{
+			name: "OK",
+			st:   NewSymbolTable(),
+			p: &lr.PrecedenceLevel{
+				Associativity: lr.LEFT,
+				Handles: lr.NewPrecedenceHandles(
+					lr.PrecedenceHandleForTerminal("*"),
+					lr.PrecedenceHandleForTerminal("/"),
+				),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddPrecedence(tc.p)
+
+			l := len(tc.st.precedences.list) - 1
+			assert.True(t, tc.st.precedences.list[l].Equal(tc.p))
+		})
+	}
+}
+
+func TestSymbolTable_AddStringTokenDef(t *testing.T) {
+	tests := []struct {
+		name  string
+		st    *SymbolTable
+		token grammar.Terminal
+		value string
+		pos   *lexer.Position
+	}{
+		{
+			name:  "OK",
+			st:    NewSymbolTable(),
+			token: "QUOT",
+			value: "\"",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   10,
+				Line:     2,
+				Column:   1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			added := tc.st.AddStringTokenDef(tc.token, tc.value, tc.pos)
+
+			e, ok := tc.st.terminals.table.Get(tc.token)
+			assert.True(t, ok)
+
+			l := len(e.definitions) - 1
+			def := e.definitions[l]
+
+			assert.Same(t, def, added)
+
+			assert.True(t, def.Terminal.Equal(tc.token))
+			assert.Equal(t, tc.value, e.definitions[0].Value)
+			assert.Equal(t, StringDef, e.definitions[0].Kind)
+			assert.True(t, def.Pos.Equal(*tc.pos))
+		})
+	}
+}
+
+func TestSymbolTable_AddRegexTokenDef(t *testing.T) {
+	tests := []struct {
+		name  string
+		st    *SymbolTable
+		token grammar.Terminal
+		regex string
+		pos   *lexer.Position
+	}{
+		{
+			name:  "OK",
+			st:    NewSymbolTable(),
+			token: "NUM",
+			regex: "[0-9]+",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   20,
+				Line:     3,
+				Column:   1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			added := tc.st.AddRegexTokenDef(tc.token, tc.regex, tc.pos)
+
+			e, ok := tc.st.terminals.table.Get(tc.token)
+			assert.True(t, ok)
+
+			l := len(e.definitions) - 1
+			def := e.definitions[l]
+
+			assert.Same(t, def, added)
+
+			assert.True(t, def.Terminal.Equal(tc.token))
+			assert.Equal(t, tc.regex, e.definitions[0].Value)
+			assert.Equal(t, RegexDef, e.definitions[0].Kind)
+			assert.True(t, def.Pos.Equal(*tc.pos))
+		})
+	}
+}
+
+func TestSymbolTable_AddStringTerminal(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name string
+		st   *SymbolTable
+		a    grammar.Terminal
+		pos  *lexer.Position
+	}{
+		{
+			name: "FirstOccurrence",
+			st:   st,
+			a:    ";",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   50,
+				Line:     5,
+				Column:   10,
+			},
+		},
+		{
+			name: "SecondOccurrence",
+			st:   st,
+			a:    ";",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   64,
+				Line:     6,
+				Column:   12,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddStringTerminal(tc.a, tc.pos)
+
+			e, ok := tc.st.terminals.table.Get(tc.a)
+			assert.True(t, ok)
+			assert.NotZero(t, e.index)
+			assert.NotNil(t, e.definitions)
+			assert.Len(t, e.definitions, 1)
+			assert.True(t, e.definitions[0].Terminal.Equal(tc.a))
+			assert.Equal(t, string(tc.a), e.definitions[0].Value)
+			assert.Equal(t, StringDef, e.definitions[0].Kind)
+			assert.Nil(t, e.definitions[0].Pos)
+			assert.Contains(t, e.occurrences, tc.pos)
+		})
+	}
+}
+
+func TestSymbolTable_AddIgnoreCaseStringTerminal(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name string
+		st   *SymbolTable
+		a    grammar.Terminal
+		pos  *lexer.Position
+	}{
+		{
+			name: "FirstOccurrence",
+			st:   st,
+			a:    "begin",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   50,
+				Line:     5,
+				Column:   10,
+			},
+		},
+		{
+			name: "SecondOccurrence",
+			st:   st,
+			a:    "begin",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   64,
+				Line:     6,
+				Column:   12,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddIgnoreCaseStringTerminal(tc.a, tc.pos)
+
+			e, ok := tc.st.terminals.table.Get(tc.a)
+			assert.True(t, ok)
+			assert.Len(t, e.definitions, 1)
+			assert.Equal(t, string(tc.a), e.definitions[0].Value)
+			assert.Equal(t, StringDef, e.definitions[0].Kind)
+			assert.Contains(t, e.occurrences, tc.pos)
+			assert.Contains(t, e.ignoreCases, tc.pos)
+		})
+	}
+}
+
+func TestSymbolTable_AddTokenTerminal(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name string
+		st   *SymbolTable
+		a    grammar.Terminal
+		pos  *lexer.Position
+	}{
+		{
+			name: "FirstOccurrence",
+			st:   st,
+			a:    "ID",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   50,
+				Line:     5,
+				Column:   8,
+			},
+		},
+		{
+			name: "SecondOccurrence",
+			st:   st,
+			a:    "ID",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   64,
+				Line:     6,
+				Column:   16,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddTokenTerminal(tc.a, tc.pos)
+
+			e, ok := tc.st.terminals.table.Get(tc.a)
+			assert.True(t, ok)
+			assert.NotZero(t, e.index)
+			assert.NotNil(t, e.definitions)
+			assert.Len(t, e.definitions, 0)
+			assert.Contains(t, e.occurrences, tc.pos)
+		})
+	}
+}
+
+func TestSymbolTable_AddErrorToken(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		pos           *lexer.Position
+		expectedCount int
+	}{
+		{
+			name:          "First",
+			st:            st,
+			pos:           &lexer.Position{Line: 3, Column: 9},
+			expectedCount: 1,
+		},
+		{
+			name:          "Second",
+			st:            st,
+			pos:           &lexer.Position{Line: 4, Column: 9},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddErrorToken(tc.pos)
+
+			e, ok := tc.st.terminals.table.Get(ErrorToken)
+			assert.True(t, ok)
+			assert.Len(t, e.definitions, 0)
+			assert.Len(t, e.occurrences, tc.expectedCount)
+			assert.Len(t, tc.st.recovery.occurrences, tc.expectedCount)
+		})
+	}
+}
+
+func TestSymbolTable_AddNonTerminal(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name string
+		st   *SymbolTable
+		A    grammar.NonTerminal
+		pos  *lexer.Position
+	}{
+		{
+			name: "FirstOccurrence",
+			st:   st,
+			A:    "expr",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   40,
+				Line:     4,
+				Column:   1,
+			},
+		},
+		{
+			name: "SecondOccurrence",
+			st:   st,
+			A:    "expr",
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   49,
+				Line:     4,
+				Column:   10,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddNonTerminal(tc.A, tc.pos)
+
+			e, ok := tc.st.nonTerminals.table.Get(tc.A)
+			assert.True(t, ok)
+			assert.NotZero(t, e.index)
+			assert.Contains(t, e.occurrences, tc.pos)
+		})
+	}
+}
+
+func TestSymbolTable_AddProduction(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name string
+		st   *SymbolTable
+		p    *grammar.Production
+		pos  *lexer.Position
+	}{
+		{
+			name: "FirstOccurrence",
+			st:   st,
+			p: &grammar.Production{
+				Head: "E",
+				Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")},
+			},
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   40,
+				Line:     4,
+				Column:   1,
+			},
+		},
+		{
+			name: "SecondOccurrence",
+			st:   st,
+			p: &grammar.Production{
+				Head: "E",
+				Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")},
+			},
+			pos: &lexer.Position{
+				Filename: "test",
+				Offset:   80,
+				Line:     8,
+				Column:   1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddProduction(tc.p, tc.pos)
+
+			e, ok := tc.st.productions.table.Get(tc.p)
+			assert.True(t, ok)
+			assert.NotZero(t, e.index)
+			assert.Contains(t, e.occurrences, tc.pos)
+		})
+	}
+}
+
+func TestSymbolTable_AddOrigin(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name           string
+		st             *SymbolTable
+		A              grammar.NonTerminal
+		text           string
+		pos            *lexer.Position
+		expectedOrigin *Origin
+	}{
+		{
+			name:           "New",
+			st:             st,
+			A:              "gen_decl_star",
+			text:           "{ decl }",
+			pos:            &lexer.Position{Line: 2, Column: 8},
+			expectedOrigin: &Origin{Text: "{ decl }", Pos: &lexer.Position{Line: 2, Column: 8}},
+		},
+		{
+			name:           "Existent",
+			st:             st,
+			A:              "gen_decl_star",
+			text:           "{ decl }",
+			pos:            &lexer.Position{Line: 4, Column: 12},
+			expectedOrigin: &Origin{Text: "{ decl }", Pos: &lexer.Position{Line: 2, Column: 8}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddOrigin(tc.A, tc.text, tc.pos)
+
+			o, ok := tc.st.origins.table.Get(tc.A)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedOrigin, o)
+		})
+	}
+}
+
+func TestSymbolTable_AddMode(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		mode          string
+		pos           *lexer.Position
+		expectedIndex int
+		expectedCount int
+	}{
+		{
+			name:          "New",
+			st:            st,
+			mode:          "string",
+			pos:           &lexer.Position{Line: 2, Column: 7},
+			expectedIndex: 1,
+			expectedCount: 1,
+		},
+		{
+			name:          "Existent",
+			st:            st,
+			mode:          "string",
+			pos:           &lexer.Position{Line: 8, Column: 7},
+			expectedIndex: 1,
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddMode(tc.mode, tc.pos)
+
+			e, ok := tc.st.modes.table.Get(tc.mode)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedIndex, e.index)
+			assert.Len(t, e.occurrences, tc.expectedCount)
+		})
+	}
+}
+
+func TestSymbolTable_AddFragment(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		fragment      string
+		regex         string
+		pos           *lexer.Position
+		expectedCount int
+	}{
+		{
+			name:          "New",
+			st:            st,
+			fragment:      "DIGITS",
+			regex:         "[0-9]+",
+			pos:           &lexer.Position{Line: 2, Column: 11},
+			expectedCount: 1,
+		},
+		{
+			name:          "Existent",
+			st:            st,
+			fragment:      "DIGITS",
+			regex:         "[0-7]+",
+			pos:           &lexer.Position{Line: 3, Column: 11},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			def := tc.st.AddFragment(tc.fragment, tc.regex, tc.pos)
+
+			assert.Equal(t, &FragmentDef{Name: tc.fragment, Value: tc.regex, Pos: tc.pos}, def)
+
+			e, ok := tc.st.fragments.table.Get(tc.fragment)
+			assert.True(t, ok)
+			assert.Len(t, e.definitions, tc.expectedCount)
+			assert.Same(t, def, e.definitions[len(e.definitions)-1])
+		})
+	}
+}
+
+func TestSymbolTable_AddLabel(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		label         string
+		p             *grammar.Production
+		pos           *lexer.Position
+		expectedCount int
+	}{
+		{
+			name:          "New",
+			st:            st,
+			label:         "Num",
+			p:             &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			pos:           &lexer.Position{Line: 2, Column: 15},
+			expectedCount: 1,
+		},
+		{
+			name:          "Existent",
+			st:            st,
+			label:         "Num",
+			p:             &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			pos:           &lexer.Position{Line: 5, Column: 15},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddLabel(tc.label, tc.p, tc.pos)
+
+			e, ok := tc.st.labels.table.Get(tc.label)
+			assert.True(t, ok)
+			assert.Len(t, e.productions, tc.expectedCount)
+			assert.Len(t, e.occurrences, tc.expectedCount)
+			assert.Same(t, tc.pos, e.occurrences[len(e.occurrences)-1])
+		})
+	}
+}
+
+func TestSymbolTable_AddFields(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		p             *grammar.Production
+		fields        []string
+		pos           *lexer.Position
+		expectedCount int
+	}{
+		{
+			name:          "New",
+			st:            st,
+			p:             &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			fields:        []string{"value"},
+			pos:           &lexer.Position{Line: 2, Column: 1},
+			expectedCount: 1,
+		},
+		{
+			name:          "Existent",
+			st:            st,
+			p:             &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			fields:        []string{"value"},
+			pos:           &lexer.Position{Line: 5, Column: 1},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddFields(tc.p, tc.fields, tc.pos)
+
+			e, ok := tc.st.fields.table.Get(tc.p)
+			assert.True(t, ok)
+			assert.Len(t, e.fields, tc.expectedCount)
+			assert.Len(t, e.occurrences, tc.expectedCount)
+			assert.Equal(t, tc.fields, e.fields[len(e.fields)-1])
+		})
+	}
+}
+
+func TestSymbolTable_AddAction(t *testing.T) {
+	st := NewSymbolTable()
+
+	tests := []struct {
+		name          string
+		st            *SymbolTable
+		p             *grammar.Production
+		a             *SemanticAction
+		expectedCount int
+	}{
+		{
+			name:          "New",
+			st:            st,
+			p:             &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			a:             &SemanticAction{Code: " $$ = $1 ", Pos: &lexer.Position{Line: 2, Column: 13}},
+			expectedCount: 1,
+		},
+		{
+			name:          "Existent",
+			st:            st,
+			p:             &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("NUM")}},
+			a:             &SemanticAction{Code: " $$ = nil ", Pos: &lexer.Position{Line: 5, Column: 13}},
+			expectedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.st.AddAction(tc.p, tc.a)
+
+			e, ok := tc.st.actions.table.Get(tc.p)
+			assert.True(t, ok)
+			assert.Len(t, e.actions, tc.expectedCount)
+			assert.Equal(t, tc.a, e.actions[len(e.actions)-1])
+		})
+	}
+}
+
+func TestSymbolTable_AddCodeBlock(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		st := NewSymbolTable()
+		st.AddCodeBlock(&CodeBlock{Code: ` import "errors" `, Pos: &lexer.Position{Line: 3, Column: 1}})
+		st.AddCodeBlock(&CodeBlock{Code: ` import "strconv" `, Pos: &lexer.Position{Line: 4, Column: 1}})
+
+		assert.Len(t, st.codeBlocks.list, 2)
+		assert.Equal(t, ` import "strconv" `, st.codeBlocks.list[1].Code)
+	})
+}
+
+func TestSymbolTable_SetWhitespaces(t *testing.T) {
+	st := NewSymbolTable()
+
 	tests := []struct {
-		name string
-		st   *SymbolTable
-		p    *lr.PrecedenceLevel
+		name          string
+		st            *SymbolTable
+		chars         []rune
+		pos           *lexer.Position
+		expectedCount int
 	}{
 		{
-			name: "OK",
-			st:   NewSymbolTable(),
-			p: &lr.PrecedenceLevel{
-				Associativity: lr.LEFT,
-				Handles: lr.NewPrecedenceHandles(
-					lr.PrecedenceHandleForTerminal("*"),
-					lr.PrecedenceHandleForTerminal("/"),
-				),
-			},
+			name:          "First",
+			st:            st,
+			chars:         []rune{' '},
+			pos:           &lexer.Position{Line: 2, Column: 1},
+			expectedCount: 1,
+		},
+		{
+			name:          "Second",
+			st:            st,
+			chars:         []rune{' ', '\t'},
+			pos:           &lexer.Position{Line: 3, Column: 1},
+			expectedCount: 2,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddPrecedence(tc.p)
+			tc.st.SetWhitespaces(tc.chars, tc.pos)
 
-			l := len(tc.st.precedences.list) - 1
-			assert.True(t, tc.st.precedences.list[l].Equal(tc.p))
+			assert.Equal(t, tc.chars, tc.st.whitespaces.chars)
+			assert.Len(t, tc.st.whitespaces.occurrences, tc.expectedCount)
 		})
 	}
 }
 
-func TestSymbolTable_AddStringTokenDef(t *testing.T) {
+func TestSymbolTable_SetIndent(t *testing.T) {
+	st := NewSymbolTable()
+
 	tests := []struct {
-		name  string
-		st    *SymbolTable
-		token grammar.Terminal
-		value string
-		pos   *lexer.Position
+		name          string
+		st            *SymbolTable
+		pos           *lexer.Position
+		expectedCount int
 	}{
 		{
-			name:  "OK",
-			st:    NewSymbolTable(),
-			token: "QUOT",
-			value: "\"",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   10,
-				Line:     2,
-				Column:   1,
-			},
+			name:          "First",
+			st:            st,
+			pos:           &lexer.Position{Line: 2, Column: 1},
+			expectedCount: 1,
+		},
+		{
+			name:          "Second",
+			st:            st,
+			pos:           &lexer.Position{Line: 3, Column: 1},
+			expectedCount: 2,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddStringTokenDef(tc.token, tc.value, tc.pos)
+			tc.st.SetIndent(tc.pos)
 
-			e, ok := tc.st.terminals.table.Get(tc.token)
-			assert.True(t, ok)
-
-			l := len(e.definitions) - 1
-			def := e.definitions[l]
-
-			assert.True(t, def.Terminal.Equal(tc.token))
-			assert.Equal(t, tc.value, e.definitions[0].Value)
-			assert.Equal(t, StringDef, e.definitions[0].Kind)
-			assert.True(t, def.Pos.Equal(*tc.pos))
+			assert.Len(t, tc.st.indent.occurrences, tc.expectedCount)
 		})
 	}
 }
 
-func TestSymbolTable_AddRegexTokenDef(t *testing.T) {
+func TestSymbolTable_AddStart(t *testing.T) {
+	st := NewSymbolTable()
+
 	tests := []struct {
-		name  string
-		st    *SymbolTable
-		token grammar.Terminal
-		regex string
-		pos   *lexer.Position
+		name          string
+		st            *SymbolTable
+		A             grammar.NonTerminal
+		pos           *lexer.Position
+		expectedCount int
 	}{
 		{
-			name:  "OK",
-			st:    NewSymbolTable(),
-			token: "NUM",
-			regex: "[0-9]+",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   20,
-				Line:     3,
-				Column:   1,
-			},
+			name:          "First",
+			st:            st,
+			A:             "stmt",
+			pos:           &lexer.Position{Line: 2, Column: 8},
+			expectedCount: 1,
+		},
+		{
+			name:          "Second",
+			st:            st,
+			A:             "expr",
+			pos:           &lexer.Position{Line: 2, Column: 13},
+			expectedCount: 2,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddRegexTokenDef(tc.token, tc.regex, tc.pos)
-
-			e, ok := tc.st.terminals.table.Get(tc.token)
-			assert.True(t, ok)
-
-			l := len(e.definitions) - 1
-			def := e.definitions[l]
+			tc.st.AddStart(tc.A, tc.pos)
 
-			assert.True(t, def.Terminal.Equal(tc.token))
-			assert.Equal(t, tc.regex, e.definitions[0].Value)
-			assert.Equal(t, RegexDef, e.definitions[0].Kind)
-			assert.True(t, def.Pos.Equal(*tc.pos))
+			assert.Len(t, tc.st.starts.nonTerminals, tc.expectedCount)
+			assert.Len(t, tc.st.starts.occurrences, tc.expectedCount)
 		})
 	}
 }
 
-func TestSymbolTable_AddStringTerminal(t *testing.T) {
+func TestSymbolTable_AddSkip(t *testing.T) {
 	st := NewSymbolTable()
+	st.AddRegexTokenDef("COMMENT", "//[^\\n]*", &lexer.Position{Line: 2, Column: 1})
 
 	tests := []struct {
-		name string
-		st   *SymbolTable
-		a    grammar.Terminal
-		pos  *lexer.Position
+		name                string
+		st                  *SymbolTable
+		token               grammar.Terminal
+		pos                 *lexer.Position
+		expectedDefinitions int
+		expectedSkips       int
 	}{
 		{
-			name: "FirstOccurrence",
-			st:   st,
-			a:    ";",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   50,
-				Line:     5,
-				Column:   10,
-			},
+			name:                "New",
+			st:                  st,
+			token:               "SHEBANG",
+			pos:                 &lexer.Position{Line: 4, Column: 7},
+			expectedDefinitions: 0,
+			expectedSkips:       1,
 		},
 		{
-			name: "SecondOccurrence",
-			st:   st,
-			a:    ";",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   64,
-				Line:     6,
-				Column:   12,
-			},
+			name:                "Existent",
+			st:                  st,
+			token:               "COMMENT",
+			pos:                 &lexer.Position{Line: 4, Column: 15},
+			expectedDefinitions: 1,
+			expectedSkips:       1,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddStringTerminal(tc.a, tc.pos)
+			tc.st.AddSkip(tc.token, tc.pos)
 
-			e, ok := tc.st.terminals.table.Get(tc.a)
+			e, ok := tc.st.terminals.table.Get(tc.token)
 			assert.True(t, ok)
-			assert.NotZero(t, e.index)
-			assert.NotNil(t, e.definitions)
-			assert.Len(t, e.definitions, 1)
-			assert.True(t, e.definitions[0].Terminal.Equal(tc.a))
-			assert.Equal(t, string(tc.a), e.definitions[0].Value)
-			assert.Equal(t, StringDef, e.definitions[0].Kind)
-			assert.Nil(t, e.definitions[0].Pos)
-			assert.Contains(t, e.occurrences, tc.pos)
+			assert.Len(t, e.definitions, tc.expectedDefinitions)
+			assert.Len(t, e.occurrences, 0)
+			assert.Len(t, e.skips, tc.expectedSkips)
+			assert.Equal(t, tc.pos, e.skips[len(e.skips)-1])
 		})
 	}
 }
 
-func TestSymbolTable_AddTokenTerminal(t *testing.T) {
+func TestSymbolTable_AddExternal(t *testing.T) {
 	st := NewSymbolTable()
+	st.AddTokenTerminal("COMMENT", &lexer.Position{Line: 5, Column: 9})
 
 	tests := []struct {
-		name string
-		st   *SymbolTable
-		a    grammar.Terminal
-		pos  *lexer.Position
+		name                string
+		st                  *SymbolTable
+		token               grammar.Terminal
+		pos                 *lexer.Position
+		expectedCount       int
+		expectedOccurrences int
 	}{
 		{
-			name: "FirstOccurrence",
-			st:   st,
-			a:    "ID",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   50,
-				Line:     5,
-				Column:   8,
-			},
+			name:                "New",
+			st:                  st,
+			token:               "RAW",
+			pos:                 &lexer.Position{Line: 2, Column: 11},
+			expectedCount:       1,
+			expectedOccurrences: 0,
 		},
 		{
-			name: "SecondOccurrence",
-			st:   st,
-			a:    "ID",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   64,
-				Line:     6,
-				Column:   16,
-			},
+			name:                "Existent",
+			st:                  st,
+			token:               "COMMENT",
+			pos:                 &lexer.Position{Line: 2, Column: 15},
+			expectedCount:       2,
+			expectedOccurrences: 1,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddTokenTerminal(tc.a, tc.pos)
+			tc.st.AddExternal(tc.token, tc.pos)
 
-			e, ok := tc.st.terminals.table.Get(tc.a)
+			assert.Len(t, tc.st.externals.terminals, tc.expectedCount)
+			assert.Len(t, tc.st.externals.occurrences, tc.expectedCount)
+
+			e, ok := tc.st.terminals.table.Get(tc.token)
 			assert.True(t, ok)
-			assert.NotZero(t, e.index)
-			assert.NotNil(t, e.definitions)
 			assert.Len(t, e.definitions, 0)
-			assert.Contains(t, e.occurrences, tc.pos)
+			assert.Len(t, e.occurrences, tc.expectedOccurrences)
 		})
 	}
 }
 
-func TestSymbolTable_AddNonTerminal(t *testing.T) {
+func TestSymbolTable_AddPriority(t *testing.T) {
 	st := NewSymbolTable()
+	st.AddRegexTokenDef("INT", "[0-9]+", &lexer.Position{Line: 2, Column: 1})
 
 	tests := []struct {
-		name string
-		st   *SymbolTable
-		A    grammar.NonTerminal
-		pos  *lexer.Position
+		name                string
+		st                  *SymbolTable
+		token               grammar.Terminal
+		pos                 *lexer.Position
+		expectedDefinitions int
+		expectedPriority    int
+		expectedPriorities  int
 	}{
 		{
-			name: "FirstOccurrence",
-			st:   st,
-			A:    "expr",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   40,
-				Line:     4,
-				Column:   1,
-			},
+			name:                "New",
+			st:                  st,
+			token:               "OCT",
+			pos:                 &lexer.Position{Line: 4, Column: 11},
+			expectedDefinitions: 0,
+			expectedPriority:    1,
+			expectedPriorities:  1,
 		},
 		{
-			name: "SecondOccurrence",
-			st:   st,
-			A:    "expr",
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   49,
-				Line:     4,
-				Column:   10,
-			},
+			name:                "Existent",
+			st:                  st,
+			token:               "INT",
+			pos:                 &lexer.Position{Line: 4, Column: 15},
+			expectedDefinitions: 1,
+			expectedPriority:    2,
+			expectedPriorities:  1,
+		},
+		{
+			name:                "Repeated",
+			st:                  st,
+			token:               "OCT",
+			pos:                 &lexer.Position{Line: 5, Column: 11},
+			expectedDefinitions: 0,
+			expectedPriority:    1,
+			expectedPriorities:  2,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddNonTerminal(tc.A, tc.pos)
+			tc.st.AddPriority(tc.token, tc.pos)
 
-			e, ok := tc.st.nonTerminals.table.Get(tc.A)
+			e, ok := tc.st.terminals.table.Get(tc.token)
 			assert.True(t, ok)
-			assert.NotZero(t, e.index)
-			assert.Contains(t, e.occurrences, tc.pos)
+			assert.Len(t, e.definitions, tc.expectedDefinitions)
+			assert.Len(t, e.occurrences, 0)
+			assert.Equal(t, tc.expectedPriority, e.priority)
+			assert.Len(t, e.priorities, tc.expectedPriorities)
+			assert.Equal(t, tc.pos, e.priorities[len(e.priorities)-1])
 		})
 	}
 }
 
-func TestSymbolTable_AddProduction(t *testing.T) {
+func TestSymbolTable_SetDoc(t *testing.T) {
 	st := NewSymbolTable()
+	st.AddRegexTokenDef("ID", `[a-z]+`, &lexer.Position{Line: 2, Column: 1})
+	st.AddNonTerminal("expr", &lexer.Position{Line: 3, Column: 1})
 
 	tests := []struct {
-		name string
-		st   *SymbolTable
-		p    *grammar.Production
-		pos  *lexer.Position
+		name        string
+		st          *SymbolTable
+		X           grammar.Symbol
+		doc         string
+		expectedDoc string
 	}{
 		{
-			name: "FirstOccurrence",
-			st:   st,
-			p: &grammar.Production{
-				Head: "E",
-				Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")},
-			},
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   40,
-				Line:     4,
-				Column:   1,
-			},
+			name:        "Terminal",
+			st:          st,
+			X:           grammar.Terminal("ID"),
+			doc:         "ID is an identifier.",
+			expectedDoc: "ID is an identifier.",
 		},
 		{
-			name: "SecondOccurrence",
-			st:   st,
-			p: &grammar.Production{
-				Head: "E",
-				Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")},
-			},
-			pos: &lexer.Position{
-				Filename: "test",
-				Offset:   80,
-				Line:     8,
-				Column:   1,
-			},
+			name:        "Terminal_Replaced",
+			st:          st,
+			X:           grammar.Terminal("ID"),
+			doc:         "ID is a name.",
+			expectedDoc: "ID is a name.",
+		},
+		{
+			name:        "NonTerminal",
+			st:          st,
+			X:           grammar.NonTerminal("expr"),
+			doc:         "expr is an expression.",
+			expectedDoc: "expr is an expression.",
+		},
+		{
+			name:        "NonExistent",
+			st:          st,
+			X:           grammar.NonTerminal("term"),
+			doc:         "term is a term.",
+			expectedDoc: "",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.st.AddProduction(tc.p, tc.pos)
+			tc.st.SetDoc(tc.X, tc.doc)
+
+			var doc string
+			switch X := tc.X.(type) {
+			case grammar.Terminal:
+				doc, _ = tc.st.TerminalDocs().Get(X)
+			case grammar.NonTerminal:
+				doc, _ = tc.st.NonTerminalDocs().Get(X)
+			}
 
-			e, ok := tc.st.productions.table.Get(tc.p)
-			assert.True(t, ok)
-			assert.NotZero(t, e.index)
-			assert.Contains(t, e.occurrences, tc.pos)
+			assert.Equal(t, tc.expectedDoc, doc)
 		})
 	}
 }
@@ -707,3 +2453,58 @@ func TestSymbolTable_GetPlus(t *testing.T) {
 		})
 	}
 }
+
+func TestSymbolTable_GetList(t *testing.T) {
+	st := NewSymbolTable()
+	st.GetStar(Strings{
+		grammar.String[grammar.Symbol]{grammar.NonTerminal("arg")},
+	})
+
+	tests := []struct {
+		name                string
+		st                  *SymbolTable
+		s                   Strings
+		sep                 Strings
+		expectedNonTerminal grammar.NonTerminal
+	}{
+		{
+			name: "New",
+			st:   st,
+			s: Strings{
+				grammar.String[grammar.Symbol]{grammar.NonTerminal("arg")},
+			},
+			sep: Strings{
+				grammar.String[grammar.Symbol]{grammar.Terminal(",")},
+			},
+			expectedNonTerminal: "gen_arg_list",
+		},
+		{
+			name: "Existent",
+			st:   st,
+			s: Strings{
+				grammar.String[grammar.Symbol]{grammar.NonTerminal("arg")},
+			},
+			sep: Strings{
+				grammar.String[grammar.Symbol]{grammar.Terminal(",")},
+			},
+			expectedNonTerminal: "gen_arg_list",
+		},
+		{
+			name: "DifferentSeparator",
+			st:   st,
+			s: Strings{
+				grammar.String[grammar.Symbol]{grammar.NonTerminal("arg")},
+			},
+			sep: Strings{
+				grammar.String[grammar.Symbol]{grammar.Terminal(";")},
+			},
+			expectedNonTerminal: "gen1_list",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedNonTerminal, tc.st.GetList(tc.s, tc.sep))
+		})
+	}
+}
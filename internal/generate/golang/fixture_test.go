@@ -20,6 +20,45 @@ var grammars = []*grammar.CFG{
 		},
 		"E",
 	),
+	// G1
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", "+", "*", "(", ")", "id", "$stmt", "$E"},
+		[]grammar.NonTerminal{"gen_entry", "stmt", "E"},
+		[]*grammar.Production{
+			{Head: "gen_entry", Body: grammar.String[grammar.Symbol]{grammar.Terminal("$stmt"), grammar.NonTerminal("stmt")}},             // gen_entry → $stmt stmt
+			{Head: "gen_entry", Body: grammar.String[grammar.Symbol]{grammar.Terminal("$E"), grammar.NonTerminal("E")}},                   // gen_entry → $E E
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("="), grammar.NonTerminal("E")}}, // stmt → id = E
+			{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}},  // E → E + E
+			{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("*"), grammar.NonTerminal("E")}},  // E → E * E
+			{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("E"), grammar.Terminal(")")}},     // E → ( E )
+			{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},                                                     // E → id
+		},
+		"gen_entry",
+	),
+	// G2
+	grammar.NewCFG(
+		[]grammar.Terminal{"(", ")", ",", "id"},
+		[]grammar.NonTerminal{"call", "gen_arg_list", "arg"},
+		[]*grammar.Production{
+			{Head: "call", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("("), grammar.NonTerminal("gen_arg_list"), grammar.Terminal(")")}}, // call → id ( gen_arg_list )
+			{Head: "gen_arg_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("gen_arg_list"), grammar.Terminal(","), grammar.NonTerminal("arg")}},            // gen_arg_list → gen_arg_list , arg
+			{Head: "gen_arg_list", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("arg")}},                                                                        // gen_arg_list → arg
+			{Head: "arg", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},                                                                                     // arg → id
+		},
+		"call",
+	),
+	// G3
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "id", "error"},
+		[]grammar.NonTerminal{"stmts", "stmt"},
+		[]*grammar.Production{
+			{Head: "stmts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("stmts"), grammar.NonTerminal("stmt")}},                                   // stmts → stmts stmt
+			{Head: "stmts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("stmt")}},                                                                 // stmts → stmt
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("="), grammar.Terminal("id"), grammar.Terminal(";")}}, // stmt → id = id ;
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("error"), grammar.Terminal(";")}},                                             // stmt → error ;
+		},
+		"stmts",
+	),
 }
 
 var precedences = []lr.PrecedenceLevels{
@@ -45,3 +84,1259 @@ var definitions = []*spec.TerminalDef{
 	{Terminal: "ID", Kind: spec.RegexDef, Value: "[A-Za-z_][0-9A-Za-z_]*"},
 	{Terminal: "NUM", Kind: spec.RegexDef, Value: "[0-9]+"},
 }
+
+// lexerGrammar is a grammar whose tokens require the lexer to roll back to the last final state.
+// Reading "1.x" passes the final state of NUM and fails on the FLOAT, so ".x" is put back into the input.
+const lexerGrammar = `grammar test;
+
+ID    = /[a-z]+/
+NUM   = /[0-9]+/
+FLOAT = /[0-9]+\.[0-9]+/
+
+start = {item};
+item  = ID | NUM | FLOAT | ".";
+`
+
+// lexerTest is the test compiled with the package generated for lexerGrammar.
+const lexerTest = `package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLexer_Rollback(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		expectedTokens []string
+	}{
+		{
+			name:           "Float",
+			src:            "1.5",
+			expectedTokens: []string{"FLOAT 1.5 @0"},
+		},
+		{
+			name:           "Dot",
+			src:            "1.",
+			expectedTokens: []string{"NUM 1 @0", ". . @1"},
+		},
+		{
+			name:           "Rollback",
+			src:            "1.x",
+			expectedTokens: []string{"NUM 1 @0", ". . @1", "ID x @2"},
+		},
+		{
+			name:           "RollbackIntoFirstHalf",
+			src:            strings.Repeat(" ", bufferSize-2) + "1.x",
+			expectedTokens: []string{"NUM 1 @4094", ". . @4095", "ID x @4096"},
+		},
+		{
+			name:           "RollbackIntoSecondHalf",
+			src:            strings.Repeat(" ", 2*bufferSize-2) + "1.x",
+			expectedTokens: []string{"NUM 1 @8190", ". . @8191", "ID x @8192"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := NewLexer("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var tokens []string
+			for {
+				token, err := l.NextToken()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatal(err)
+				}
+
+				tokens = append(tokens, fmt.Sprintf("%s %s @%d", token.Terminal.Name(), token.Lexeme, token.Pos.Offset))
+			}
+
+			if fmt.Sprint(tokens) != fmt.Sprint(tc.expectedTokens) {
+				t.Errorf("expected %q, got %q", tc.expectedTokens, tokens)
+			}
+		})
+	}
+}
+
+func TestInput_Retract(t *testing.T) {
+	const src = "abcdefghij"
+
+	// With sub-buffers of size 2, retracting after every number of characters crosses the boundary between the halves.
+	for read := 1; read <= len(src); read++ {
+		for retract := 1; retract <= 2 && retract <= read; retract++ {
+			t.Run(fmt.Sprintf("Read%d_Retract%d", read, retract), func(t *testing.T) {
+				in, err := newInput("test", strings.NewReader(src), 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for range read {
+					if _, err := in.Next(); err != nil && err != io.EOF {
+						t.Fatal(err)
+					}
+				}
+
+				for range retract {
+					in.Retract()
+				}
+
+				var b strings.Builder
+				b.WriteString(src[:read-retract])
+
+				for {
+					r, err := in.Next()
+					if err == io.EOF {
+						break
+					} else if err != nil {
+						t.Fatal(err)
+					}
+
+					b.WriteRune(r)
+				}
+
+				if b.String() != src {
+					t.Errorf("expected %q, got %q", src, b.String())
+				}
+			})
+		}
+	}
+}
+`
+
+// labelsGrammar is a grammar with labeled alternatives.
+const labelsGrammar = `grammar test;
+
+NUM = /[0-9]+/
+
+@left "*" "/"
+@left "+" "-"
+
+start = expr;
+expr  = expr "+" expr #Add
+      | expr "-" expr #Sub
+      | expr "*" expr #Mul
+      | expr "/" expr #Div
+      | "(" expr ")"  #Paren
+      | NUM           #Num
+      ;
+`
+
+// labelsTest is the test compiled with the package generated for labelsGrammar.
+const labelsTest = `package test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInternalNode_Name(t *testing.T) {
+	p, err := NewParser("test", strings.NewReader("1 + (2) * 3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := p.ParseAndBuildAST()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	Traverse(root, VLR, func(n Node) bool {
+		if in, ok := n.(*InternalNode); ok {
+			names = append(names, in.Name())
+		}
+		return true
+	})
+
+	expectedNames := []string{"start", "Add", "Num", "Mul", "Paren", "Num", "Num"}
+	if strings.Join(names, " ") != strings.Join(expectedNames, " ") {
+		t.Errorf("expected %q, got %q", expectedNames, names)
+	}
+
+	add := root.(*InternalNode).Children[0].(*InternalNode)
+
+	if label := add.Label(); label != "Add" {
+		t.Errorf("expected label %q, got %q", "Add", label)
+	}
+
+	if s := add.String(); !strings.Contains(s, "#Add") {
+		t.Errorf("expected %q to include the label", s)
+	}
+
+	if label := Grammar.Productions[ProdAdd].Label; label != "Add" {
+		t.Errorf("expected ProdAdd to be labeled %q, got %q", "Add", label)
+	}
+}
+`
+
+// listsGrammar is a grammar with separated lists.
+const listsGrammar = `grammar test;
+
+ID = /[A-Za-z_][0-9A-Za-z_]*/
+
+start = {{stmt % ";"}};
+stmt  = "call" ID "(" {arg % ","} ")"
+      | "list" "[" {ID %% ","} "]"
+      ;
+arg   = ID;
+`
+
+// listsTest is the test compiled with the package generated for listsGrammar.
+const listsTest = `package test
+
+import (
+	"strings"
+	"testing"
+)
+
+func lexemes(n Node) string {
+	var s []string
+	Traverse(n, VLR, func(n Node) bool {
+		if leaf, ok := n.(*LeafNode); ok {
+			s = append(s, leaf.Lexeme)
+		}
+		return true
+	})
+
+	return strings.Join(s, " ")
+}
+
+func TestListNode(t *testing.T) {
+	p, err := NewParser("test", strings.NewReader("call f(a, b, c); list [x, y,]; call g()"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := p.ParseAndBuildAST()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stmts, ok := root.(*InternalNode).Children[0].(*ListNode)
+	if !ok {
+		t.Fatalf("expected a list node, got %s", root.(*InternalNode).Children[0])
+	}
+
+	if len(stmts.Children) != 5 {
+		t.Fatalf("expected 5 children, got %d", len(stmts.Children))
+	}
+
+	if s := lexemes(stmts); s != "call f ( a , b , c ) ; list [ x , y , ] ; call g ( )" {
+		t.Errorf("unexpected lexemes %q", s)
+	}
+
+	args, ok := stmts.Children[0].(*InternalNode).Children[3].(*ListNode)
+	if !ok {
+		t.Fatalf("expected a list node, got %s", stmts.Children[0].(*InternalNode).Children[3])
+	}
+
+	if len(args.Children) != 5 || lexemes(args) != "a , b , c" {
+		t.Errorf("expected a flat list of arguments, got %s", args)
+	}
+
+	if s := args.String(); !strings.HasSuffix(s, "→ arg \",\" arg \",\" arg <test:1:8>") {
+		t.Errorf("unexpected string %q", s)
+	}
+
+	ids, ok := stmts.Children[2].(*InternalNode).Children[2].(*ListNode)
+	if !ok {
+		t.Fatalf("expected a list node, got %s", stmts.Children[2].(*InternalNode).Children[2])
+	}
+
+	if len(ids.Children) != 3 || lexemes(ids) != "x , y" {
+		t.Errorf("expected a flat list of identifiers, got %s", ids)
+	}
+
+	if n := len(stmts.Children[4].(*InternalNode).Children); n != 4 {
+		t.Errorf("expected an empty list to have no node, got %d children", n)
+	}
+
+	// A list node never has a nested node for the same list.
+	Traverse(root, VLR, func(n Node) bool {
+		if list, ok := n.(*ListNode); ok {
+			for _, child := range list.Children {
+				if child.Symbol().Equal(list.Symbol()) {
+					t.Errorf("nested list node %s", child)
+				}
+			}
+		}
+		return true
+	})
+}
+`
+
+// actionsGrammar is a grammar with semantic actions.
+const actionsGrammar = `grammar test;
+
+{%
+import (
+  "errors"
+  "strconv"
+)
+%}
+
+NUM = /[0-9]+/
+
+@left "*" "/"
+@left "+" "-"
+
+start = expr;
+expr  = lhs:expr "+" rhs:expr {% $$ = $lhs.(int) + $rhs.(int) %}
+      | lhs:expr "-" rhs:expr {% $$ = $lhs.(int) - $rhs.(int) %}
+      | lhs:expr "*" rhs:expr {% $$ = $lhs.(int) * $rhs.(int) %}
+      | lhs:expr "/" rhs:expr {%
+          if $3.(int) == 0 {
+            return nil, errors.New("division by zero")
+          }
+          $$ = $1.(int) / $3.(int)
+        %}
+      | "(" expr ")" {% $$ = $2 %}
+      | NUM #Num     {% $$, err = strconv.Atoi($1) %}
+      ;
+`
+
+// actionsTest is the test compiled with the package generated for actionsGrammar.
+const actionsTest = `package test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestActions(t *testing.T) {
+	tests := []struct {
+		name          string
+		src           string
+		expectedVal   any
+		expectedError string
+	}{
+		{
+			name:        "Success",
+			src:         "1 + 2 * (3 - 1)",
+			expectedVal: 5,
+		},
+		{
+			name:          "DivisionByZero",
+			src:           "8 / (3 - 3)",
+			expectedError: "division by zero",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewParser("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			val, err := p.ParseAndEvaluate(Actions)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if val.Val != tc.expectedVal {
+					t.Errorf("expected %v, got %v", tc.expectedVal, val.Val)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestActions_NotLexeme(t *testing.T) {
+	if _, err := Actions(ProdNum, []*Value{{Val: 42}}); err == nil {
+		t.Error("expected an error for a terminal value that is not a lexeme")
+	}
+}
+
+func TestActions_LineDirectives(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "actions.go", nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || !strings.HasPrefix(fn.Name.Name, "_action") {
+			continue
+		}
+
+		n++
+		first, last := fn.Body.List[0], fn.Body.List[len(fn.Body.List)-1]
+
+		// The code of the action is mapped to the grammar.
+		if pos := fset.Position(first.Pos()); filepath.Base(pos.Filename) != "test.grammar" {
+			t.Errorf("%s: expected the code to be mapped to test.grammar, got %s", fn.Name.Name, pos)
+		}
+
+		// The code generated after the action is mapped back to actions.go.
+		pos, raw := fset.Position(last.Pos()), fset.PositionFor(last.Pos(), false)
+		if filepath.Base(pos.Filename) != "actions.go" || pos.Line != raw.Line {
+			t.Errorf("%s: expected the return statement to be mapped to %s, got %s", fn.Name.Name, raw, pos)
+		}
+	}
+
+	if n != 6 {
+		t.Errorf("expected 6 actions, got %d", n)
+	}
+}
+`
+
+// recoveryGrammar is a grammar with error productions for recovering from syntax errors.
+const recoveryGrammar = `grammar test;
+
+ID  = /[a-z]+/
+NUM = /[0-9]+/
+
+start = {stmt};
+stmt  = ID "=" expr ";"
+      | error ";"
+      ;
+expr  = NUM | ID;
+`
+
+// recoveryTest is the test compiled with the package generated for recoveryGrammar.
+const recoveryTest = `package test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecovery(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		expectedStmts  []string
+		expectedErrors []string
+	}{
+		{
+			name:          "Success",
+			src:           "a = 1; b = x;",
+			expectedStmts: []string{"ID", "ID"},
+		},
+		{
+			name:          "MultipleErrors",
+			src:           "a = 1; b = = 2; c = 3; d 4; e = 5;",
+			expectedStmts: []string{"ID", "error", "ID", "error", "ID"},
+			expectedErrors: []string{
+				"test:1:12: unexpected string \"=\"",
+				"test:1:26: unexpected string \"4\"",
+			},
+		},
+		{
+			name:          "DiscardTokens",
+			src:           "a = 1 2 3 4; b = 5;",
+			expectedStmts: []string{"error", "ID"},
+			expectedErrors: []string{
+				"test:1:7: unexpected string \"2\"",
+			},
+		},
+		{
+			name:          "SuppressErrors",
+			src:           "a = = ; b 1 ; c = 2;",
+			expectedStmts: []string{"error", "error", "ID"},
+			expectedErrors: []string{
+				"test:1:5: unexpected string \"=\"",
+			},
+		},
+		{
+			name:          "CannotRecover",
+			src:           "a = = 1",
+			expectedStmts: nil,
+			expectedErrors: []string{
+				"test:1:5: unexpected string \"=\"",
+				"cannot recover from syntax error",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewParser("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The first symbol of every statement tells whether the statement is recovered from a syntax error.
+			var stmts []string
+			_, err = p.ParseAndEvaluate(func(i int, rhs []*Value) (any, error) {
+				if prod := Grammar.Productions[i]; prod.Head.Name() == "stmt" {
+					stmts = append(stmts, prod.Body[0].Name())
+				}
+				return nil, nil
+			})
+
+			if len(stmts) != len(tc.expectedStmts) {
+				t.Fatalf("expected statements %v, got %v", tc.expectedStmts, stmts)
+			}
+
+			for i := range stmts {
+				if stmts[i] != tc.expectedStmts[i] {
+					t.Errorf("expected statements %v, got %v", tc.expectedStmts, stmts)
+				}
+			}
+
+			if len(tc.expectedErrors) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected errors %q, got nil", tc.expectedErrors)
+			}
+
+			errs := strings.Split(err.Error(), "\n")
+			if len(errs) != len(tc.expectedErrors) {
+				t.Fatalf("expected errors %q, got %q", tc.expectedErrors, errs)
+			}
+
+			for i := range errs {
+				if !strings.HasPrefix(errs[i], tc.expectedErrors[i]) {
+					t.Errorf("expected error %q, got %q", tc.expectedErrors[i], errs[i])
+				}
+			}
+		})
+	}
+}
+`
+
+// docsGrammar is a grammar with doc comments.
+const docsGrammar = `grammar test;
+
+// NUM is a number literal,
+// made of one or more decimal digits.
+NUM = /[0-9]+/
+
+@left "+"
+
+start = expr;
+
+/* expr is an arithmetic expression. */
+expr = expr "+" expr #Add
+     | "(" expr ")"
+     | NUM
+     ;
+`
+
+// docsTest is the test compiled with the package generated for docsGrammar.
+const docsTest = `package test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	var comments []*ast.CommentGroup
+
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, e.Name(), nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files = append(files, f)
+		comments = append(comments, f.Comments...)
+	}
+
+	p, err := doc.NewFromFiles(fset, files, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Typed constants are listed under their types, the same way go doc shows them.
+	values := p.Consts
+	for _, typ := range p.Types {
+		values = append(values, typ.Consts...)
+	}
+
+	// Render the constants with their comments the same way go doc does.
+	var b bytes.Buffer
+	prods := map[string]int{}
+
+	for _, v := range values {
+		if err := format.Node(&b, fset, &printer.CommentedNode{Node: v.Decl, Comments: comments}); err != nil {
+			t.Fatal(err)
+		}
+		b.WriteString("\n")
+
+		for _, spec := range v.Decl.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if name := vs.Names[0].Name; strings.HasPrefix(name, "Prod") {
+				lit, ok := vs.Values[0].(*ast.BasicLit)
+				if !ok {
+					t.Fatalf("expected %s to be an integer literal", name)
+				}
+
+				prods[name], _ = strconv.Atoi(lit.Value)
+			}
+		}
+	}
+
+	// Whitespaces are normalized, so the test does not depend on the alignment of the constants.
+	rendered := strings.Join(strings.Fields(b.String()), " ")
+
+	for _, expected := range []string{
+		"// NUM is a number literal, // made of one or more decimal digits. TermNUM Terminal = \"NUM\"",
+		"NonTermStart NonTerminal = \"start\"",
+		"// expr is an arithmetic expression. NonTermExpr NonTerminal = \"expr\"",
+		"// expr is an arithmetic expression. ProdAdd = ",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("expected the rendered documentation to contain %q, got:\n%s", expected, b.String())
+		}
+	}
+
+	// Every production rule has a constant of its own.
+	if len(prods) != len(Grammar.Productions) {
+		t.Fatalf("expected %d production constants, got %v", len(Grammar.Productions), prods)
+	}
+
+	seen := map[int]bool{}
+	for name, i := range prods {
+		if i < 0 || i >= len(Grammar.Productions) || seen[i] {
+			t.Errorf("%s: unexpected production index %d", name, i)
+			continue
+		}
+		seen[i] = true
+
+		prod := Grammar.Productions[i]
+		switch {
+		case name == "ProdAdd":
+			if prod.Label != "Add" {
+				t.Errorf("%s: expected a production rule labeled Add, got %s", name, prod)
+			}
+		case name == "ProdStart":
+			if prod.Head != "start" {
+				t.Errorf("%s: expected a production rule for start, got %s", name, prod)
+			}
+		case strings.HasPrefix(name, "ProdExpr"):
+			if prod.Head != "expr" || prod.Label != "" {
+				t.Errorf("%s: expected an unlabeled production rule for expr, got %s", name, prod)
+			}
+		default:
+			t.Errorf("unexpected production constant %s", name)
+		}
+	}
+
+	if ProdAdd != prods["ProdAdd"] || TermNUM != "NUM" || NonTermExpr != "expr" {
+		t.Error("expected the constants to be usable from the package")
+	}
+}
+`
+
+// indentGrammar is a grammar with indentation-sensitive lexing.
+const indentGrammar = `grammar test;
+
+ID = /[a-z]+/
+
+@indent
+
+start = {stmt};
+stmt  = ID NEWLINE | ID NEWLINE INDENT {{stmt}} DEDENT;
+`
+
+// indentTest is the test compiled with the package generated for indentGrammar.
+const indentTest = `package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLexer_Indent(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		expectedTokens []string
+		expectedError  string
+	}{
+		{
+			name: "Blocks",
+			src:  "a\nb\n  c\n  d\n    e\nf\n",
+			expectedTokens: []string{
+				"ID a 1:1", "NEWLINE 1:2",
+				"ID b 2:1", "NEWLINE 2:2",
+				"INDENT 3:3", "ID c 3:3", "NEWLINE 3:4",
+				"ID d 4:3", "NEWLINE 4:4",
+				"INDENT 5:5", "ID e 5:5", "NEWLINE 5:6",
+				"DEDENT 6:1", "DEDENT 6:1", "ID f 6:1", "NEWLINE 6:2",
+			},
+		},
+		{
+			name: "OpenBlocksAtEOF",
+			src:  "a\n  b\n    c",
+			expectedTokens: []string{
+				"ID a 1:1", "NEWLINE 1:2",
+				"INDENT 2:3", "ID b 2:3", "NEWLINE 2:4",
+				"INDENT 3:5", "ID c 3:5", "NEWLINE 3:6",
+				"DEDENT 3:6", "DEDENT 3:6",
+			},
+		},
+		{
+			name:           "Empty",
+			src:            "\n\n",
+			expectedTokens: nil,
+		},
+		{
+			name: "InconsistentDedent",
+			src:  "a\n    b\n  c\n",
+			expectedTokens: []string{
+				"ID a 1:1", "NEWLINE 1:2",
+				"INDENT 2:5", "ID b 2:5",
+			},
+			expectedError: "lexical error at test:3:3: inconsistent dedent",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := NewLexer("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var tokens []string
+			for {
+				token, err := l.NextToken()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					if tc.expectedError == "" {
+						t.Fatal(err)
+					} else if err.Error() != tc.expectedError {
+						t.Errorf("expected error %q, got %q", tc.expectedError, err)
+					}
+					break
+				}
+
+				s := token.Terminal.Name()
+				if token.Lexeme != "" {
+					s += " " + token.Lexeme
+				}
+
+				tokens = append(tokens, fmt.Sprintf("%s %d:%d", s, token.Pos.Line, token.Pos.Column))
+			}
+
+			if fmt.Sprint(tokens) != fmt.Sprint(tc.expectedTokens) {
+				t.Errorf("expected %q, got %q", tc.expectedTokens, tokens)
+			}
+		})
+	}
+}
+
+func TestParser_Indent(t *testing.T) {
+	p, err := NewParser("test", strings.NewReader("a\n  b\n    c\nd\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := p.ParseAndBuildAST()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stmts int
+	Traverse(root, VLR, func(n Node) bool {
+		if in, ok := n.(*InternalNode); ok && in.NonTerminal == "stmt" {
+			stmts++
+		}
+		return true
+	})
+
+	if stmts != 4 {
+		t.Errorf("expected 4 statements, got %d", stmts)
+	}
+}
+`
+
+// externalGrammar is a grammar with an external terminal.
+const externalGrammar = `grammar test;
+
+ID = /[a-z]+/
+
+@external COMMENT
+
+start = {stmt};
+stmt  = ID "=" expr ";" | COMMENT;
+expr  = expr "/" ID | expr "*" ID | ID;
+`
+
+// externalTest is the test compiled with the package generated for externalGrammar.
+const externalTest = `package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// The input of the lexer is passed to external scanners as is.
+var _ ExternalInput = (*input)(nil)
+
+// scanComment is an external scanner for nested comments, e.g., /* a /* b */ c */.
+// It declines anything else, including an unterminated comment.
+func scanComment(in ExternalInput) bool {
+	next := func() rune {
+		if r, err := in.Next(); err == nil {
+			return r
+		}
+		return 0
+	}
+
+	if next() != '/' || next() != '*' {
+		return false
+	}
+
+	for depth := 1; depth > 0; {
+		switch next() {
+		case 0:
+			return false
+		case '/':
+			switch next() {
+			case 0:
+				return false
+			case '*':
+				depth++
+			default:
+				in.Retract()
+			}
+		case '*':
+			switch next() {
+			case 0:
+				return false
+			case '/':
+				depth--
+			default:
+				in.Retract()
+			}
+		}
+	}
+
+	return true
+}
+
+func TestLexer_RegisterExternal(t *testing.T) {
+	l, err := NewLexer("test", strings.NewReader("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.RegisterExternal(Terminal("COMMENT"), scanComment); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	expectedError := "terminal \"ID\" is not external"
+	if err := l.RegisterExternal(Terminal("ID"), scanComment); err == nil || err.Error() != expectedError {
+		t.Errorf("expected error %q, got %v", expectedError, err)
+	}
+}
+
+func TestLexer_External(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		register       bool
+		expectedTokens []string
+	}{
+		{
+			name:     "Accept",
+			src:      "a /* b /* c */ d */ = e;",
+			register: true,
+			expectedTokens: []string{
+				"ID a 1:1",
+				"COMMENT /* b /* c */ d */ 1:3",
+				"= = 1:21",
+				"ID e 1:23",
+				"; ; 1:24",
+			},
+		},
+		{
+			name:     "DeclineAndFallBack",
+			src:      "a = b / c;",
+			register: true,
+			expectedTokens: []string{
+				"ID a 1:1",
+				"= = 1:3",
+				"ID b 1:5",
+				"/ / 1:7",
+				"ID c 1:9",
+				"; ; 1:10",
+			},
+		},
+		{
+			name:     "RewindAfterDecline",
+			src:      "a = b /* c",
+			register: true,
+			expectedTokens: []string{
+				"ID a 1:1",
+				"= = 1:3",
+				"ID b 1:5",
+				"/ / 1:7",
+				"* * 1:8",
+				"ID c 1:10",
+			},
+		},
+		{
+			name:     "NotRegistered",
+			src:      "a /* b */",
+			register: false,
+			expectedTokens: []string{
+				"ID a 1:1",
+				"/ / 1:3",
+				"* * 1:4",
+				"ID b 1:6",
+				"* * 1:8",
+				"/ / 1:9",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := NewLexer("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.register {
+				if err := l.RegisterExternal(Terminal("COMMENT"), scanComment); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var tokens []string
+			for {
+				token, err := l.NextToken()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatal(err)
+				}
+
+				tokens = append(tokens, fmt.Sprintf("%s %s %d:%d", token.Terminal.Name(), token.Lexeme, token.Pos.Line, token.Pos.Column))
+			}
+
+			if fmt.Sprint(tokens) != fmt.Sprint(tc.expectedTokens) {
+				t.Errorf("expected %q, got %q", tc.expectedTokens, tokens)
+			}
+		})
+	}
+}
+
+func TestParser_External(t *testing.T) {
+	p, err := NewParser("test", strings.NewReader("a = b / c;\n/* x /* y */ */\nd = e * f;\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.L.RegisterExternal(Terminal("COMMENT"), scanComment); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ParseAndBuildAST(); err != nil {
+		t.Fatal(err)
+	}
+}
+`
+
+// modesGrammar is a grammar with lexer modes for string interpolation and raw text.
+const modesGrammar = `grammar test;
+
+OPEN   = "\"" @push string
+RAW    = "r:" @switch raw
+ID     = /[a-z]+/
+RBRACE = "}" @pop
+
+@mode string {
+  CLOSE  = "\"" @pop
+  INTERP = "{" @push interp
+  CHARS  = /[^"{]+/
+}
+
+@mode interp {
+  ID     = /[a-z]+/
+  OPEN   = "\"" @push string
+  RBRACE = "}" @pop
+}
+
+@mode raw {
+  TEXT = /[^;]+/
+  SEMI = ";" @switch default
+}
+
+start = {value};
+value = OPEN {part} CLOSE | RAW TEXT SEMI | ID | RBRACE;
+part  = CHARS | INTERP value RBRACE;
+`
+
+// modesTest is the test compiled with the package generated for modesGrammar.
+const modesTest = `package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLexer_Modes(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		expectedTokens []string
+	}{
+		{
+			name: "PushAndPop",
+			src:  "\"x {a} y {\"z {b}\"} w\"",
+			expectedTokens: []string{
+				"OPEN[\"]@1",
+				"CHARS[x ]@2",
+				"INTERP[{]@4", "ID[a]@5", "RBRACE[}]@6",
+				"CHARS[ y ]@7",
+				"INTERP[{]@10",
+				"OPEN[\"]@11",
+				"CHARS[z ]@12",
+				"INTERP[{]@14", "ID[b]@15", "RBRACE[}]@16",
+				"CLOSE[\"]@17",
+				"RBRACE[}]@18",
+				"CHARS[ w]@19",
+				"CLOSE[\"]@21",
+			},
+		},
+		{
+			name: "WhitespaceByMode",
+			src:  "\"{ a } \"",
+			expectedTokens: []string{
+				"OPEN[\"]@1",
+				"INTERP[{]@2", "ID[a]@4", "RBRACE[}]@6",
+				"CHARS[ ]@7",
+				"CLOSE[\"]@8",
+			},
+		},
+		{
+			name: "Switch",
+			src:  "r:a b;} c",
+			expectedTokens: []string{
+				"RAW[r:]@1",
+				"TEXT[a b]@3",
+				"SEMI[;]@6",
+				"RBRACE[}]@7",
+				"ID[c]@9",
+			},
+		},
+		{
+			name: "PopOnEmptyStack",
+			src:  "} a \"b\"",
+			expectedTokens: []string{
+				"RBRACE[}]@1",
+				"ID[a]@3",
+				"OPEN[\"]@5",
+				"CHARS[b]@6",
+				"CLOSE[\"]@7",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := NewLexer("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var tokens []string
+			for {
+				token, err := l.NextToken()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatal(err)
+				}
+
+				tokens = append(tokens, fmt.Sprintf("%s[%s]@%d", token.Terminal.Name(), token.Lexeme, token.Pos.Column))
+			}
+
+			if fmt.Sprint(tokens) != fmt.Sprint(tc.expectedTokens) {
+				t.Errorf("expected %q, got %q", tc.expectedTokens, tokens)
+			}
+		})
+	}
+}
+
+func TestParser_Modes(t *testing.T) {
+	p, err := NewParser("test", strings.NewReader("\"x {a} y {\"z {b}\"} w\" r:raw text; } c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := p.ParseAndBuildAST()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values int
+	Traverse(root, VLR, func(n Node) bool {
+		if in, ok := n.(*InternalNode); ok && in.NonTerminal == "value" {
+			values++
+		}
+		return true
+	})
+
+	if values != 7 {
+		t.Errorf("expected 7 values, got %d", values)
+	}
+}
+`
+
+// startsGrammar is a grammar with more than one start symbol.
+const startsGrammar = `grammar test;
+
+ID  = /[A-Za-z_][0-9A-Za-z_]*/
+NUM = /[0-9]+/
+
+@left "*" "/"
+@left "+" "-"
+@start stmt expr
+
+stmt = ID "=" expr ";";
+expr = expr "+" expr
+     | expr "-" expr
+     | expr "*" expr
+     | expr "/" expr
+     | "(" expr ")"
+     | NUM
+     | ID
+     ;
+`
+
+// startsTest is the test compiled with the package generated for startsGrammar.
+const startsTest = `package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParser_Entries(t *testing.T) {
+	tests := []struct {
+		name                string
+		src                 string
+		parse               func(*Parser) (Node, error)
+		expectedNonTerminal NonTerminal
+		expectedError       string
+	}{
+		{
+			name:                "Default",
+			src:                 "x = 1 + 2;",
+			parse:               (*Parser).ParseAndBuildAST,
+			expectedNonTerminal: "stmt",
+		},
+		{
+			name:          "Default_Rejected",
+			src:           "1 + 2",
+			parse:         (*Parser).ParseAndBuildAST,
+			expectedError: "test:1:1: unexpected string \"1\"",
+		},
+		{
+			name:                "Stmt",
+			src:                 "x = 1 + 2;",
+			parse:               (*Parser).ParseStmtAndBuildAST,
+			expectedNonTerminal: "stmt",
+		},
+		{
+			name:                "Expr",
+			src:                 "1 + 2 * x",
+			parse:               (*Parser).ParseExprAndBuildAST,
+			expectedNonTerminal: "expr",
+		},
+		{
+			name:          "Expr_Rejected",
+			src:           "x = 1;",
+			parse:         (*Parser).ParseExprAndBuildAST,
+			expectedError: "test:1:3: unexpected string \"=\"",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewParser("test", strings.NewReader(tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			root, err := tc.parse(p)
+
+			if tc.expectedError != "" {
+				var perr *ParseError
+				if !errors.As(err, &perr) {
+					t.Fatalf("expected a parse error, got %v", err)
+				}
+
+				if !strings.HasPrefix(err.Error(), tc.expectedError) {
+					t.Errorf("expected error %q, got %q", tc.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			in, ok := root.(*InternalNode)
+			if !ok {
+				t.Fatalf("expected an internal node, got %v", root)
+			}
+
+			if in.NonTerminal != tc.expectedNonTerminal {
+				t.Errorf("expected the root to be %s, got %s", tc.expectedNonTerminal, in.NonTerminal)
+			}
+		})
+	}
+}
+`
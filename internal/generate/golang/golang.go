@@ -1,17 +1,24 @@
-// Package golang provides functionality for generating Go code that implements a full LALR parser based on an EBNF specification.
+// Package golang provides functionality for generating Go code that implements a full LR parser based on an EBNF specification.
 package golang
 
 import (
 	"bytes"
 	"embed"
 	"fmt"
+	goparser "go/parser"
+	"go/scanner"
+	"go/token"
 	"iter"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gardenbed/charm/ui"
 	"github.com/moorara/algo/automata"
@@ -27,6 +34,15 @@ import (
 //go:embed templates/*.tmpl
 var templates embed.FS
 
+const (
+	// generatedHeader is the first line of every Go file generated by emerge.
+	// It marks the file as generated, so it can be replaced or removed when regenerating the parser.
+	generatedHeader = "// Code generated by emerge. DO NOT EDIT."
+
+	// manifestFilename is the name of the file listing the files generated in the package directory by the last run.
+	manifestFilename = ".emerge.manifest"
+)
+
 var (
 	navajoWhite = ui.Fg256Color(223)
 	darkOrange  = ui.Fg256Color(166)
@@ -71,17 +87,25 @@ func isIDValid(name string) bool {
 type generator struct {
 	ui.UI
 	*Params
+
+	// files holds the rendered content of the generated files, keyed by their names in the package directory.
+	files map[string]*bytes.Buffer
 }
 
 // Params contains the configuration and data required for generating the parser code.
 type Params struct {
 	Debug bool
+	Force bool
+	Table spec.TableKind
 	Path  string
 	Spec  *spec.Spec
 }
 
-// Generate creates a self-contained, complete package that implements a full LALR parser for the input language,
+// Generate creates a self-contained, complete package that implements a full LR parser for the input language,
 // including all necessary data types, data structures, and a lexer (a.k.a. scanner).
+//
+// All files are rendered in memory first and written to the package directory only if every step succeeds.
+// If Force is set, an existing package directory is reused and only the files generated by emerge are replaced or removed.
 func Generate(u ui.UI, params *Params) error {
 	g := &generator{
 		UI:     u,
@@ -110,13 +134,26 @@ func Generate(u ui.UI, params *Params) error {
 		errs = errors.Append(errs, err)
 	}
 
-	return errs
+	if errs != nil {
+		return errs
+	}
+
+	return g.writeFiles()
 }
 
 // prepare validates the params and ensures the required directory structure exists before generating package code.
 func (g *generator) prepare() error {
 	g.Path = filepath.Clean(g.Path)
 
+	// Default to LALR(1) if no parsing table is specified.
+	if g.Table == "" {
+		g.Table = spec.LALR
+	}
+
+	if !g.Table.IsValid() {
+		return fmt.Errorf("invalid parsing table: %s", string(g.Table))
+	}
+
 	g.Debugf(navajoWhite, "     Checking output path %q ...", g.Path)
 
 	// Ensure the output path exists.
@@ -141,6 +178,13 @@ func (g *generator) prepare() error {
 
 	packageDir := filepath.Join(g.Path, g.Spec.Name)
 
+	// Reuse the existing package directory when regenerating the parser.
+	if g.Force {
+		if info, err := os.Stat(packageDir); err == nil && info.IsDir() {
+			return nil
+		}
+	}
+
 	// Create the package directory.
 	if err := os.Mkdir(packageDir, os.ModePerm); err != nil {
 		return fmt.Errorf("error on creating package directory: %s", err)
@@ -177,27 +221,70 @@ func (g *generator) generateCore() error {
 }
 
 type lexerData struct {
-	Debug          bool
-	Package        string
+	Debug      bool
+	Package    string
+	Modes      []*lexerModeData
+	ModeIndex  map[string]int
+	Whitespace bool
+	Skips      []grammar.Terminal
+	Indent     bool
+	Externals  []*spec.ExternalDef
+}
+
+// lexerModeData holds the data for generating the DFA of a single lexer mode.
+type lexerModeData struct {
+	Index          int
+	Name           string
 	Assocs         []spec.FinalTerminalAssociation
 	DFATransitions iter.Seq2[automata.State, iter.Seq2[[]disc.Range[automata.Symbol], automata.State]]
 }
 
 // generateLexer generates the lexer code based on the provided terminal (token) definitions for the input language.
+// A separate finite automaton is constructed for each lexer mode.
 func (g *generator) generateLexer() error {
 	g.Infof(hotPink, "     Generating the lexer ...")
 
-	g.Infof(hotPink, "       Constructing finite automaton ...")
-	dfa, assocs, err := g.Spec.BuildLexerDFA()
+	g.Infof(hotPink, "       Constructing finite automata ...")
+	modes, err := g.Spec.BuildLexerModes()
 	if err != nil {
 		return err
 	}
 
 	data := &lexerData{
-		Debug:          g.Debug,
-		Package:        g.Spec.Name,
-		Assocs:         assocs,
-		DFATransitions: dfa.Transitions(),
+		Debug:     g.Debug,
+		Package:   g.Spec.Name,
+		Modes:     make([]*lexerModeData, len(modes)),
+		ModeIndex: make(map[string]int, len(modes)),
+		Indent:    g.Spec.Indent,
+		Externals: g.Spec.Externals,
+	}
+
+	for i, mode := range modes {
+		data.ModeIndex[mode.Name] = i
+		data.Modes[i] = &lexerModeData{
+			Index:          i,
+			Name:           mode.Name,
+			Assocs:         mode.Assocs,
+			DFATransitions: mode.DFA.Transitions(),
+		}
+
+		// The same terminal can be defined and skipped in more than one mode.
+		for _, assoc := range mode.Assocs {
+			if assoc.Terminal == "WS" {
+				data.Whitespace = true
+			}
+
+			if assoc.Skip && !generic.Contains(data.Skips, grammar.EqTerminal, assoc.Terminal) {
+				data.Skips = append(data.Skips, assoc.Terminal)
+			}
+		}
+	}
+
+	// External terminals are recognized by scanner functions, but they are skipped the same way.
+	for _, def := range data.Externals {
+		if def.Skip && !generic.Contains(data.Skips, grammar.EqTerminal, def.Terminal) {
+			data.Skips = append(data.Skips, def.Terminal)
+		}
 	}
 
 	var errs error
@@ -207,35 +294,29 @@ func (g *generator) generateLexer() error {
 		}
 	}
 
-	// Generate the lexer graph if debugging is enabled.
-	if err := g.generateLexerGraph(dfa, assocs); err != nil {
-		errs = errors.Append(errs, err)
+	// Generate the lexer graphs if debugging is enabled.
+	for _, mode := range modes {
+		if err := g.generateLexerGraph(mode); err != nil {
+			errs = errors.Append(errs, err)
+		}
 	}
 
 	return errs
 }
 
-// generateLexerGraph generates a DOT format graph of the lexer's DFA if debugging is enabled.
-func (g *generator) generateLexerGraph(dfa *automata.DFA, assocs []spec.FinalTerminalAssociation) error {
+// generateLexerGraph generates a DOT format graph of the DFA of a lexer mode if debugging is enabled.
+// The graph for the default mode is named lexer.dot, and the graphs for other modes are named lexer.<mode>.dot.
+func (g *generator) generateLexerGraph(mode *spec.LexerMode) error {
 	if !g.Params.Debug {
 		return nil
 	}
 
-	g.Debugf(navajoWhite, "       Generating the lexer graph ...")
-
-	// Write the DOT code to the file.
-	filepath := filepath.Join(g.Path, g.Spec.Name, "lexer.dot")
-	f, err := os.OpenFile(filepath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
-	if err != nil {
-		return err
-	}
+	g.Debugf(navajoWhite, "       Generating the lexer graph for mode %s ...", mode.Name)
 
-	defer func() {
-		_ = f.Close()
-	}()
+	assocs := mode.Assocs
 
 	// Generate the DOT code for the DFA.
-	dot := dfa.DOT()
+	dot := mode.DFA.DOT()
 
 	// Modify the DOT code: colorize edges and their labels.
 	nodeRE := regexp.MustCompile(`  node \[shape=circle\];`)
@@ -260,7 +341,12 @@ func (g *generator) generateLexerGraph(dfa *automata.DFA, assocs []spec.FinalTer
 		return m
 	})
 
-	if _, err := f.WriteString(dot); err != nil {
+	filename := "lexer.dot"
+	if mode.Name != spec.DefaultMode {
+		filename = fmt.Sprintf("lexer.%s.dot", mode.Name)
+	}
+
+	if _, err := g.output(filename).WriteString(dot); err != nil {
 		return err
 	}
 
@@ -270,18 +356,78 @@ func (g *generator) generateLexerGraph(dfa *automata.DFA, assocs []spec.FinalTer
 type parserData struct {
 	Debug        bool
 	Package      string
+	Algorithm    string
 	Terminals    []grammar.Terminal
 	NonTerminals []grammar.NonTerminal
 	Productions  []*grammar.Production
+	Labels       []string
+	Fields       [][]string
+	Consts       *constsData
+	Entry        string
+	Entries      []*entryData
+	Lists        *listsData
+	Actions      []*actionData
+	Recovery     bool
 	ParsingTable *lr.ParsingTable
 }
 
+// constsData holds the exported constants generated for the terminals, non-terminals, and production rules.
+// Only the terminals and non-terminals with names that are identifiers have constants, while every production rule has one.
+type constsData struct {
+	Terminals    []*constData
+	NonTerminals []*constData
+	Productions  []*constData
+}
+
+// constData describes an exported constant along with the doc comment of the grammar symbol it stands for.
+// A production rule is documented by the doc comment of its head, and an undocumented symbol has an empty doc comment.
+type constData struct {
+	Name    string
+	Value   string
+	Doc     string
+	Comment string
+}
+
+// listsData holds the indices of the production rules for the non-terminals synthesized for separated lists.
+// A list is created by its base production rule for the first occurrence and extended by its recursive production rule.
+type listsData struct {
+	Bases      []int
+	Recursives []int
+}
+
+// actionData describes the function generated for the semantic action of a production rule.
+// Terminal values are passed as lexemes of type string, and non-terminal values are passed as values of type any.
+// Lexemes holds the positions (1-based) of the terminals in the body, whose values are checked to be strings before they are passed.
+type actionData struct {
+	Index     int
+	Params    string
+	Lexemes   []int
+	Args      string
+	Code      string
+	Directive string
+}
+
+// actionsData holds the data for generating the file with the semantic actions and the top-level code blocks.
+type actionsData struct {
+	Package    string
+	CodeBlocks []*spec.CodeBlock
+	Actions    []*actionData
+}
+
+// entryData describes an entry point for parsing declared by a @start directive.
+// The marker is empty if the grammar has a single start symbol and needs no marker for selecting it.
+type entryData struct {
+	Name        string
+	NonTerminal string
+	Marker      string
+}
+
 // generateParser generates the parser code based on the provided grammar and precedence levels for the input language.
 func (g *generator) generateParser() error {
 	g.Infof(orchid, "     Generating the parser ...")
 
-	g.Infof(orchid, "       Constructing LALR(1) Parsing Table ...")
-	T, err := g.Spec.LALRParsingTable()
+	g.Infof(orchid, "       Constructing %s Parsing Table ...", g.Table)
+	T, err := g.Spec.ParsingTable(g.Table)
 	if err != nil {
 		return err
 	}
@@ -290,22 +436,187 @@ func (g *generator) generateParser() error {
 	_, _, nonTerminals := g.Spec.Grammar.OrderNonTerminals()
 	productions := g.Spec.Grammar.OrderProductions()
 
+	// Labels and fields are kept by production index and left nil if no production rule has them.
+	var labels []string
+	var fields [][]string
+	for i, p := range productions {
+		if label := g.Spec.Label(p); label != "" {
+			if labels == nil {
+				labels = make([]string, len(productions))
+			}
+			labels[i] = label
+		}
+
+		if names := g.Spec.FieldNames(p); names != nil {
+			if fields == nil {
+				fields = make([][]string, len(productions))
+			}
+			fields[i] = names
+		}
+	}
+
+	// Exported constants carry the doc comments of the grammar symbols, so go doc and editors can show them.
+	// A name already taken by another constant is suffixed with the index of the symbol or the production rule.
+	consts := new(constsData)
+	used := map[string]bool{}
+
+	for i, a := range terminals {
+		if name := formatConstName("Term", string(a)); name != "" {
+			consts.Terminals = append(consts.Terminals, &constData{
+				Name:  uniqueName(used, name, i),
+				Value: strconv.Quote(string(a)),
+				Doc:   g.Spec.Doc(a),
+			})
+		}
+	}
+
+	for i, A := range nonTerminals {
+		// The non-terminals synthesized for EBNF constructs and entry points are not declared in the grammar.
+		if A == spec.Entry || g.Spec.Origin(A) != nil {
+			continue
+		}
+
+		if name := formatConstName("NonTerm", string(A)); name != "" {
+			consts.NonTerminals = append(consts.NonTerminals, &constData{
+				Name:  uniqueName(used, name, i),
+				Value: strconv.Quote(string(A)),
+				Doc:   g.Spec.Doc(A),
+			})
+		}
+	}
+
+	// A production rule is named after its label, or else after its head followed by its ordinal
+	// among the production rules of the head if there is more than one.
+	// The names of labeled production rules are reserved first, so they are never suffixed.
+	counts := map[grammar.NonTerminal]int{}
+	for i, p := range productions {
+		counts[p.Head]++
+
+		if labels != nil && labels[i] != "" {
+			used["Prod"+labels[i]] = true
+		}
+	}
+
+	ordinals := map[grammar.NonTerminal]int{}
+	for i, p := range productions {
+		ordinals[p.Head]++
+
+		var name string
+		if labels != nil && labels[i] != "" {
+			name = "Prod" + labels[i]
+		} else {
+			name = "Prod" + formatEntryName(p.Head)
+			if counts[p.Head] > 1 {
+				name += strconv.Itoa(ordinals[p.Head])
+			}
+			name = uniqueName(used, name, i)
+		}
+
+		consts.Productions = append(consts.Productions, &constData{
+			Name:    name,
+			Value:   strconv.Itoa(i),
+			Doc:     g.Spec.Doc(p.Head),
+			Comment: p.String(),
+		})
+	}
+
+	// Entry points are declared by @start directives and left nil if there is none.
+	var entry string
+	var entries []*entryData
+	for _, A := range g.Spec.Starts {
+		e := &entryData{
+			Name:        formatEntryName(A),
+			NonTerminal: string(A),
+		}
+
+		if len(g.Spec.Starts) > 1 {
+			entry = string(spec.Entry)
+			e.Marker = string(spec.EntryMarker(A))
+		}
+
+		entries = append(entries, e)
+	}
+
+	// The production rules of separated lists are kept by index and left nil if there is none.
+	var lists *listsData
+	for i, p := range productions {
+		if !slices.Contains(g.Spec.Lists, p.Head) {
+			continue
+		}
+
+		if lists == nil {
+			lists = new(listsData)
+		}
+
+		if len(p.Body) > 0 && p.Body[0].Equal(p.Head) {
+			lists.Recursives = append(lists.Recursives, i)
+		} else {
+			lists.Bases = append(lists.Bases, i)
+		}
+	}
+
+	// Semantic actions are kept in the order of production rules and left nil if there is none.
+	// The line directives in the actions are relative to the package directory, where the actions are generated.
+	dir, _ := filepath.Abs(filepath.Join(g.Path, g.Spec.Name))
+
+	var actions []*actionData
+	var errs error
+	for i, p := range productions {
+		if a := g.Spec.Action(p); a != nil {
+			action, err := newActionData(i, p, a, dir)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+
+			actions = append(actions, action)
+		}
+	}
+
+	if errs != nil {
+		return errs
+	}
+
 	data := &parserData{
 		Debug:        g.Debug,
 		Package:      g.Spec.Name,
+		Algorithm:    g.Table.String(),
 		Terminals:    terminals,
 		NonTerminals: nonTerminals,
 		Productions:  productions,
+		Labels:       labels,
+		Fields:       fields,
+		Consts:       consts,
+		Entry:        entry,
+		Entries:      entries,
+		Lists:        lists,
+		Actions:      actions,
+		Recovery:     slices.Contains(terminals, spec.ErrorToken),
 		ParsingTable: T,
 	}
 
-	var errs error
-	for _, filename := range []string{"ast.go.tmpl", "parser.lalr.go.tmpl"} {
+	// The same template is used for all algorithms; only the parsing table and the comments naming the algorithm differ.
+	for _, filename := range []string{"ast.go.tmpl", "parser.lr.go.tmpl"} {
 		if err := g.renderTemplate(filename, data); err != nil {
 			errs = errors.Append(errs, err)
 		}
 	}
 
+	// The semantic actions are generated in a separate file, so the code blocks can declare their own imports.
+	if actions != nil || len(g.Spec.CodeBlocks) > 0 {
+		actionsData := &actionsData{
+			Package:    g.Spec.Name,
+			CodeBlocks: g.Spec.CodeBlocks,
+			Actions:    actions,
+		}
+
+		if err := g.renderFile("actions.go.tmpl", "actions.go", actionsData); err != nil {
+			errs = errors.Append(errs, err)
+		} else {
+			resetLineDirectives(g.output("actions.go"), "actions.go")
+		}
+	}
+
 	// Generate the parsing table if debugging is enabled.
 	if err := g.generateParsingTable(T); err != nil {
 		errs = errors.Append(errs, err)
@@ -322,21 +633,11 @@ func (g *generator) generateParsingTable(T *lr.ParsingTable) error {
 
 	g.Debugf(navajoWhite, "       Generating the parsing table ...")
 
-	// Write the DOT code to the file.
-	filepath := filepath.Join(g.Path, g.Spec.Name, "parser.txt")
-	f, err := os.OpenFile(filepath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = f.Close()
-	}()
-
 	// Generate the content for the parsing table.
-	content := T.String()
+	// Synthesized non-terminals are represented by the EBNF constructs they are generated from.
+	content := g.Spec.DescribeText(T.String())
 
-	if _, err := f.WriteString(content); err != nil {
+	if _, err := g.output("parser.txt").WriteString(content); err != nil {
 		return err
 	}
 
@@ -370,17 +671,7 @@ func (g *generator) generateExample() error {
 		return err
 	}
 
-	filepath := filepath.Join(g.Path, g.Spec.Name, "example_test.go")
-	f, err := os.OpenFile(filepath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = f.Close()
-	}()
-
-	if err := tmpl.Execute(f, data); err != nil {
+	if err := tmpl.Execute(g.output("example_test.go"), data); err != nil {
 		return err
 	}
 
@@ -388,8 +679,14 @@ func (g *generator) generateExample() error {
 }
 
 // renderTemplate renders an embedded template by name and
-// writes the output to a file in the directory specified by Path and Package.
+// appends the output to the main Go file of the package.
 func (g *generator) renderTemplate(filename string, data any) error {
+	return g.renderFile(filename, fmt.Sprintf("%s.go", g.Spec.Name), data)
+}
+
+// renderFile renders an embedded template by name and
+// appends the output to the given file in the package directory.
+func (g *generator) renderFile(filename, outname string, data any) error {
 	g.Debugf(navajoWhite, "       Rendering %q ...", filename)
 
 	content, err := templates.ReadFile(filepath.Join("templates", filename))
@@ -408,6 +705,7 @@ func (g *generator) renderTemplate(filename string, data any) error {
 		"hasAnyGOTO":          hasAnyGOTO,
 		"lookupGOTO":          lookupGOTO,
 		"findProductionIndex": findProductionIndex,
+		"formatDoc":           formatDoc,
 	})
 
 	tmpl, err = tmpl.Parse(string(content))
@@ -415,23 +713,262 @@ func (g *generator) renderTemplate(filename string, data any) error {
 		return err
 	}
 
-	filepath := filepath.Join(g.Path, g.Spec.Name, fmt.Sprintf("%s.go", g.Spec.Name))
-	f, err := os.OpenFile(filepath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+	if err := tmpl.Execute(g.output(outname), data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// output returns the in-memory buffer for a generated file in the package directory.
+// The buffer is created on first use.
+func (g *generator) output(filename string) *bytes.Buffer {
+	if g.files == nil {
+		g.files = make(map[string]*bytes.Buffer)
+	}
+
+	b, ok := g.files[filename]
+	if !ok {
+		b = new(bytes.Buffer)
+		g.files[filename] = b
+	}
+
+	return b
+}
+
+// writeFiles writes all rendered files to the package directory.
+//
+// The files are first written to a staging directory inside the package directory and then moved into place.
+// Files generated by emerge in a previous run that are not generated anymore (e.g., lexer.dot without -debug) are removed.
+// If moving any file fails, the moves that already succeeded are rolled back, so the package directory is left unchanged.
+// Other files in the package directory are left untouched, and an error is returned if one of them would be replaced.
+// The generated files are listed in a manifest, so the next run knows which files it can replace or remove.
+func (g *generator) writeFiles() error {
+	packageDir := filepath.Join(g.Path, g.Spec.Name)
+
+	filenames := slices.Sorted(maps.Keys(g.files))
+	g.output(manifestFilename).WriteString(strings.Join(filenames, "\n") + "\n")
+	filenames = append(filenames, manifestFilename)
+
+	owned, err := g.ownedFiles(packageDir)
+	if err != nil {
+		return err
+	}
+
+	// A file that is not generated by emerge is never replaced.
+	for _, filename := range filenames {
+		info, err := os.Lstat(filepath.Join(packageDir, filename))
+		if err == nil && info.Mode().IsRegular() && !slices.Contains(owned, filename) {
+			return fmt.Errorf("cannot replace %q: the file is not generated by emerge", filename)
+		}
+	}
+
+	stageDir, err := os.MkdirTemp(packageDir, ".emerge-")
 	if err != nil {
 		return err
 	}
 
 	defer func() {
-		_ = f.Close()
+		_ = os.RemoveAll(stageDir)
 	}()
 
-	if err := tmpl.Execute(f, data); err != nil {
+	newDir := filepath.Join(stageDir, "new")
+	oldDir := filepath.Join(stageDir, "old")
+
+	for _, dir := range []string{newDir, oldDir} {
+		if err := os.Mkdir(dir, os.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	for _, filename := range filenames {
+		if err := os.WriteFile(filepath.Join(newDir, filename), g.files[filename].Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	var moves []fileMove
+
+	rollback := func(err error) error {
+		for _, m := range slices.Backward(moves) {
+			if rerr := os.Rename(m.to, m.from); rerr != nil {
+				err = errors.Append(err, rerr)
+			}
+		}
 		return err
 	}
 
+	// Move the files from the previous run out of the way, so stale files are removed with the staging directory.
+	for _, filename := range owned {
+		m := fileMove{
+			from: filepath.Join(packageDir, filename),
+			to:   filepath.Join(oldDir, filename),
+		}
+
+		if err := os.Rename(m.from, m.to); err != nil {
+			return rollback(err)
+		}
+
+		moves = append(moves, m)
+	}
+
+	for _, filename := range filenames {
+		g.Debugf(navajoWhite, "     Writing %q ...", filename)
+
+		m := fileMove{
+			from: filepath.Join(newDir, filename),
+			to:   filepath.Join(packageDir, filename),
+		}
+
+		if err := os.Rename(m.from, m.to); err != nil {
+			return rollback(err)
+		}
+
+		moves = append(moves, m)
+	}
+
 	return nil
 }
 
+// fileMove is a file rename that can be reverted.
+type fileMove struct {
+	from, to string
+}
+
+// ownedFiles returns the names of the files in the package directory that are generated by emerge.
+// A file is generated by emerge if it is listed in the manifest written by the previous run,
+// or if it is a Go file starting with the generated code header.
+func (g *generator) ownedFiles(packageDir string) ([]string, error) {
+	var filenames []string
+
+	isRegular := func(filename string) bool {
+		info, err := os.Lstat(filepath.Join(packageDir, filename))
+		return err == nil && info.Mode().IsRegular()
+	}
+
+	manifest, err := os.ReadFile(filepath.Join(packageDir, manifestFilename))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		filenames = append(filenames, manifestFilename)
+
+		// Only plain filenames are accepted, so a manifest never refers to a file outside the package directory.
+		for _, filename := range strings.Split(string(manifest), "\n") {
+			if filename != "" && filename == filepath.Base(filename) && filename != manifestFilename &&
+				isRegular(filename) && !slices.Contains(filenames, filename) {
+				filenames = append(filenames, filename)
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(packageDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		filename := e.Name()
+		if filepath.Ext(filename) != ".go" || !isRegular(filename) || slices.Contains(filenames, filename) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(packageDir, filename))
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.HasPrefix(content, []byte(generatedHeader+"\n")) {
+			filenames = append(filenames, filename)
+		}
+	}
+
+	return filenames, nil
+}
+
+// newActionData creates the data for generating the function of the semantic action of the i-th production rule.
+// The references in the code are replaced by the parameters of the function: _0 for $$ and _i for the i-th symbol.
+// A line directive maps the code back to its position in the grammar, so the Go compiler reports errors there.
+// Since the grammar file is referred to from the generated code, a relative filename is made relative to the package directory.
+//
+// The function is parsed once the references are replaced, and syntax errors are reported at the position of the action.
+func newActionData(i int, p *grammar.Production, a *spec.SemanticAction, dir string) (*actionData, error) {
+	params := make([]string, len(p.Body))
+	args := make([]string, len(p.Body))
+	var lexemes []int
+
+	for j, X := range p.Body {
+		switch X.(type) {
+		case grammar.Terminal:
+			params[j] = fmt.Sprintf("_%d string", j+1)
+			args[j] = fmt.Sprintf("_%d", j+1)
+			lexemes = append(lexemes, j+1)
+		default:
+			params[j] = fmt.Sprintf("_%d any", j+1)
+			args[j] = fmt.Sprintf("rhs[%d].Val", j)
+		}
+	}
+
+	var directive string
+	if a.Pos != nil && a.Pos.Filename != "" {
+		filename := a.Pos.Filename
+		if abs, err := filepath.Abs(filename); err == nil {
+			if rel, err := filepath.Rel(dir, abs); err == nil {
+				filename = filepath.ToSlash(rel)
+			}
+		}
+
+		// The code starts right after the opening {%.
+		directive = fmt.Sprintf("//line %s:%d:%d", filename, a.Pos.Line, a.Pos.Column+2)
+	}
+
+	data := &actionData{
+		Index:   i,
+		Params:  strings.Join(params, ", "),
+		Lexemes: lexemes,
+		Args:    strings.Join(args, ", "),
+		Code: a.Expand(func(i int) string {
+			return fmt.Sprintf("_%d", i)
+		}),
+		Directive: directive,
+	}
+
+	src := fmt.Sprintf("package p\nfunc _(%s) (_0 any, err error) {\n%s\n}\n", data.Params, data.Code)
+	if _, err := goparser.ParseFile(token.NewFileSet(), "", src, goparser.SkipObjectResolution); err != nil {
+		var errs error
+		if list, ok := err.(scanner.ErrorList); ok {
+			for _, e := range list {
+				errs = errors.Append(errs, fmt.Errorf("syntax error in semantic action for %s: %s: %s", p, e.Msg, a.Pos))
+			}
+		} else {
+			errs = errors.Append(errs, fmt.Errorf("syntax error in semantic action for %s: %s: %s", p, err, a.Pos))
+		}
+
+		return nil, errs
+	}
+
+	return data, nil
+}
+
+// resetLineDirectives completes the line directives that map the generated code after a semantic action back to the generated file.
+// The template ends the code of every semantic action with a line directive naming the file without a line number,
+// which is replaced by a line directive for the line following it.
+func resetLineDirectives(b *bytes.Buffer, filename string) {
+	marker := "//line " + filename
+	lines := strings.Split(b.String(), "\n")
+
+	for i, line := range lines {
+		if line == marker {
+			// Line numbers are 1-based, and the directive applies to the line following it.
+			lines[i] = fmt.Sprintf("%s:%d", marker, i+2)
+		}
+	}
+
+	b.Reset()
+	b.WriteString(strings.Join(lines, "\n"))
+}
+
 func formatStates(states automata.States) string {
 	var b bytes.Buffer
 
@@ -486,6 +1023,71 @@ func formatSymbolString(s grammar.String[grammar.Symbol]) string {
 	return b.String()
 }
 
+// formatDoc converts a doc comment into the lines of a Go comment, each one preceded by an indentation.
+func formatDoc(doc, indent string) string {
+	var b strings.Builder
+
+	for i, line := range strings.Split(doc, "\n") {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		b.WriteString(indent)
+		b.WriteString("//")
+		if line != "" {
+			b.WriteString(" ")
+			b.WriteString(line)
+		}
+	}
+
+	return b.String()
+}
+
+// formatConstName converts the name of a grammar symbol into the name of its exported constant with the given prefix.
+// For example, expr_list becomes NonTermExprList for the NonTerm prefix.
+// It returns an empty string if the name of the symbol is not an identifier, such as "+".
+func formatConstName(prefix, name string) string {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return ""
+		}
+	}
+
+	if s := formatEntryName(grammar.NonTerminal(name)); s != "" {
+		return prefix + s
+	}
+
+	return ""
+}
+
+// uniqueName returns a name that is not used yet and marks it as used.
+// The name is suffixed with the given index as many times as needed for making it unique.
+func uniqueName(used map[string]bool, name string, i int) string {
+	for used[name] {
+		name = fmt.Sprintf("%s_%d", name, i)
+	}
+
+	used[name] = true
+
+	return name
+}
+
+// formatEntryName converts the name of a start symbol into the camel case name of its entry point.
+// For example, expr_list becomes ExprList.
+func formatEntryName(A grammar.NonTerminal) string {
+	var b strings.Builder
+
+	for _, part := range strings.FieldsFunc(string(A), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		r, size := utf8.DecodeRuneInString(part)
+		b.WriteRune(unicode.ToUpper(r))
+		b.WriteString(part[size:])
+	}
+
+	return b.String()
+}
+
 func equalEndmarker(a grammar.Terminal) bool {
 	return a == grammar.Endmarker
 }
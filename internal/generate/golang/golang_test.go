@@ -1,17 +1,24 @@
 package golang
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gardenbed/charm/ui"
 	"github.com/moorara/algo/automata"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
 	"github.com/moorara/algo/parser/lr"
 	"github.com/moorara/algo/parser/lr/lookahead"
+	"github.com/moorara/algo/symboltable"
 
 	"github.com/gardenbed/emerge/internal/ebnf/parser/spec"
 )
@@ -80,6 +87,40 @@ func TestGenerate(t *testing.T) {
 				"foo.go",
 			},
 		},
+		{
+			name: "PackageDirExists",
+			params: &Params{
+				Debug: false,
+				Path:  tempDir,
+				Spec: &spec.Spec{
+					Name:        "foo",
+					Definitions: definitions,
+					Grammar:     grammars[0],
+					Precedences: precedences[0],
+				},
+			},
+			expectedErrorRegex: `error on creating package directory: mkdir .+/foo: file exists`,
+		},
+		{
+			name: "Success_Force",
+			params: &Params{
+				Debug: true,
+				Force: true,
+				Path:  tempDir,
+				Spec: &spec.Spec{
+					Name:        "foo",
+					Definitions: definitions,
+					Grammar:     grammars[0],
+					Precedences: precedences[0],
+				},
+			},
+			expectedFiles: []string{
+				"foo.go",
+				"example_test.go",
+				"lexer.dot",
+				"parser.txt",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -104,6 +145,106 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerate_Compile(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command is not available")
+	}
+
+	tempDir, err := os.MkdirTemp("", "emerge-test-")
+	assert.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tempDir))
+	}()
+
+	tests := []struct {
+		name     string
+		grammar  string
+		testFile string
+	}{
+		{
+			name:     "Lexer",
+			grammar:  lexerGrammar,
+			testFile: lexerTest,
+		},
+		{
+			name:     "Labels",
+			grammar:  labelsGrammar,
+			testFile: labelsTest,
+		},
+		{
+			name:     "Lists",
+			grammar:  listsGrammar,
+			testFile: listsTest,
+		},
+		{
+			name:     "Actions",
+			grammar:  actionsGrammar,
+			testFile: actionsTest,
+		},
+		{
+			name:     "Recovery",
+			grammar:  recoveryGrammar,
+			testFile: recoveryTest,
+		},
+		{
+			name:     "Docs",
+			grammar:  docsGrammar,
+			testFile: docsTest,
+		},
+		{
+			name:     "Indent",
+			grammar:  indentGrammar,
+			testFile: indentTest,
+		},
+		{
+			name:     "External",
+			grammar:  externalGrammar,
+			testFile: externalTest,
+		},
+		{
+			name:     "Modes",
+			grammar:  modesGrammar,
+			testFile: modesTest,
+		},
+		{
+			name:     "Starts",
+			grammar:  startsGrammar,
+			testFile: startsTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := spec.Parse("test.grammar", strings.NewReader(tc.grammar))
+			assert.NoError(t, err)
+
+			path := filepath.Join(tempDir, tc.name)
+			assert.NoError(t, os.Mkdir(path, os.ModePerm))
+
+			err = Generate(ui.NewNop(), &Params{
+				Path: path,
+				Spec: s,
+			})
+			assert.NoError(t, err)
+
+			// The generated package only depends on the standard library, so it can be built as a module of its own.
+			packageDir := filepath.Join(path, s.Name)
+			assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "go.mod"), []byte("module "+s.Name+"\n\ngo 1.24\n"), 0644))
+			assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "compile_test.go"), []byte(tc.testFile), 0644))
+
+			for _, args := range [][]string{{"build", "./..."}, {"test", "./..."}} {
+				cmd := exec.Command("go", args...)
+				cmd.Dir = packageDir
+				cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+
+				out, err := cmd.CombinedOutput()
+				assert.NoError(t, err, "go %s:\n%s", strings.Join(args, " "), out)
+			}
+		})
+	}
+}
+
 func TestGenerator_prepare(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "emerge-test-")
 	assert.NoError(t, err)
@@ -146,6 +287,21 @@ func TestGenerator_prepare(t *testing.T) {
 			},
 			expectedErrorRegex: `output path is not a directory: ".+/emerge-test-file-.+"`,
 		},
+		{
+			name: "InvalidTable",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.TableKind("glr"),
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "foo",
+					},
+				},
+			},
+			expectedErrorRegex: `invalid parsing table: glr`,
+		},
 		{
 			name: "InvalidPackage",
 			g: &generator{
@@ -174,6 +330,35 @@ func TestGenerator_prepare(t *testing.T) {
 			},
 			expectedErrorRegex: ``,
 		},
+		{
+			name: "PackageDirExists",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "foo",
+					},
+				},
+			},
+			expectedErrorRegex: `error on creating package directory: mkdir .+/foo: file exists`,
+		},
+		{
+			name: "Success_Force",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Force: true,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "foo",
+					},
+				},
+			},
+			expectedErrorRegex: ``,
+		},
 	}
 
 	for _, tc := range tests {
@@ -205,22 +390,6 @@ func TestGenerator_generateCore(t *testing.T) {
 		g                    *generator
 		expectedErrorRegexes []string
 	}{
-		{
-			name: "PackageDirNotExist",
-			g: &generator{
-				UI: ui.NewNop(),
-				Params: &Params{
-					Debug: false,
-					Path:  tempDir,
-					Spec: &spec.Spec{
-						Name: "foo",
-					},
-				},
-			},
-			expectedErrorRegexes: []string{
-				`open .+/foo/foo.go: no such file or directory`,
-			},
-		},
 		{
 			name: "Success",
 			g: &generator{
@@ -291,24 +460,104 @@ func TestGenerator_generateLexer(t *testing.T) {
 			},
 		},
 		{
-			name: "PackageDirNotExist",
+			name: "Success",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: false,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name:        "foo",
+						Name:        "",
 						Definitions: definitions,
 					},
 				},
 			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "InvalidModeDefinitions",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "foo",
+						Definitions: []*spec.TerminalDef{
+							{Terminal: "QUOTE", Kind: spec.StringDef, Value: `"`, Action: &spec.ModeAction{Kind: spec.PushMode, Mode: "str"}},
+							{Terminal: "CHARS", Kind: spec.RegexDef, Value: `[^"`, Mode: "str"},
+						},
+						Modes: []string{"str"},
+					},
+				},
+			},
 			expectedErrorRegexes: []string{
-				`open .+/foo/foo.go: no such file or directory`,
+				`1 error occurred:`,
+				`(?s)mode str: .*"CHARS": invalid regular expression: \[\^"`,
 			},
 		},
 		{
-			name: "Success",
+			name: "SuccessWithModes",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: true,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "",
+						Definitions: []*spec.TerminalDef{
+							{Terminal: "QUOTE", Kind: spec.StringDef, Value: `"`, Action: &spec.ModeAction{Kind: spec.PushMode, Mode: "str"}},
+							{Terminal: "ID", Kind: spec.RegexDef, Value: `[a-z]+`},
+							{Terminal: "END", Kind: spec.StringDef, Value: `"`, Mode: "str", Action: &spec.ModeAction{Kind: spec.PopMode}},
+							{Terminal: "CHARS", Kind: spec.RegexDef, Value: `[^"]+`, Mode: "str"},
+						},
+						Modes: []string{"str"},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "SuccessWithSkips",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "",
+						Definitions: []*spec.TerminalDef{
+							{Terminal: "ID", Kind: spec.RegexDef, Value: `[a-z]+`},
+							{Terminal: "COMMENT", Kind: spec.RegexDef, Value: `#[a-z]*`, Skip: true},
+						},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "SuccessWithExternals",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "",
+						Definitions: []*spec.TerminalDef{
+							{Terminal: "ID", Kind: spec.RegexDef, Value: `[a-z]+`},
+						},
+						Externals: []*spec.ExternalDef{
+							{Terminal: "RAW_STRING"},
+							{Terminal: "COMMENT", Skip: true},
+						},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "SuccessWithoutWhitespaces",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
@@ -316,7 +565,29 @@ func TestGenerator_generateLexer(t *testing.T) {
 					Path:  tempDir,
 					Spec: &spec.Spec{
 						Name:        "",
-						Definitions: definitions,
+						Whitespaces: []rune{},
+						Definitions: []*spec.TerminalDef{
+							{Terminal: "SPACE", Kind: spec.RegexDef, Value: `[ \t]+`},
+							{Terminal: "ID", Kind: spec.RegexDef, Value: `[a-z]+`},
+						},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "SuccessWithIndent",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:   "",
+						Indent: true,
+						Definitions: []*spec.TerminalDef{
+							{Terminal: "ID", Kind: spec.RegexDef, Value: `[a-z]+`},
+						},
 					},
 				},
 			},
@@ -350,11 +621,27 @@ func TestGenerator_generateLexerGraph(t *testing.T) {
 		assert.NoError(t, os.RemoveAll(tempDir))
 	}()
 
+	dfa := automata.NewDFABuilder().
+		SetStart(0).
+		SetFinal([]automata.State{1}).
+		AddTransition(0, '1', '9', 1).
+		AddTransition(1, '0', '9', 1).
+		Build()
+
+	assocs := []spec.FinalTerminalAssociation{
+		{
+			Final:    automata.NewStates(1),
+			Terminal: "NUM",
+			Kind:     spec.RegexDef,
+			Value:    "[1-9][0-9]+",
+		},
+	}
+
 	tests := []struct {
 		name               string
 		g                  *generator
-		dfa                *automata.DFA
-		assocs             []spec.FinalTerminalAssociation
+		mode               *spec.LexerMode
+		expectedFile       string
 		expectedErrorRegex string
 	}{
 		{
@@ -365,28 +652,32 @@ func TestGenerator_generateLexerGraph(t *testing.T) {
 					Debug: false,
 				},
 			},
-			dfa:                nil,
-			assocs:             nil,
+			mode:               nil,
+			expectedFile:       "",
 			expectedErrorRegex: ``,
 		},
 		{
-			name: "PackageDirNotExist",
+			name: "DefaultMode",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: true,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name: "foo",
+						Name: "",
 					},
 				},
 			},
-			dfa:                nil,
-			assocs:             nil,
-			expectedErrorRegex: `open .+/foo/lexer.dot: no such file or directory`,
+			mode: &spec.LexerMode{
+				Name:   spec.DefaultMode,
+				DFA:    dfa,
+				Assocs: assocs,
+			},
+			expectedFile:       "lexer.dot",
+			expectedErrorRegex: ``,
 		},
 		{
-			name: "Success",
+			name: "NonDefaultMode",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
@@ -397,30 +688,25 @@ func TestGenerator_generateLexerGraph(t *testing.T) {
 					},
 				},
 			},
-			dfa: automata.NewDFABuilder().
-				SetStart(0).
-				SetFinal([]automata.State{1}).
-				AddTransition(0, '1', '9', 1).
-				AddTransition(1, '0', '9', 1).
-				Build(),
-			assocs: []spec.FinalTerminalAssociation{
-				{
-					Final:    automata.NewStates(1),
-					Terminal: "NUM",
-					Kind:     spec.RegexDef,
-					Value:    "[1-9][0-9]+",
-				},
+			mode: &spec.LexerMode{
+				Name:   "number",
+				DFA:    dfa,
+				Assocs: assocs,
 			},
+			expectedFile:       "lexer.number.dot",
 			expectedErrorRegex: ``,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.g.generateLexerGraph(tc.dfa, tc.assocs)
+			err := tc.g.generateLexerGraph(tc.mode)
 
 			if tc.expectedErrorRegex == "" {
 				assert.NoError(t, err)
+				if tc.expectedFile != "" {
+					assert.Contains(t, tc.g.files, tc.expectedFile)
+				}
 			} else {
 				assert.Error(t, err)
 
@@ -439,9 +725,34 @@ func TestGenerator_generateParser(t *testing.T) {
 		assert.NoError(t, os.RemoveAll(tempDir))
 	}()
 
+	labels := symboltable.NewQuadraticHashTable[*grammar.Production, string](grammar.HashProduction, grammar.EqProduction, nil, symboltable.HashOpts{})
+	labels.Put(&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}}, "Add")
+	labels.Put(&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("*"), grammar.NonTerminal("E")}}, "Mul")
+
+	fields := symboltable.NewQuadraticHashTable[*grammar.Production, []string](grammar.HashProduction, grammar.EqProduction, nil, symboltable.HashOpts{})
+	fields.Put(&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}}, []string{"lhs", "", "rhs"})
+	fields.Put(&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("E"), grammar.Terminal(")")}}, []string{"", "inner", ""})
+
+	actions := symboltable.NewQuadraticHashTable[*grammar.Production, *spec.SemanticAction](grammar.HashProduction, grammar.EqProduction, nil, symboltable.HashOpts{})
+	actions.Put(&grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}}, &spec.SemanticAction{
+		Code: " $$, err = strconv.Atoi($1) ",
+		Pos:  &lexer.Position{Filename: "test.grammar", Line: 9, Column: 10},
+		Refs: []*spec.ActionRef{
+			{Offset: 1, Length: 2, Index: 0},
+			{Offset: 24, Length: 2, Index: 1},
+		},
+	})
+
+	terminalDocs := symboltable.NewQuadraticHashTable[grammar.Terminal, string](grammar.HashTerminal, grammar.EqTerminal, nil, symboltable.HashOpts{})
+	terminalDocs.Put("id", "id is an identifier.")
+
+	nonTerminalDocs := symboltable.NewQuadraticHashTable[grammar.NonTerminal, string](grammar.HashNonTerminal, grammar.EqNonTerminal, nil, symboltable.HashOpts{})
+	nonTerminalDocs.Put("E", "E is an expression,\n\nwhich adds or multiplies other expressions.")
+
 	tests := []struct {
 		name                 string
 		g                    *generator
+		expectedStates       int
 		expectedErrorRegexes []string
 	}{
 		{
@@ -450,6 +761,7 @@ func TestGenerator_generateParser(t *testing.T) {
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: false,
+					Table: spec.LALR,
 					Spec: &spec.Spec{
 						Name:        "foo",
 						Grammar:     grammars[0],
@@ -465,47 +777,224 @@ func TestGenerator_generateParser(t *testing.T) {
 			},
 		},
 		{
-			name: "PackageDirNotExist",
+			name: "Success",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: false,
+					Table: spec.LALR,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name:        "foo",
+						Name:        "",
 						Grammar:     grammars[0],
 						Precedences: precedences[0],
 					},
 				},
 			},
-			expectedErrorRegexes: []string{
-				`open .+/foo/foo.go: no such file or directory`,
+			expectedStates:       10,
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_Labels",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[0],
+						Precedences: precedences[0],
+						Labels:      labels,
+					},
+				},
 			},
+			expectedErrorRegexes: nil,
 		},
 		{
-			name: "Success",
+			name: "Success_Docs",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:            "",
+						Grammar:         grammars[0],
+						Precedences:     precedences[0],
+						Labels:          labels,
+						TerminalDocs:    terminalDocs,
+						NonTerminalDocs: nonTerminalDocs,
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_Fields",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: false,
+					Table: spec.LALR,
 					Path:  tempDir,
 					Spec: &spec.Spec{
 						Name:        "",
 						Grammar:     grammars[0],
 						Precedences: precedences[0],
+						Fields:      fields,
 					},
 				},
 			},
 			expectedErrorRegexes: nil,
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			err := tc.g.generateParser()
+		{
+			name: "Success_Start",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[0],
+						Precedences: precedences[0],
+						Starts:      []grammar.NonTerminal{"E"},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_Starts",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[1],
+						Precedences: precedences[0],
+						Starts:      []grammar.NonTerminal{"stmt", "E"},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_Lists",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[2],
+						Precedences: lr.PrecedenceLevels{},
+						Lists:       []grammar.NonTerminal{"gen_arg_list"},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_Actions",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[0],
+						Precedences: precedences[0],
+						Actions:     actions,
+						CodeBlocks: []*spec.CodeBlock{
+							{Code: ` import "strconv" `, Pos: &lexer.Position{Filename: "test.grammar", Line: 3, Column: 1}},
+						},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_Recovery",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LALR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[3],
+						Precedences: lr.PrecedenceLevels{},
+					},
+				},
+			},
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_SLR",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.SLR,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[0],
+						Precedences: precedences[0],
+					},
+				},
+			},
+			expectedStates:       10,
+			expectedErrorRegexes: nil,
+		},
+		{
+			name: "Success_LR1",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Table: spec.LR1,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name:        "",
+						Grammar:     grammars[0],
+						Precedences: precedences[0],
+					},
+				},
+			},
+			expectedStates:       18,
+			expectedErrorRegexes: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.g.generateParser()
 
 			if len(tc.expectedErrorRegexes) == 0 {
 				assert.NoError(t, err)
+
+				if tc.expectedStates > 0 {
+					code := tc.g.files[tc.g.Spec.Name+".go"].String()
+					assert.Contains(t, code, fmt.Sprintf("// Parser is the %s parser", tc.g.Table))
+
+					action := code[strings.Index(code, "func _ACTION("):strings.Index(code, "func _GOTO(")]
+					states := regexp.MustCompile(`(?m)^\tcase \d+:$`).FindAllString(action, -1)
+					assert.Len(t, states, tc.expectedStates)
+				}
 			} else {
 				assert.Error(t, err)
 
@@ -529,11 +1018,19 @@ func TestGenerator_generateParsingTable(t *testing.T) {
 	T, err := lookahead.BuildParsingTable(grammars[0], precedences[0])
 	assert.NoError(t, err)
 
+	origins := symboltable.NewQuadraticHashTable[grammar.NonTerminal, *spec.Origin](grammar.HashNonTerminal, grammar.EqNonTerminal, nil, symboltable.HashOpts{})
+	origins.Put("E", &spec.Origin{
+		Text: `{ expr }`,
+		Pos:  &lexer.Position{Filename: "test", Offset: 40, Line: 4, Column: 12},
+	})
+
 	tests := []struct {
 		name               string
 		g                  *generator
 		T                  *lr.ParsingTable
 		expectedErrorRegex string
+		expectedContents   []string
+		unexpectedContents []string
 	}{
 		{
 			name: "DebugFalse",
@@ -547,34 +1044,42 @@ func TestGenerator_generateParsingTable(t *testing.T) {
 			expectedErrorRegex: ``,
 		},
 		{
-			name: "PackageDirNotExist",
+			name: "Success",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: true,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name: "foo",
+						Name: "",
 					},
 				},
 			},
-			T:                  nil,
-			expectedErrorRegex: `open .+/foo/parser.txt: no such file or directory`,
+			T:                  T,
+			expectedErrorRegex: ``,
 		},
 		{
-			name: "Success",
+			name: "SynthesizedNonTerminals",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: true,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name: "",
+						Name:    "",
+						Origins: origins,
 					},
 				},
 			},
 			T:                  T,
 			expectedErrorRegex: ``,
+			expectedContents: []string{
+				`{ expr }@test:4:12 → { expr }@test:4:12 "+" { expr }@test:4:12`,
+			},
+			unexpectedContents: []string{
+				`E → E`,
+				`Synthesized Non-Terminals:`,
+			},
 		},
 	}
 
@@ -584,6 +1089,16 @@ func TestGenerator_generateParsingTable(t *testing.T) {
 
 			if tc.expectedErrorRegex == "" {
 				assert.NoError(t, err)
+
+				content := tc.g.output("parser.txt").String()
+
+				for _, expectedContent := range tc.expectedContents {
+					assert.Contains(t, content, expectedContent)
+				}
+
+				for _, unexpectedContent := range tc.unexpectedContents {
+					assert.NotContains(t, content, unexpectedContent)
+				}
 			} else {
 				assert.Error(t, err)
 
@@ -607,22 +1122,6 @@ func TestGenerator_generateExample(t *testing.T) {
 		g                    *generator
 		expectedErrorRegexes []string
 	}{
-		{
-			name: "PackageDirNotExist",
-			g: &generator{
-				UI: ui.NewNop(),
-				Params: &Params{
-					Debug: false,
-					Path:  tempDir,
-					Spec: &spec.Spec{
-						Name: "foo",
-					},
-				},
-			},
-			expectedErrorRegexes: []string{
-				`open .+/foo/example_test.go: no such file or directory`,
-			},
-		},
 		{
 			name: "Success",
 			g: &generator{
@@ -682,20 +1181,338 @@ func TestGenerator_renderTemplate(t *testing.T) {
 			expectedErrorRegex: `open templates/missing.go.tmpl: file does not exist`,
 		},
 		{
-			name: "PackageDirNotExist",
+			name: "Success",
 			g: &generator{
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: false,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name: "foo",
+						Name: "",
 					},
 				},
 			},
 			filename:           "core.go.tmpl",
 			data:               nil,
-			expectedErrorRegex: `open .+/foo/foo.go: no such file or directory`,
+			expectedErrorRegex: ``,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.g.renderTemplate(tc.filename, tc.data)
+
+			if tc.expectedErrorRegex == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+
+				re := regexp.MustCompile(tc.expectedErrorRegex)
+				assert.True(t, re.MatchString(err.Error()), "%q DOES NOT INCLUDE %q", err, tc.expectedErrorRegex)
+			}
+		})
+	}
+}
+
+func TestNewActionData(t *testing.T) {
+	p := &grammar.Production{Head: "E", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal("+"), grammar.NonTerminal("E")}}
+
+	tests := []struct {
+		name               string
+		i                  int
+		p                  *grammar.Production
+		a                  *spec.SemanticAction
+		dir                string
+		expectedData       *actionData
+		expectedErrorRegex string
+	}{
+		{
+			name: "WithoutPosition",
+			i:    0,
+			p:    p,
+			a: &spec.SemanticAction{
+				Code: " $$ = $1 ",
+				Refs: []*spec.ActionRef{
+					{Offset: 1, Length: 2, Index: 0},
+					{Offset: 6, Length: 2, Index: 1},
+				},
+			},
+			dir: "",
+			expectedData: &actionData{
+				Index:     0,
+				Params:    "_1 any, _2 string, _3 any",
+				Lexemes:   []int{2},
+				Args:      "rhs[0].Val, _2, rhs[2].Val",
+				Code:      " _0 = _1 ",
+				Directive: "",
+			},
+		},
+		{
+			name: "WithPosition",
+			i:    2,
+			p:    p,
+			a: &spec.SemanticAction{
+				Code: " $$ = $1.(int) + $3.(int) ",
+				Pos:  &lexer.Position{Filename: "test.grammar", Line: 9, Column: 16},
+				Refs: []*spec.ActionRef{
+					{Offset: 1, Length: 2, Index: 0},
+					{Offset: 6, Length: 2, Index: 1},
+					{Offset: 17, Length: 2, Index: 3},
+				},
+			},
+			dir: "",
+			expectedData: &actionData{
+				Index:     2,
+				Params:    "_1 any, _2 string, _3 any",
+				Lexemes:   []int{2},
+				Args:      "rhs[0].Val, _2, rhs[2].Val",
+				Code:      " _0 = _1.(int) + _3.(int) ",
+				Directive: "//line test.grammar:9:18",
+			},
+		},
+		{
+			name: "RelativeToPackage",
+			i:    2,
+			p:    p,
+			a: &spec.SemanticAction{
+				Code: " $$ = $2 ",
+				Pos:  &lexer.Position{Filename: "/work/grammar/test.grammar", Line: 9, Column: 16},
+				Refs: []*spec.ActionRef{
+					{Offset: 1, Length: 2, Index: 0},
+					{Offset: 6, Length: 2, Index: 2},
+				},
+			},
+			dir: "/work/out/test",
+			expectedData: &actionData{
+				Index:     2,
+				Params:    "_1 any, _2 string, _3 any",
+				Lexemes:   []int{2},
+				Args:      "rhs[0].Val, _2, rhs[2].Val",
+				Code:      " _0 = _2 ",
+				Directive: "//line ../../grammar/test.grammar:9:18",
+			},
+		},
+		{
+			name: "SyntaxError",
+			i:    2,
+			p:    p,
+			a: &spec.SemanticAction{
+				Code: " $$ = ( ",
+				Pos:  &lexer.Position{Filename: "test.grammar", Line: 9, Column: 16},
+				Refs: []*spec.ActionRef{
+					{Offset: 1, Length: 2, Index: 0},
+				},
+			},
+			dir:                "",
+			expectedErrorRegex: `syntax error in semantic action for E → E "\+" E: .+: test.grammar:9:16`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := newActionData(tc.i, tc.p, tc.a, tc.dir)
+
+			if tc.expectedErrorRegex == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedData, data)
+			} else {
+				assert.Nil(t, data)
+				assert.Error(t, err)
+
+				re := regexp.MustCompile(tc.expectedErrorRegex)
+				assert.True(t, re.MatchString(err.Error()), "%q DOES NOT INCLUDE %q", err, tc.expectedErrorRegex)
+			}
+		})
+	}
+}
+
+func TestResetLineDirectives(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		filename        string
+		expectedContent string
+	}{
+		{
+			name:            "NoDirective",
+			content:         "package foo\n",
+			filename:        "actions.go",
+			expectedContent: "package foo\n",
+		},
+		{
+			name:            "Directives",
+			content:         "package foo\n\nfunc _action1() {\n//line test.grammar:9:18\n\tcode()\n//line actions.go\n\treturn\n}\n",
+			filename:        "actions.go",
+			expectedContent: "package foo\n\nfunc _action1() {\n//line test.grammar:9:18\n\tcode()\n//line actions.go:7\n\treturn\n}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := bytes.NewBufferString(tc.content)
+			resetLineDirectives(b, tc.filename)
+			assert.Equal(t, tc.expectedContent, b.String())
+		})
+	}
+}
+
+func TestFormatConstName(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		symbol       string
+		expectedName string
+	}{
+		{
+			name:         "Token",
+			prefix:       "Term",
+			symbol:       "NUM",
+			expectedName: "TermNUM",
+		},
+		{
+			name:         "Keyword",
+			prefix:       "Term",
+			symbol:       "print",
+			expectedName: "TermPrint",
+		},
+		{
+			name:         "NotIdentifier",
+			prefix:       "Term",
+			symbol:       "+",
+			expectedName: "",
+		},
+		{
+			name:         "Underscores",
+			prefix:       "NonTerm",
+			symbol:       "expr_list",
+			expectedName: "NonTermExprList",
+		},
+		{
+			name:         "OnlyUnderscore",
+			prefix:       "NonTerm",
+			symbol:       "_",
+			expectedName: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, formatConstName(tc.prefix, tc.symbol))
+		})
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	used := map[string]bool{}
+
+	assert.Equal(t, "NonTermExprList", uniqueName(used, "NonTermExprList", 1))
+	assert.Equal(t, "NonTermExprList_2", uniqueName(used, "NonTermExprList", 2))
+	assert.Equal(t, "NonTermExprList_2_2", uniqueName(used, "NonTermExprList", 2))
+	assert.True(t, used["NonTermExprList"] && used["NonTermExprList_2"] && used["NonTermExprList_2_2"])
+}
+
+func TestGenerator_writeFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "emerge-test-")
+	assert.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tempDir))
+	}()
+
+	generated := generatedHeader + "\n\npackage bar\n"
+
+	packageDir := filepath.Join(tempDir, "bar")
+	assert.NoError(t, os.Mkdir(packageDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "bar.go"), []byte(generated), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "stale.go"), []byte(generated), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "actions.go"), []byte("package bar"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "lexer.dot"), []byte("stale"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "lexer.str.dot"), []byte("stale"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "lexer.custom.dot"), []byte("custom"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "parser.txt"), []byte("stale"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, "custom.go"), []byte("package bar"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(packageDir, manifestFilename), []byte("bar.go\nlexer.dot\nlexer.str.dot\nparser.txt\n"), 0644))
+
+	bazDir := filepath.Join(tempDir, "baz")
+	assert.NoError(t, os.Mkdir(bazDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(bazDir, "baz.go"), []byte(generatedHeader+"\nold"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(bazDir, "parser.txt"), []byte("old"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(bazDir, manifestFilename), []byte("baz.go\nparser.txt\n"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(bazDir, "zzz", "custom"), os.ModePerm))
+
+	quxDir := filepath.Join(tempDir, "qux")
+	assert.NoError(t, os.Mkdir(quxDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(quxDir, "actions.go"), []byte("package qux"), 0644))
+
+	tests := []struct {
+		name               string
+		g                  *generator
+		files              map[string]string
+		expectedFiles      map[string]string
+		expectedErrorRegex string
+	}{
+		{
+			name: "PackageDirNotExist",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "foo",
+					},
+				},
+			},
+			files: map[string]string{
+				"foo.go": "package foo",
+			},
+			expectedErrorRegex: `/foo.*: no such file or directory`,
+		},
+		{
+			name: "RollBack",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Force: true,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "baz",
+					},
+				},
+			},
+			files: map[string]string{
+				"baz.go": "package baz",
+				"zzz":    "conflicts with a non-empty directory",
+			},
+			expectedFiles: map[string]string{
+				"baz.go":         generatedHeader + "\nold",
+				"parser.txt":     "old",
+				manifestFilename: "baz.go\nparser.txt\n",
+			},
+			expectedErrorRegex: `rename .+/zzz .+/baz/zzz: file exists`,
+		},
+		{
+			name: "NotGenerated",
+			g: &generator{
+				UI: ui.NewNop(),
+				Params: &Params{
+					Debug: false,
+					Force: true,
+					Path:  tempDir,
+					Spec: &spec.Spec{
+						Name: "qux",
+					},
+				},
+			},
+			files: map[string]string{
+				"qux.go":     "package qux",
+				"actions.go": "package qux",
+			},
+			expectedFiles: map[string]string{
+				"actions.go": "package qux",
+			},
+			expectedErrorRegex: `cannot replace "actions.go": the file is not generated by emerge`,
 		},
 		{
 			name: "Success",
@@ -703,30 +1520,55 @@ func TestGenerator_renderTemplate(t *testing.T) {
 				UI: ui.NewNop(),
 				Params: &Params{
 					Debug: false,
+					Force: true,
 					Path:  tempDir,
 					Spec: &spec.Spec{
-						Name: "",
+						Name: "bar",
 					},
 				},
 			},
-			filename:           "core.go.tmpl",
-			data:               nil,
+			files: map[string]string{
+				"bar.go":          "package bar",
+				"example_test.go": "package bar_test",
+			},
+			expectedFiles: map[string]string{
+				"bar.go":           "package bar",
+				"actions.go":       "package bar",
+				"custom.go":        "package bar",
+				"example_test.go":  "package bar_test",
+				"lexer.custom.dot": "custom",
+				manifestFilename:   "bar.go\nexample_test.go\n",
+			},
 			expectedErrorRegex: ``,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.g.renderTemplate(tc.filename, tc.data)
+			for filename, content := range tc.files {
+				tc.g.output(filename).WriteString(content)
+			}
+
+			err := tc.g.writeFiles()
 
 			if tc.expectedErrorRegex == "" {
 				assert.NoError(t, err)
+
+				entries, err := os.ReadDir(filepath.Join(tc.g.Path, tc.g.Spec.Name))
+				assert.NoError(t, err)
+				assert.Len(t, entries, len(tc.expectedFiles))
 			} else {
 				assert.Error(t, err)
 
 				re := regexp.MustCompile(tc.expectedErrorRegex)
 				assert.True(t, re.MatchString(err.Error()), "%q DOES NOT INCLUDE %q", err, tc.expectedErrorRegex)
 			}
+
+			for filename, expectedContent := range tc.expectedFiles {
+				content, err := os.ReadFile(filepath.Join(tc.g.Path, tc.g.Spec.Name, filename))
+				assert.NoError(t, err)
+				assert.Equal(t, expectedContent, string(content))
+			}
 		})
 	}
 }
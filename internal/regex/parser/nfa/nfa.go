@@ -6,6 +6,7 @@ package nfa
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/moorara/algo/automata"
 	"github.com/moorara/algo/parser/combinator"
@@ -14,11 +15,27 @@ import (
 	"github.com/gardenbed/emerge/internal/regex/parser"
 )
 
+// caseInsensitiveFlag is the flag that makes a regular expression case-insensitive when it appears at the beginning.
+const caseInsensitiveFlag = "(?i)"
+
+// Parse parses a regular expression and converts it into an NFA.
 func Parse(regex string) (*automata.NFA, error) {
+	return ParseWithFragments(regex, nil)
+}
+
+// ParseWithFragments parses a regular expression that may reference regex fragments and converts it into an NFA.
+// Each fragment reference is expanded in place, as if the fragment was a group.
+func ParseWithFragments(regex string, fragments parser.Fragments) (*automata.NFA, error) {
 	m := new(mappers)
-	p := parser.New(m)
+	p := parser.New(m).WithFragments(fragments)
+
+	expr := regex
+	if strings.HasPrefix(expr, caseInsensitiveFlag) {
+		m.foldCase = true
+		expr = expr[len(caseInsensitiveFlag):]
+	}
 
-	out, err := p.Parse(regex)
+	out, err := p.Parse(expr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid regular expression: %s: %w", regex, err)
 	}
@@ -44,7 +61,10 @@ const (
 )
 
 // mappers implements the parser.Mappers interface.
-type mappers struct{}
+// If foldCase is true, every character also matches its case-folded equivalents.
+type mappers struct {
+	foldCase bool
+}
 
 func (m *mappers) ToAnyChar(r combinator.Result) (combinator.Result, error) {
 	nfa, _ := charRangesToNFA(false, char.Classes["UNICODE"])
@@ -57,7 +77,7 @@ func (m *mappers) ToAnyChar(r combinator.Result) (combinator.Result, error) {
 
 func (m *mappers) ToSingleChar(r combinator.Result) (combinator.Result, error) {
 	c := r.Val.(rune)
-	nfa, ranges := charRangesToNFA(false, char.RangeList{{c, c}})
+	nfa, ranges := m.rangesToNFA(false, char.RangeList{{c, c}})
 
 	return combinator.Result{
 		Val: nfa,
@@ -77,17 +97,17 @@ func (m *mappers) ToCharClass(r combinator.Result) (combinator.Result, error) {
 
 	switch class {
 	case `\s`:
-		nfa, ranges = charRangesToNFA(false, char.Classes[`\s`])
+		nfa, ranges = m.rangesToNFA(false, char.Classes[`\s`])
 	case `\S`:
-		nfa, ranges = charRangesToNFA(true, char.Classes[`\s`])
+		nfa, ranges = m.rangesToNFA(true, char.Classes[`\s`])
 	case `\d`:
-		nfa, ranges = charRangesToNFA(false, char.Classes[`\d`])
+		nfa, ranges = m.rangesToNFA(false, char.Classes[`\d`])
 	case `\D`:
-		nfa, ranges = charRangesToNFA(true, char.Classes[`\d`])
+		nfa, ranges = m.rangesToNFA(true, char.Classes[`\d`])
 	case `\w`:
-		nfa, ranges = charRangesToNFA(false, char.Classes[`\w`])
+		nfa, ranges = m.rangesToNFA(false, char.Classes[`\w`])
 	case `\W`:
-		nfa, ranges = charRangesToNFA(true, char.Classes[`\w`])
+		nfa, ranges = m.rangesToNFA(true, char.Classes[`\w`])
 	default:
 		return combinator.Result{}, fmt.Errorf("invalid character class: %s", class)
 	}
@@ -109,7 +129,7 @@ func (m *mappers) ToASCIICharClass(r combinator.Result) (combinator.Result, erro
 		return combinator.Result{}, fmt.Errorf("invalid ASCII character class: %s", class)
 	}
 
-	nfa, ranges := charRangesToNFA(false, ranges)
+	nfa, ranges := m.rangesToNFA(false, ranges)
 
 	return combinator.Result{
 		Val: nfa,
@@ -137,7 +157,7 @@ func (m *mappers) ToUnicodeCharClass(r combinator.Result) (combinator.Result, er
 		return combinator.Result{}, fmt.Errorf("invalid Unicode character class: %s", class)
 	}
 
-	nfa, ranges := charRangesToNFA(prop == `\P`, ranges)
+	nfa, ranges := m.rangesToNFA(prop == `\P`, ranges)
 
 	return combinator.Result{
 		Val: nfa,
@@ -218,7 +238,7 @@ func (m *mappers) ToQuantifier(r combinator.Result) (combinator.Result, error) {
 
 func (m *mappers) ToCharInGroup(r combinator.Result) (combinator.Result, error) {
 	c := r.Val.(rune)
-	nfa, ranges := charRangesToNFA(false, char.RangeList{{c, c}})
+	nfa, ranges := m.rangesToNFA(false, char.RangeList{{c, c}})
 
 	return combinator.Result{
 		Val: nfa,
@@ -240,7 +260,7 @@ func (m *mappers) ToCharRange(r combinator.Result) (combinator.Result, error) {
 		return combinator.Result{}, fmt.Errorf("invalid character range %c-%c", lo, hi)
 	}
 
-	nfa, ranges := charRangesToNFA(false, char.RangeList{{lo, hi}})
+	nfa, ranges := m.rangesToNFA(false, char.RangeList{{lo, hi}})
 
 	return combinator.Result{
 		Val: nfa,
@@ -394,6 +414,16 @@ func empty() *automata.NFA {
 	return b.Build()
 }
 
+// rangesToNFA converts a list of character ranges into an NFA.
+// If case folding is enabled, the case-folded equivalents of the characters are also accepted.
+func (m *mappers) rangesToNFA(neg bool, ranges char.RangeList) (*automata.NFA, char.RangeList) {
+	if m.foldCase {
+		ranges = ranges.FoldCase()
+	}
+
+	return charRangesToNFA(neg, ranges)
+}
+
 // charRangesToNFA converts a list of character ranges into an NFA.
 //
 // If neg is true, the NFA accepts all runes except those in the given ranges.
@@ -9,6 +9,7 @@ import (
 	"github.com/moorara/algo/parser/combinator"
 
 	"github.com/gardenbed/emerge/internal/char"
+	"github.com/gardenbed/emerge/internal/regex/parser"
 )
 
 var testNFA = map[string]*automata.NFA{
@@ -574,6 +575,9 @@ func TestParse_Sanity(t *testing.T) {
 		`-?[0-9]+(\.[0-9]+)?`,
 		`"([^\\"]|\\[\\"'tnr])*"`,
 		`(#|\/\/)[^\n\r]*|\/\*.*?\*\/`,
+		// Flags
+		`(?i)begin`,
+		`(?i)[a-z][0-9a-z_]*`,
 	}
 
 	for _, regex := range regexes {
@@ -630,6 +634,23 @@ func TestParse_Verify(t *testing.T) {
 				Build().
 				Concat(testNFA["word"].Star()),
 		},
+		{
+			regex: `(?i)[a-c]x`,
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{1}).
+				AddTransition(0, 'A', 'C', []automata.State{1}).
+				AddTransition(0, 'a', 'c', []automata.State{1}).
+				Build().
+				Concat(
+					automata.NewNFABuilder().
+						SetStart(0).
+						SetFinal([]automata.State{1}).
+						AddTransition(0, 'X', 'X', []automata.State{1}).
+						AddTransition(0, 'x', 'x', []automata.State{1}).
+						Build(),
+				),
+		},
 	}
 
 	for _, tc := range tests {
@@ -648,6 +669,57 @@ func TestParse_Verify(t *testing.T) {
 	}
 }
 
+func TestParseWithFragments(t *testing.T) {
+	fragments := parser.Fragments{
+		"DIGIT":  `[0-9]`,
+		"DIGITS": `{DIGIT}+`,
+		"HEX":    `[0-9A-Fa-f]`,
+		"ESCAPE": `\\[\\"'tnr]|\\x{HEX}{2}`,
+		"LOOP":   `{LOOP}`,
+	}
+
+	tests := []struct {
+		regex         string
+		expectedError string
+		expectedRegex string
+	}{
+		{
+			regex:         `{EXP}[0-9]`,
+			expectedError: "invalid regular expression: {EXP}[0-9]: 0: undefined fragment EXP",
+		},
+		{
+			regex:         `{LOOP}`,
+			expectedError: "invalid regular expression: {LOOP}: 0: in fragment LOOP: 0: cyclic fragment reference: LOOP -> LOOP",
+		},
+		{
+			regex:         `{DIGITS}(\.{DIGITS})?`,
+			expectedRegex: `(([0-9])+)(\.(([0-9])+))?`,
+		},
+		{
+			regex:         `"([^\\"]|{ESCAPE})*"`,
+			expectedRegex: `"([^\\"]|(\\[\\"'tnr]|\\x([0-9A-Fa-f]){2}))*"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.regex, func(t *testing.T) {
+			nfa, err := ParseWithFragments(tc.regex, fragments)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, nfa)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, nfa)
+
+				expectedNFA, err := Parse(tc.expectedRegex)
+				assert.NoError(t, err)
+				assert.True(t, nfa.Equal(expectedNFA))
+			}
+		})
+	}
+}
+
 func TestMappers_ToAnyChar(t *testing.T) {
 	tests := []MapperTest{
 		{
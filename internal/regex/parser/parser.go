@@ -3,6 +3,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	comb "github.com/moorara/algo/parser/combinator"
 )
@@ -82,6 +83,23 @@ func toLetters(r comb.Result) (comb.Result, error) {
 	}, nil
 }
 
+func toName(r comb.Result) (comb.Result, error) {
+	r0, _ := r.Get(0)
+	r1, _ := r.Get(1)
+
+	name := string(r0.Val.(rune))
+	if l, ok := r1.Val.(comb.List); ok {
+		for _, r := range l {
+			name += string(r.Val.(rune))
+		}
+	}
+
+	return comb.Result{
+		Val: name,
+		Pos: r0.Pos,
+	}, nil
+}
+
 func toEscapedChar(r comb.Result) (comb.Result, error) {
 	r0, _ := r.Get(0)
 	r1, _ := r.Get(1)
@@ -160,10 +178,20 @@ type Mappers interface {
 	ToRegex(comb.Result) (comb.Result, error)            // regex --> expr
 }
 
+// Fragments maps the names of regex fragments to their regular expressions.
+// A fragment is not a regular expression on its own;
+// it is referenced by name from other regular expressions, e.g. {DIGITS}, and expanded in place.
+type Fragments map[string]string
+
 // Parser is a parser combinator for regular expressions.
 type Parser struct {
 	m Mappers
 
+	// fragments are the regex fragments that can be referenced,
+	// and expanding is the chain of fragments currently being expanded (used for detecting cycles).
+	fragments Fragments
+	expanding []string
+
 	// Combinators
 	digit            comb.Parser
 	hexDigit         comb.Parser
@@ -185,6 +213,7 @@ type Parser struct {
 	repOp            comb.Parser
 	upperBound       comb.Parser
 	range_           comb.Parser
+	fragmentName     comb.Parser
 	repetition       comb.Parser
 	quantifier       comb.Parser
 	charInGroup      comb.Parser
@@ -303,6 +332,11 @@ func New(m Mappers) *Parser {
 		comb.ExpectRune('}'),
 	).Map(p.m.ToRange)
 
+	// fragment_name --> [A-Z] [0-9A-Z_]*
+	p.fragmentName = comb.ExpectRuneInRange('A', 'Z').CONCAT(
+		comb.ALT(comb.ExpectRuneInRange('0', '9'), comb.ExpectRuneInRange('A', 'Z'), comb.ExpectRune('_')).REP1().OPT(),
+	).Map(toName)
+
 	p.repetition = p.repOp.ALT(p.range_).Map(p.m.ToRepetition)                           // repetition --> rep_op | range
 	p.quantifier = p.repetition.CONCAT(comb.ExpectRune('?').OPT()).Map(p.m.ToQuantifier) // quantifier --> repetition lazy_modifier?
 
@@ -366,9 +400,62 @@ func (p *Parser) group(in comb.Input) (*comb.Output, error) {
 }
 
 // Recursive definition
-// subexpr_item --> group | match
+// fragment_ref --> "{" fragment_name "}" quantifier?
+//
+// A fragment reference is expanded to the regular expression of the fragment,
+// and the result is mapped the same way as a group.
+func (p *Parser) fragmentRef(in comb.Input) (*comb.Output, error) {
+	return comb.CONCAT(
+		comb.ExpectRune('{'),
+		p.fragmentName,
+		comb.ExpectRune('}'),
+		p.quantifier.OPT(),
+	).Map(p.expandFragment).Map(p.m.ToGroup)(in)
+}
+
+// expandFragment replaces the name in a fragment reference with the parsed regular expression of the fragment.
+// It fails if the fragment is not defined, if the fragment references itself directly or indirectly,
+// or if the regular expression of the fragment is invalid.
+func (p *Parser) expandFragment(r comb.Result) (comb.Result, error) {
+	r0, _ := r.Get(0)
+	r1, _ := r.Get(1)
+	r2, _ := r.Get(2)
+	r3, _ := r.Get(3)
+
+	name := r1.Val.(string)
+
+	for i, n := range p.expanding {
+		if n == name {
+			cycle := append(append([]string{}, p.expanding[i:]...), name)
+			return comb.Result{}, fmt.Errorf("cyclic fragment reference: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	regex, ok := p.fragments[name]
+	if !ok {
+		return comb.Result{}, fmt.Errorf("undefined fragment %s", name)
+	}
+
+	p.expanding = append(p.expanding, name)
+	defer func() {
+		p.expanding = p.expanding[:len(p.expanding)-1]
+	}()
+
+	out, err := p.parse(regex)
+	if err != nil {
+		return comb.Result{}, fmt.Errorf("in fragment %s: %s", name, err)
+	}
+
+	return comb.Result{
+		Val: comb.List{r0, out.Result, r2, r3},
+		Pos: r.Pos,
+	}, nil
+}
+
+// Recursive definition
+// subexpr_item --> group | fragment_ref | match
 func (p *Parser) subexprItem(in comb.Input) (*comb.Output, error) {
-	return comb.ALT(p.group, p.match).Map(p.m.ToSubexprItem)(in)
+	return comb.ALT(p.group, p.fragmentRef, p.match).Map(p.m.ToSubexprItem)(in)
 }
 
 // Recursive definition
@@ -385,8 +472,20 @@ func (p *Parser) expr(in comb.Input) (*comb.Output, error) {
 	).Map(p.m.ToExpr)(in)
 }
 
+// WithFragments sets the regex fragments that can be referenced from the regular expressions being parsed.
+func (p *Parser) WithFragments(fragments Fragments) *Parser {
+	p.fragments = fragments
+	return p
+}
+
 // Parse is the topmost parser combinator for parsing a regular expression read from the input.
 func (p *Parser) Parse(regex string) (*comb.Output, error) {
+	p.expanding = nil
+	return p.parse(regex)
+}
+
+// parse parses a regular expression and ensures that the entire input has been matched.
+func (p *Parser) parse(regex string) (*comb.Output, error) {
 	in := newStringInput(regex)
 
 	out, err := p.regex(in)
@@ -147,6 +147,60 @@ func TestToLetters(t *testing.T) {
 	}
 }
 
+func TestToName(t *testing.T) {
+	tests := []struct {
+		name           string
+		r              comb.Result
+		expectedResult comb.Result
+		expectedError  string
+	}{
+		{
+			name: "SingleLetter",
+			r: comb.Result{
+				Val: comb.List{
+					{Val: 'D', Pos: 1},
+					{Val: comb.Empty{}},
+				},
+			},
+			expectedResult: comb.Result{Val: "D", Pos: 1},
+			expectedError:  "",
+		},
+		{
+			name: "OK",
+			r: comb.Result{
+				Val: comb.List{
+					{Val: 'E', Pos: 1},
+					{
+						Val: comb.List{
+							{Val: 'S', Pos: 2},
+							{Val: 'C', Pos: 3},
+							{Val: '_', Pos: 4},
+							{Val: '2', Pos: 5},
+						},
+						Pos: 2,
+					},
+				},
+			},
+			expectedResult: comb.Result{Val: "ESC_2", Pos: 1},
+			expectedError:  "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := toName(tc.r)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, res)
+			} else {
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
 func TestToEscapedChar(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -658,6 +712,48 @@ func TestParser_letters(t *testing.T) {
 	}
 }
 
+func TestParser_fragmentName(t *testing.T) {
+	tests := []struct {
+		name          string
+		m             *mockMappers
+		in            comb.Input
+		expectedOut   *comb.Output
+		expectedError string
+	}{
+		{
+			name:          "Failure",
+			m:             &mockMappers{},
+			in:            newStringInput(`esc`),
+			expectedOut:   nil,
+			expectedError: "0: unexpected rune 'e'",
+		},
+		{
+			name: "Success",
+			m:    &mockMappers{},
+			in:   newStringInput(`ESC_2`),
+			expectedOut: &comb.Output{
+				Result: comb.Result{Val: "ESC_2", Pos: 0},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.m)
+			out, err := p.fragmentName(tc.in)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOut, out)
+			} else {
+				assert.Nil(t, out)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
 func TestParser_char(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -5237,6 +5333,101 @@ func TestParser_group(t *testing.T) {
 	}
 }
 
+func TestParser_fragmentRef(t *testing.T) {
+	tests := []struct {
+		name             string
+		m                *mockMappers
+		fragments        Fragments
+		in               comb.Input
+		expectedInResult comb.Result
+		expectedError    string
+	}{
+		{
+			name:             "Failure",
+			m:                &mockMappers{},
+			fragments:        Fragments{"A": "a"},
+			in:               newStringInput(`{a}`),
+			expectedInResult: comb.Result{},
+			expectedError:    "1: unexpected rune 'a'",
+		},
+		{
+			name:             "UndefinedFragment",
+			m:                &mockMappers{},
+			fragments:        Fragments{"A": "a"},
+			in:               newStringInput(`{B}`),
+			expectedInResult: comb.Result{},
+			expectedError:    "0: undefined fragment B",
+		},
+		{
+			name:             "CyclicFragments",
+			m:                &mockMappers{},
+			fragments:        Fragments{"A": "{B}", "B": "{A}"},
+			in:               newStringInput(`{A}`),
+			expectedInResult: comb.Result{},
+			expectedError:    "0: in fragment A: 0: in fragment B: 0: cyclic fragment reference: A -> B -> A",
+		},
+		{
+			name: "Success",
+			m: &mockMappers{
+				ToSingleCharMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToMatchItemMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToMatchMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToSubexprItemMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToSubexprMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToExprMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToRegexMocks: []MapFuncMock{
+					{OutError: nil},
+				},
+				ToGroupMocks: []MapFuncMock{
+					{},
+				},
+			},
+			fragments: Fragments{"A": "a"},
+			in:        newStringInput(`{A}`),
+			expectedInResult: comb.Result{
+				Val: comb.List{
+					{Val: '{', Pos: 0},
+					{},
+					{Val: '}', Pos: 2},
+					{Val: comb.Empty{}},
+				},
+				Pos: 0,
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.m).WithFragments(tc.fragments)
+			_, err := p.fragmentRef(tc.in)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			// Verify the expected result has been passed to the mapper function
+			if m := tc.m.ToGroupMocks; len(m) > 0 {
+				assert.Equal(t, tc.expectedInResult, m[0].InResult)
+			}
+		})
+	}
+}
+
 func TestParser_subexprItem(t *testing.T) {
 	tests := []struct {
 		name             string